@@ -0,0 +1,37 @@
+package aviatrix
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// capabilityCheck ties a controller capability to the attribute whose use
+// needs it. An empty attribute means the resource itself needs it.
+type capabilityCheck struct {
+	attribute  string
+	capability goaviatrix.Capability
+}
+
+// requireCapabilities returns a CustomizeDiff function that fails the plan
+// when the configuration uses a feature the connected controller lacks.
+func requireCapabilities(checks ...capabilityCheck) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		client := meta.(goaviatrix.ControllerAPI)
+		for _, check := range checks {
+			if check.attribute != "" {
+				if _, ok := d.GetOk(check.attribute); !ok {
+					continue
+				}
+			}
+			if err := client.RequireCapability(check.capability); err != nil {
+				if check.attribute != "" {
+					return fmt.Errorf("%q: %s", check.attribute, err)
+				}
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,43 @@
+package aviatrix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/mock"
+)
+
+// TestRequireCapabilities plans a gateway against a 4.7.419 controller,
+// which lacks the VPN connection limit.
+func TestRequireCapabilities(t *testing.T) {
+	client := &goaviatrix.Client{ControllerVersion: &goaviatrix.AviatrixVersion{Major: 4, Minor: 7, Build: 419}}
+	meta := &mock.Client{RequireCapabilityFunc: client.RequireCapability}
+	raw := map[string]interface{}{
+		"cloud_type":   1,
+		"account_name": "tfa-test",
+		"gw_name":      "tfg-vpn",
+		"vpc_id":       "vpc-0123",
+		"vpc_reg":      "us-west-1",
+		"gw_size":      "t2.micro",
+		"subnet":       "10.0.0.0/24",
+		"vpn_access":   true,
+		"vpn_cidr":     "192.168.43.0/24",
+	}
+	for _, maxVpnConn := range []string{"", "100"} {
+		raw["max_vpn_conn"] = maxVpnConn
+		c, err := config.NewRawConfig(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = resourceAviatrixGateway().Diff(nil, terraform.NewResourceConfig(c), meta)
+		if maxVpnConn == "" && err != nil {
+			t.Errorf("plan without max_vpn_conn: %s", err)
+		}
+		if maxVpnConn != "" && (err == nil || !strings.Contains(err.Error(), `"max_vpn_conn": VPN connection limit requires`)) {
+			t.Errorf("plan with max_vpn_conn: err = %v, want a capability error", err)
+		}
+	}
+}
@@ -1,19 +1,144 @@
 package aviatrix
 
 import (
-	"crypto/tls"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
 )
 
 // Config contains the configuration for the Aviatrix provider
-// (Username, Password, and Controller IP)
+// (credentials, Controller IP or URL, proxy, retry, TLS, rate limit, cache,
+// read-only, default tags and record/replay settings)
 type Config struct {
-	Username     string
-	Password     string
-	ControllerIP string
+	Username               string
+	Password               string
+	ControllerIP           string
+	ControllerURL          string
+	ProxyURL               string
+	Headers                map[string]string
+	CID                    string
+	Profile                string
+	SharedCredentialsFile  string
+	CredentialProcess      string
+	SessionCacheDir        string
+	ReadOnly               bool
+	DryRun                 bool
+	MaxRetries             int
+	RetryMaxWait           int
+	VerifySSL              bool
+	CABundle               string
+	CertificateFingerprint string
+	ClientCertificate      string
+	ClientKey              string
+	MaxConcurrentReads     int
+	MaxConcurrentWrites    int
+	ReadRateLimit          float64
+	WriteRateLimit         float64
+	CacheTTL               int
+	DefaultTags            map[string]string
+	RecordMode             goaviatrix.RecordMode
+	CassettePath           string
+}
+
+// callMetrics collects the controller calls made by every client this
+// provider process creates, for the summary logged by LogCallSummary.
+var callMetrics = goaviatrix.NewCallMetrics()
+
+// cassettes are shared by every client this provider process creates, so a
+// whole test run records to, or replays from, one file per path.
+var cassettes = struct {
+	sync.Mutex
+	byPath map[string]*goaviatrix.Cassette
+}{byPath: map[string]*goaviatrix.Cassette{}}
+
+// cassetteFor returns the cassette at path, starting a new one in record
+// mode and loading the existing one in replay mode.
+func cassetteFor(mode goaviatrix.RecordMode, path string) (*goaviatrix.Cassette, error) {
+	cassettes.Lock()
+	defer cassettes.Unlock()
+	if c, ok := cassettes.byPath[path]; ok {
+		return c, nil
+	}
+	var c *goaviatrix.Cassette
+	if mode == goaviatrix.RecordModeRecord {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		c = goaviatrix.NewCassette(path)
+	} else {
+		var err error
+		if c, err = goaviatrix.LoadCassette(path); err != nil {
+			return nil, err
+		}
+	}
+	cassettes.byPath[path] = c
+	return c, nil
+}
+
+// LogCallSummary writes the number and latency of controller calls made by
+// this provider process to the Terraform log. main calls it once the
+// provider has been shut down.
+func LogCallSummary() {
+	log.Printf("[INFO] Aviatrix controller call summary: %s", callMetrics.Summary())
+}
+
+// credentials returns the controller IP and login settings. Those not set
+// in the provider configuration or environment are read from the shared
+// credentials file profile, then from the output of the credential process.
+func (c *Config) credentials() (*goaviatrix.Credentials, error) {
+	creds := &goaviatrix.Credentials{
+		ControllerIP:      c.ControllerIP,
+		Username:          c.Username,
+		Password:          c.Password,
+		CID:               c.CID,
+		CredentialProcess: c.CredentialProcess,
+	}
+	if c.ControllerURL != "" {
+		creds.ControllerIP = c.ControllerURL
+	}
+	if credentialsComplete(creds) {
+		return creds, nil
+	}
+
+	path := c.SharedCredentialsFile
+	if path == "" {
+		var err error
+		if path, err = goaviatrix.DefaultCredentialsFile(); err != nil {
+			return nil, err
+		}
+	}
+	// The default file is optional unless a profile is asked for.
+	if _, err := os.Stat(path); err == nil || c.SharedCredentialsFile != "" || c.Profile != "" {
+		profile, err := goaviatrix.LoadProfile(path, c.Profile)
+		if err != nil {
+			return nil, err
+		}
+		creds.Merge(profile)
+	}
+
+	if !credentialsComplete(creds) && creds.CredentialProcess != "" {
+		output, err := goaviatrix.RunCredentialProcess(creds.CredentialProcess)
+		if err != nil {
+			return nil, err
+		}
+		creds.Merge(output)
+	}
+
+	if !credentialsComplete(creds) {
+		return nil, errors.New("controller_ip (or controller_url) and either username and password or cid must be set in the " +
+			"provider configuration, the environment, the shared credentials file or by credential_process")
+	}
+	return creds, nil
+}
+
+func credentialsComplete(creds *goaviatrix.Credentials) bool {
+	return creds.ControllerIP != "" && (creds.CID != "" || (creds.Username != "" && creds.Password != ""))
 }
 
 // Client gets the Aviatrix client to access the Controller
@@ -23,15 +148,74 @@ type Config struct {
 //    the aviatrix client (from goaviatrix)
 //    error (if any)
 func (c *Config) Client() (*goaviatrix.Client, error) {
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	creds, err := c.credentials()
+	if err != nil {
+		return nil, err
+	}
+	tlsOptions := &goaviatrix.TLSOptions{
+		VerifySSL:   c.VerifySSL,
+		CABundle:    c.CABundle,
+		Fingerprint: c.CertificateFingerprint,
+		ClientCert:  c.ClientCertificate,
+		ClientKey:   c.ClientKey,
+	}
+	tlsConfig, err := tlsOptions.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := goaviatrix.ProxyFunc(c.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	var tr http.RoundTripper = &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxy,
+	}
+	if len(c.Headers) != 0 {
+		tr = &goaviatrix.HeaderTransport{Headers: c.Headers, Transport: tr}
+	}
+	if c.RecordMode != "" && c.RecordMode != goaviatrix.RecordModeOff {
+		cassette, err := cassetteFor(c.RecordMode, c.CassettePath)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[INFO] Aviatrix controller traffic is in %s mode with cassette %s", c.RecordMode, c.CassettePath)
+		tr = cassette.Transport(c.RecordMode, tr)
+	}
+	var sessionCache *goaviatrix.SessionCache
+	if c.SessionCacheDir != "" {
+		if sessionCache, err = goaviatrix.NewSessionCache(c.SessionCacheDir); err != nil {
+			return nil, err
+		}
+	}
+	var client *goaviatrix.Client
+	if creds.CID != "" {
+		client, err = goaviatrix.NewClientWithCID(creds.Username, creds.Password, creds.CID, creds.ControllerIP,
+			&http.Client{Transport: tr})
+		if client != nil {
+			client.SessionCache = sessionCache
+		}
+	} else {
+		client, err = goaviatrix.NewClientWithSessionCache(creds.Username, creds.Password, creds.ControllerIP,
+			sessionCache, &http.Client{Transport: tr})
 	}
-	client, err := goaviatrix.NewClient(c.Username, c.Password, c.ControllerIP, &http.Client{Transport: tr})
 
 	log.Printf("[INFO] Aviatrix Client configured for use")
 
 	if client == nil || err != nil {
 		log.Printf("[ERROR] unable to create client: %s", err)
+		return client, err
+	}
+	client.Observer = callMetrics
+	client.ReadOnly = c.ReadOnly
+	client.DryRun = c.DryRun
+	client.DefaultTags = c.DefaultTags
+	client.RetryPolicy.MaxRetries = c.MaxRetries
+	client.RetryPolicy.MaxInterval = time.Duration(c.RetryMaxWait) * time.Second
+	client.ReadLimiter = goaviatrix.NewLimiter(c.MaxConcurrentReads, c.ReadRateLimit, c.MaxConcurrentReads)
+	client.WriteLimiter = goaviatrix.NewLimiter(c.MaxConcurrentWrites, c.WriteRateLimit, c.MaxConcurrentWrites)
+	if c.CacheTTL > 0 {
+		client.Cache = goaviatrix.NewResponseCache(time.Duration(c.CacheTTL) * time.Second)
 	}
-	return client, err
+	return client, nil
 }
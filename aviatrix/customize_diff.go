@@ -0,0 +1,51 @@
+package aviatrix
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// customizeDiffAll returns a CustomizeDiff function running funcs in
+// order. It stops at the first error.
+func customizeDiffAll(funcs ...schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		for _, f := range funcs {
+			if err := f(d, meta); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// forceNewIfChanged returns a CustomizeDiff function replacing an existing
+// resource when any of keys changes, for attributes the controller can not
+// update in place.
+func forceNewIfChanged(keys ...string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		for _, key := range keys {
+			if d.HasChange(key) {
+				if err := d.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// diffIsSet reports whether the new value of key is known and not the zero
+// value.
+func diffIsSet(d *schema.ResourceDiff, key string) bool {
+	_, ok := d.GetOk(key)
+	return ok
+}
+
+// diffIsUnset reports whether the new value of key is known to be the zero
+// value. A value interpolated from a resource that is not created yet is
+// neither set nor unset until apply.
+func diffIsUnset(d *schema.ResourceDiff, key string) bool {
+	return d.NewValueKnown(key) && !diffIsSet(d, key)
+}
@@ -48,7 +48,7 @@ func dataSourceAviatrixAccount() *schema.Resource {
 }
 
 func dataSourceAviatrixAccountRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	account := &goaviatrix.Account{
 		AccountName: d.Get("account_name").(string),
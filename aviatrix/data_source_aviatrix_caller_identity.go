@@ -23,11 +23,11 @@ func dataSourceAviatrixCallerIdentity() *schema.Resource {
 }
 
 func dataSourceAviatrixCallerIdentityRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.ControllerAPI)
 
-	log.Printf("[DEBUG] CID is '%s'", client.CID)
+	log.Printf("[DEBUG] CID is '%s'", client.GetCID())
 
 	d.SetId(time.Now().UTC().String())
-	d.Set("cid", client.CID)
+	d.Set("cid", client.GetCID())
 	return nil
 }
@@ -53,7 +53,7 @@ func dataSourceAviatrixGateway() *schema.Resource {
 }
 
 func dataSourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.GatewayAPI)
 
 	gateway := &goaviatrix.Gateway{
 		GwName: d.Get("gw_name").(string),
@@ -0,0 +1,75 @@
+package aviatrix
+
+import (
+	"log"
+	"sort"
+	"sync"
+)
+
+// mutexKV is a set of mutexes keyed by name. The controller rejects
+// concurrent changes to one transit gateway or TGW with "operation in
+// progress", so resources serialize those changes on the object's key.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+// aviatrixMutexKV is shared by every resource of this provider process
+var aviatrixMutexKV = newMutexKV()
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{store: map[string]*sync.Mutex{}}
+}
+
+// Lock locks the mutexes of keys. They are taken in sorted order, so two
+// callers locking the same keys can not deadlock.
+func (m *mutexKV) Lock(keys ...string) {
+	for _, key := range sortedUnique(keys) {
+		log.Printf("[DEBUG] Locking %q", key)
+		m.get(key).Lock()
+		log.Printf("[DEBUG] Locked %q", key)
+	}
+}
+
+// Unlock unlocks the mutexes of keys
+func (m *mutexKV) Unlock(keys ...string) {
+	for _, key := range sortedUnique(keys) {
+		m.get(key).Unlock()
+		log.Printf("[DEBUG] Unlocked %q", key)
+	}
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+func sortedUnique(keys []string) []string {
+	sorted := make([]string, 0, len(keys))
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// transitGatewayMutexKey is the key of changes to a transit gateway's
+// spokes, peerings and VGW connections.
+func transitGatewayMutexKey(gwName string) string {
+	return "transit_gateway/" + gwName
+}
+
+// awsTgwMutexKey is the key of changes to an AWS TGW's attachments
+func awsTgwMutexKey(tgwName string) string {
+	return "aws_tgw/" + tgwName
+}
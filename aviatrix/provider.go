@@ -6,9 +6,17 @@ import (
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
 )
 
-const supportedVersion = "4.7"
+// supportedVersions are the controller versions this provider works with:
+// 4.6, the oldest release the provider was tested against before 4.7, and
+// later 4.x releases. Features that need a newer controller are checked per
+// resource with requireCapabilities.
+var supportedVersions = goaviatrix.VersionRange{
+	Min: goaviatrix.AviatrixVersion{Major: 4, Minor: 6},
+	Max: goaviatrix.AviatrixVersion{Major: 5, Minor: 0},
+}
 
 // Provider returns a schema.Provider for Aviatrix.
 func Provider() terraform.ResourceProvider {
@@ -16,24 +24,171 @@ func Provider() terraform.ResourceProvider {
 		Schema: map[string]*schema.Schema{
 			"controller_ip": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: envDefaultFunc("AVIATRIX_CONTROLLER_IP"),
 			},
+			"controller_url": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   envDefaultFunc("AVIATRIX_CONTROLLER_URL"),
+				ConflictsWith: []string{"controller_ip"},
+				Description:   "URL of the controller, with scheme and optional port and base path, instead of controller_ip.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_PROXY_URL"),
+				Description: "Proxy to reach the controller through. Defaults to the HTTPS_PROXY and NO_PROXY environment variables.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "HTTP headers sent with every request to the controller.",
+			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: envDefaultFunc("AVIATRIX_USERNAME"),
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: envDefaultFunc("AVIATRIX_PASSWORD"),
 			},
+			"cid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_CID"),
+				Description: "CID of a controller session already logged in to, used instead of logging in.",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_PROFILE"),
+				Description: "Profile in the shared credentials file to read missing credentials from.",
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_SHARED_CREDENTIALS_FILE"),
+				Description: "Path of the shared credentials file. Defaults to ~/.aviatrix/credentials.",
+			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_CREDENTIAL_PROCESS"),
+				Description: "Command printing the credentials as JSON, run when they are not set otherwise.",
+			},
+			"session_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: envDefaultFunc("AVIATRIX_SESSION_CACHE_DIR"),
+				Description: "Directory to keep controller sessions in between runs. Empty disables the cache.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse every controller action that could change the controller.",
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Log and fail, instead of sending, every controller action that could change the controller.",
+			},
 			"skip_version_validation": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "Maximum number of times a failed controller action is retried.",
+			},
+			"retry_max_wait": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     60,
+				Description: "Maximum wait in seconds between two retries of a controller action.",
+			},
+			"verify_ssl": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Verify the controller's TLS certificate chain and host name.",
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded CA certificates, or the path of a PEM file, used to verify the controller.",
+			},
+			"certificate_fingerprint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Hex SHA-256 fingerprint the controller's certificate must match.",
+			},
+			"client_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded client certificate, or the path of a PEM file, presented to the controller.",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of client_certificate, or the path of a PEM file.",
+			},
+			"max_concurrent_reads": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "Maximum number of list_/get_ controller actions in flight at once. 0 means no limit.",
+			},
+			"max_concurrent_writes": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Maximum number of mutating controller actions in flight at once. 0 means no limit.",
+			},
+			"read_rate_limit": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of list_/get_ controller actions started per second. 0 means no limit.",
+			},
+			"write_rate_limit": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of mutating controller actions started per second. 0 means no limit.",
+			},
+			"cache_ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Seconds to reuse list responses from the controller within one run. 0 disables the cache.",
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags added to every gateway, transit gateway, spoke gateway and VPC. Tags set on a resource take precedence.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Map of tags.",
+						},
+					},
+				},
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -82,11 +237,69 @@ func envDefaultFunc(k string) schema.SchemaDefaultFunc {
 	}
 }
 
-func aviatrixConfigure(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
+// defaultCassettePath is where AVIATRIX_RECORD_MODE records to and replays
+// from when AVIATRIX_CASSETTE is not set.
+const defaultCassettePath = "testdata/controller.cassette.json"
+
+func newConfig(d *schema.ResourceData) (Config, error) {
+	recordMode, err := goaviatrix.ParseRecordMode(os.Getenv("AVIATRIX_RECORD_MODE"))
+	if err != nil {
+		return Config{}, err
+	}
+	cassettePath := os.Getenv("AVIATRIX_CASSETTE")
+	if cassettePath == "" {
+		cassettePath = defaultCassettePath
+	}
+	headers := map[string]string{}
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		headers[k] = v.(string)
+	}
+	defaultTags := map[string]string{}
+	if v, ok := d.GetOk("default_tags.0.tags"); ok {
+		for k, v := range v.(map[string]interface{}) {
+			defaultTags[k] = v.(string)
+		}
+	}
+	return Config{
 		ControllerIP: d.Get("controller_ip").(string),
 		Username:     d.Get("username").(string),
 		Password:     d.Get("password").(string),
+		CID:          d.Get("cid").(string),
+		MaxRetries:   d.Get("max_retries").(int),
+		RetryMaxWait: d.Get("retry_max_wait").(int),
+
+		ControllerURL:         d.Get("controller_url").(string),
+		ProxyURL:              d.Get("proxy_url").(string),
+		Headers:               headers,
+		Profile:               d.Get("profile").(string),
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
+		CredentialProcess:     d.Get("credential_process").(string),
+		SessionCacheDir:       d.Get("session_cache_dir").(string),
+		ReadOnly:              d.Get("read_only").(bool),
+		DryRun:                d.Get("dry_run").(bool),
+
+		VerifySSL:              d.Get("verify_ssl").(bool),
+		CABundle:               d.Get("ca_bundle").(string),
+		CertificateFingerprint: d.Get("certificate_fingerprint").(string),
+		ClientCertificate:      d.Get("client_certificate").(string),
+		ClientKey:              d.Get("client_key").(string),
+
+		MaxConcurrentReads:  d.Get("max_concurrent_reads").(int),
+		MaxConcurrentWrites: d.Get("max_concurrent_writes").(int),
+		ReadRateLimit:       d.Get("read_rate_limit").(float64),
+		WriteRateLimit:      d.Get("write_rate_limit").(float64),
+		CacheTTL:            d.Get("cache_ttl").(int),
+		DefaultTags:         defaultTags,
+
+		RecordMode:   recordMode,
+		CassettePath: cassettePath,
+	}, nil
+}
+
+func aviatrixConfigure(d *schema.ResourceData) (interface{}, error) {
+	config, err := newConfig(d)
+	if err != nil {
+		return nil, err
 	}
 
 	skipVersionValidation := d.Get("skip_version_validation").(bool)
@@ -99,7 +312,7 @@ func aviatrixConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
-	err = client.ControllerVersionValidation(supportedVersion)
+	err = client.ControllerVersionValidation(supportedVersions)
 	if err != nil {
 		return nil, errors.New("controller version validation failed: " + err.Error())
 	}
@@ -108,10 +321,9 @@ func aviatrixConfigure(d *schema.ResourceData) (interface{}, error) {
 }
 
 func aviatrixConfigureWithoutVersionValidation(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
-		ControllerIP: d.Get("controller_ip").(string),
-		Username:     d.Get("username").(string),
-		Password:     d.Get("password").(string),
+	config, err := newConfig(d)
+	if err != nil {
+		return nil, err
 	}
 
 	return config.Client()
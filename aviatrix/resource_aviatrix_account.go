@@ -26,9 +26,10 @@ func resourceAviatrixAccount() *schema.Resource {
 				Description: "Account name. This can be used for logging in to CloudN console or UserConnect controller.",
 			},
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateCloudType(goaviatrix.AWS, goaviatrix.GCP, goaviatrix.ARM),
+				Description:  "Type of cloud service provider.",
 			},
 			"aws_account_number": {
 				Type:        schema.TypeString,
@@ -99,7 +100,7 @@ func resourceAviatrixAccount() *schema.Resource {
 }
 
 func resourceAviatrixAccountCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	account := &goaviatrix.Account{
 		AccountName:                           d.Get("account_name").(string),
@@ -204,7 +205,7 @@ func resourceAviatrixAccountCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixAccountRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	accountName := d.Get("account_name").(string)
 	if accountName == "" {
@@ -255,7 +256,7 @@ func resourceAviatrixAccountRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixAccountUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	account := &goaviatrix.Account{
 		AccountName:                           d.Get("account_name").(string),
@@ -379,7 +380,7 @@ func resourceAviatrixAccountUpdate(d *schema.ResourceData, meta interface{}) err
 
 //for now, deleteing gcp account will not delete the credential file
 func resourceAviatrixAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 	account := &goaviatrix.Account{
 		AccountName: d.Get("account_name").(string),
 	}
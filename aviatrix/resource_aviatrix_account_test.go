@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -50,7 +49,7 @@ func preAccountCheck(t *testing.T, msgEnd string) {
 func TestAccAviatrixAccount_basic(t *testing.T) {
 	var account goaviatrix.Account
 
-	rInt := acctest.RandInt()
+	rInt := testAccRandInt()
 	importStateVerifyIgnore := []string{"aws_secret_key"}
 
 	skipAcc := os.Getenv("SKIP_ACCOUNT")
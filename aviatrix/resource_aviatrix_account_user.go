@@ -46,7 +46,7 @@ func resourceAviatrixAccountUser() *schema.Resource {
 }
 
 func resourceAviatrixAccountUserCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	user := &goaviatrix.AccountUser{
 		AccountName: d.Get("account_name").(string),
@@ -69,7 +69,7 @@ func resourceAviatrixAccountUserCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixAccountUserRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	userName := d.Get("username").(string)
 	accountName := d.Get("account_name").(string)
@@ -107,7 +107,7 @@ func resourceAviatrixAccountUserRead(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAviatrixAccountUserUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	user := &goaviatrix.AccountUserEdit{
 		AccountName: d.Get("account_name").(string),
@@ -145,7 +145,7 @@ func resourceAviatrixAccountUserUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixAccountUserDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.AccountAPI)
 
 	user := &goaviatrix.AccountUser{
 		AccountName: d.Get("account_name").(string),
@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -14,7 +13,7 @@ import (
 func TestAccAviatrixAccountUser_basic(t *testing.T) {
 	var account goaviatrix.AccountUser
 
-	rInt := acctest.RandInt()
+	rInt := testAccRandInt()
 	resourceName := "aviatrix_account_user.foo"
 	importStateVerifyIgnore := []string{"password"}
 
@@ -72,7 +72,7 @@ func resourceAviatrixARMPeer() *schema.Resource {
 }
 
 func resourceAviatrixARMPeerCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	armPeer := &goaviatrix.ARMPeer{
 		AccountName1: d.Get("account_name1").(string),
@@ -95,7 +95,7 @@ func resourceAviatrixARMPeerCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixARMPeerRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	vNet1 := d.Get("vnet_name_resource_group1").(string)
 	vNet2 := d.Get("vnet_name_resource_group2").(string)
@@ -143,7 +143,7 @@ func resourceAviatrixARMPeerRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixARMPeerDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	armPeer := &goaviatrix.ARMPeer{
 		VNet1: d.Get("vnet_name_resource_group1").(string),
@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -35,7 +34,7 @@ func preARMPeerCheck(t *testing.T, msgCommon string) (string, string, string, st
 func TestAccAviatrixARMPeer_basic(t *testing.T) {
 	var armPeer goaviatrix.ARMPeer
 
-	rInt := acctest.RandInt()
+	rInt := testAccRandInt()
 	resourceName := "aviatrix_arm_peer.test_arm_peer"
 
 	skipAcc := os.Getenv("SKIP_ARM_PEER")
@@ -86,7 +86,7 @@ func resourceAviatrixAWSPeer() *schema.Resource {
 }
 
 func resourceAviatrixAWSPeerCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	awsPeer := &goaviatrix.AWSPeer{
 		AccountName1: d.Get("account_name1").(string),
@@ -116,7 +116,7 @@ func resourceAviatrixAWSPeerCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixAWSPeerRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	vpcID1 := d.Get("vpc_id1").(string)
 	vpcID2 := d.Get("vpc_id2").(string)
@@ -164,7 +164,7 @@ func resourceAviatrixAWSPeerRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixAWSPeerDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 	awsPeer := &goaviatrix.AWSPeer{
 		VpcID1: d.Get("vpc_id1").(string),
 		VpcID2: d.Get("vpc_id2").(string),
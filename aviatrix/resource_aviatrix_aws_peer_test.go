@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -35,7 +34,7 @@ func preAWSPeerCheck(t *testing.T, msgCommon string) (string, string, string, st
 func TestAccAviatrixAWSPeer_basic(t *testing.T) {
 	var awsPeer goaviatrix.AWSPeer
 
-	rInt := acctest.RandInt()
+	rInt := testAccRandInt()
 	resourceName := "aviatrix_aws_peer.test_aws_peer"
 
 	skipAcc := os.Getenv("SKIP_AWS_PEER")
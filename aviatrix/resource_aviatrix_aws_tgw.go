@@ -3,6 +3,7 @@ package aviatrix
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -17,6 +18,10 @@ func resourceAviatrixAWSTgw() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAviatrixAWSTgwMigrateState,
@@ -38,9 +43,10 @@ func resourceAviatrixAWSTgw() *schema.Resource {
 				Description: "Region of cloud provider.",
 			},
 			"aws_side_as_number": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "BGP Local ASN (Autonomous System Number), Integer between 1-65535.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateASN,
+				Description:  "BGP Local ASN (Autonomous System Number), Integer between 1-65535.",
 			},
 			"security_domains": {
 				Type:        schema.TypeList,
@@ -106,7 +112,7 @@ func resourceAviatrixAWSTgw() *schema.Resource {
 }
 
 func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 
 	awsTgw := &goaviatrix.AWSTgw{
 		Name:                      d.Get("tgw_name").(string),
@@ -232,6 +238,11 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 	flag := false
 	defer resourceAviatrixAWSTgwReadIfRequired(d, meta, &flag)
 
+	err = client.WaitForAWSTgwAvailable(awsTgw, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("failed to create AWS TGW: %s", err)
+	}
+
 	for i := range domainsToCreate {
 		securityDomain := &goaviatrix.SecurityDomain{
 			Name:        domainsToCreate[i],
@@ -280,7 +291,9 @@ func resourceAviatrixAWSTgwCreate(d *schema.ResourceData, meta interface{}) erro
 				AccountName: attachedVPCAll[i][2],
 				VpcID:       attachedVPCAll[i][1],
 			}
+			aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgw.Name))
 			err := client.AttachVpcToAWSTgw(awsTgw, vpcSolo, attachedVPCAll[i][0])
+			aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgw.Name))
 			if err != nil {
 				return fmt.Errorf("failed to attach VPC: %s", err)
 			}
@@ -299,7 +312,7 @@ func resourceAviatrixAWSTgwReadIfRequired(d *schema.ResourceData, meta interface
 }
 
 func resourceAviatrixAWSTgwRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 
 	tgwName := d.Get("tgw_name").(string)
 	if tgwName == "" {
@@ -447,7 +460,7 @@ func resourceAviatrixAWSTgwRead(d *schema.ResourceData, meta interface{}) error
 func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[INFO] Updating AWS TGW")
 
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 	awsTgw := &goaviatrix.AWSTgw{
 		Name:        d.Get("tgw_name").(string),
 		AccountName: d.Get("account_name").(string),
@@ -711,7 +724,9 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 	if manageVpcAttachment {
 		for i := range toDetachVPCs {
 			if len(toDetachVPCs[i]) == 4 {
+				aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgw.Name))
 				err := client.DetachVpcFromAWSTgw(awsTgw, toDetachVPCs[i][1])
+				aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgw.Name))
 				if err != nil {
 					resourceAviatrixAWSTgwRead(d, meta)
 					return fmt.Errorf("failed to detach VPC: %s", err)
@@ -743,7 +758,9 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 
 				res, _ := client.IsVpcAttachedToTgw(awsTgw, &vpcSolo)
 				if !res {
+					aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgw.Name))
 					err := client.AttachVpcToAWSTgw(awsTgw, vpcSolo, toAttachVPCs[i][0])
+					aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgw.Name))
 					if err != nil {
 						resourceAviatrixAWSTgwRead(d, meta)
 						return fmt.Errorf("failed to attach VPC: %s", err)
@@ -774,7 +791,7 @@ func resourceAviatrixAWSTgwUpdate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAviatrixAWSTgwDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 	awsTgw := &goaviatrix.AWSTgw{
 		Name:                      d.Get("tgw_name").(string),
 		AccountName:               d.Get("account_name").(string),
@@ -803,7 +820,9 @@ func resourceAviatrixAWSTgwDelete(d *schema.ResourceData, meta interface{}) erro
 		}
 		for i := range attachedVPCs {
 			if len(attachedVPCs[i]) == 4 {
+				aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgw.Name))
 				err := client.DetachVpcFromAWSTgw(awsTgw, attachedVPCs[i][1])
+				aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgw.Name))
 				if err != nil {
 					resourceAviatrixAWSTgwRead(d, meta)
 					return fmt.Errorf("failed to detach VPC: %s", err)
@@ -838,5 +857,10 @@ func resourceAviatrixAWSTgwDelete(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("couldn't find AWS TGW: %s", err)
 	}
 
+	err = client.WaitForAWSTgwDeleted(awsTgw, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return fmt.Errorf("failed to delete AWS TGW: %s", err)
+	}
+
 	return nil
 }
@@ -51,7 +51,7 @@ func resourceAviatrixAwsTgwVpcAttachment() *schema.Resource {
 }
 
 func resourceAviatrixAwsTgwVpcAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 
 	awsTgwVpcAttachment := &goaviatrix.AwsTgwVpcAttachment{
 		TgwName:            d.Get("tgw_name").(string),
@@ -63,7 +63,9 @@ func resourceAviatrixAwsTgwVpcAttachmentCreate(d *schema.ResourceData, meta inte
 
 	log.Printf("[INFO] Attaching vpc: %s to tgw %s", awsTgwVpcAttachment.VpcID, awsTgwVpcAttachment.TgwName)
 
+	aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgwVpcAttachment.TgwName))
 	err := client.CreateAwsTgwVpcAttachment(awsTgwVpcAttachment)
+	aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgwVpcAttachment.TgwName))
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix Aws Tgw Vpc Attach: %s", err)
 	}
@@ -73,7 +75,7 @@ func resourceAviatrixAwsTgwVpcAttachmentCreate(d *schema.ResourceData, meta inte
 }
 
 func resourceAviatrixAwsTgwVpcAttachmentRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 
 	tgwName := d.Get("tgw_name").(string)
 	securityDomainName := d.Get("security_domain_name").(string)
@@ -133,7 +135,7 @@ func resourceAviatrixAwsTgwVpcAttachmentUpdate(d *schema.ResourceData, meta inte
 }
 
 func resourceAviatrixAwsTgwVpcAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 
 	awsTgwVpcAttachment := &goaviatrix.AwsTgwVpcAttachment{
 		TgwName:            d.Get("tgw_name").(string),
@@ -145,7 +147,9 @@ func resourceAviatrixAwsTgwVpcAttachmentDelete(d *schema.ResourceData, meta inte
 
 	log.Printf("[INFO] Detaching vpc: %s from tgw %s", awsTgwVpcAttachment.VpcID, awsTgwVpcAttachment.TgwName)
 
+	aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgwVpcAttachment.TgwName))
 	err := client.DeleteAwsTgwVpcAttachment(awsTgwVpcAttachment)
+	aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgwVpcAttachment.TgwName))
 	if err != nil {
 		return fmt.Errorf("failed to detach vpc from tgw: %s", err)
 	}
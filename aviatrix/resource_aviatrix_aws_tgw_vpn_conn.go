@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -18,6 +17,9 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: requireCapabilities(
+			capabilityCheck{capability: goaviatrix.CapabilityAwsTgwVpnConn},
+		),
 
 		Schema: map[string]*schema.Schema{
 			"tgw_name": {
@@ -39,28 +41,32 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 				Description: "Unique name of the connection.",
 			},
 			"public_ip": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Public IP address. Example: '40.0.0.0'.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIPAddress,
+				Description:  "Public IP address. Example: '40.0.0.0'.",
 			},
 			"remote_as_number": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "AWS side as a number. Integer between 1-65535. Example: '12'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateASN,
+				Description:  "AWS side as a number. Integer between 1-65535. Example: '12'.",
 			},
 			"remote_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Remote CIDRs joined as a string with ','.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Remote CIDRs joined as a string with ','.",
 			},
 			"inside_ip_cidr_tun_1": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Inside IP CIDR for Tunnel 1. A /30 CIDR in 169.254.0.0/16.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Inside IP CIDR for Tunnel 1. A /30 CIDR in 169.254.0.0/16.",
 			},
 			"pre_shared_key_tun_1": {
 				Type:      schema.TypeString,
@@ -71,10 +77,11 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 					"underscore(_) and dot(.). It cannot start with 0",
 			},
 			"inside_ip_cidr_tun_2": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Inside IP CIDR for Tunnel 2. A /30 CIDR in 169.254.0.0/16.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Inside IP CIDR for Tunnel 2. A /30 CIDR in 169.254.0.0/16.",
 			},
 			"pre_shared_key_tun_2": {
 				Type:      schema.TypeString,
@@ -94,7 +101,7 @@ func resourceAviatrixAwsTgwVpnConn() *schema.Resource {
 }
 
 func resourceAviatrixAwsTgwVpnConnCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 
 	awsTgwVpnConn := &goaviatrix.AwsTgwVpnConn{
 		TgwName:          d.Get("tgw_name").(string),
@@ -128,7 +135,9 @@ func resourceAviatrixAwsTgwVpnConnCreate(d *schema.ResourceData, meta interface{
 
 	log.Printf("[INFO] Creating Aviatrix AWS TGW VPN Connection: %#v", awsTgwVpnConn)
 
+	aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgwVpnConn.TgwName))
 	vpnID, err := client.CreateAwsTgwVpnConn(awsTgwVpnConn)
+	aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgwVpnConn.TgwName))
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix AWS TGW VPN Connection: %s", err)
 	}
@@ -138,7 +147,7 @@ func resourceAviatrixAwsTgwVpnConnCreate(d *schema.ResourceData, meta interface{
 }
 
 func resourceAviatrixAwsTgwVpnConnRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 
 	tgwName := d.Get("tgw_name").(string)
 	vpnID := d.Get("vpn_id").(string)
@@ -188,7 +197,7 @@ func resourceAviatrixAwsTgwVpnConnRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixAwsTgwVpnConnDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TGWAPI)
 	awsTgwVpnConn := &goaviatrix.AwsTgwVpnConn{
 		TgwName: d.Get("tgw_name").(string),
 		VpnID:   d.Get("vpn_id").(string),
@@ -196,17 +205,21 @@ func resourceAviatrixAwsTgwVpnConnDelete(d *schema.ResourceData, meta interface{
 
 	log.Printf("[INFO] Deleting Aviatrix aws_tgw_vpn_conn: %#v", awsTgwVpnConn)
 
+	aviatrixMutexKV.Lock(awsTgwMutexKey(awsTgwVpnConn.TgwName))
 	err := client.DeleteAwsTgwVpnConn(awsTgwVpnConn)
-
-	time.Sleep(40 * time.Second)
-
+	aviatrixMutexKV.Unlock(awsTgwMutexKey(awsTgwVpnConn.TgwName))
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if goaviatrix.IsNotFound(err) {
 			return nil
 		}
 
 		return fmt.Errorf("failed to delete Aviatrix AwsTgwVpnConn: %s", err)
 	}
 
+	err = client.WaitForAwsTgwVpnConnDeleted(awsTgwVpnConn, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return fmt.Errorf("failed to delete Aviatrix AwsTgwVpnConn: %s", err)
+	}
+
 	return nil
 }
@@ -19,6 +19,10 @@ func resourceAviatrixControllerConfig() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"sg_management_account_name": {
@@ -63,7 +67,7 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 
 	account := d.Get("sg_management_account_name").(string)
 
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.ControllerAPI)
 
 	log.Printf("[INFO] Configuring Aviatrix controller : %#v", d)
 
@@ -74,7 +78,9 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 			log.Printf("[INFO] Http Access is already enabled")
 		} else {
 			err = client.EnableHttpAccess()
-			time.Sleep(10 * time.Second)
+			if err == nil {
+				err = client.WaitForHttpAccess(true, d.Timeout(schema.TimeoutCreate))
+			}
 		}
 	} else {
 		curStatus, _ := client.GetHttpAccessEnabled()
@@ -82,7 +88,9 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 			log.Printf("[INFO] Http Access is already disabled")
 		} else {
 			err = client.DisableHttpAccess()
-			time.Sleep(10 * time.Second)
+			if err == nil {
+				err = client.WaitForHttpAccess(false, d.Timeout(schema.TimeoutCreate))
+			}
 		}
 	}
 	if err != nil {
@@ -137,17 +145,21 @@ func resourceAviatrixControllerConfigCreate(d *schema.ResourceData, meta interfa
 		if err != nil {
 			return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
 		}
+		err = client.WaitForVersion(version.Version, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
+		}
 
 		newCurrent, _, _ := client.GetCurrentVersion()
 		log.Printf("Upgrade complete (now %s)", newCurrent)
 	}
 
-	d.SetId(strings.Replace(client.ControllerIP, ".", "-", -1))
+	d.SetId(strings.Replace(client.GetControllerIP(), ".", "-", -1))
 	return resourceAviatrixControllerConfigRead(d, meta)
 }
 
 func resourceAviatrixControllerConfigRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.ControllerAPI)
 
 	log.Printf("[INFO] Getting controller %s configuration", d.Id())
 	result, err := client.GetHttpAccessEnabled()
@@ -207,12 +219,12 @@ func resourceAviatrixControllerConfigRead(d *schema.ResourceData, meta interface
 
 	d.Set("version", current)
 
-	d.SetId(strings.Replace(client.ControllerIP, ".", "-", -1))
+	d.SetId(strings.Replace(client.GetControllerIP(), ".", "-", -1))
 	return nil
 }
 
 func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.ControllerAPI)
 	account := d.Get("sg_management_account_name").(string)
 
 	log.Printf("[INFO] Updating Controller configuration: %#v", d)
@@ -222,18 +234,22 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 		httpAccess := d.Get("http_access").(bool)
 		if httpAccess {
 			err := client.EnableHttpAccess()
-			time.Sleep(10 * time.Second)
 			if err != nil {
 				log.Printf("[ERROR] Failed to enable http access on controller %s", d.Id())
 				return err
 			}
+			if err := client.WaitForHttpAccess(true, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("failed to enable http access on controller: %s", err)
+			}
 		} else {
 			err := client.DisableHttpAccess()
-			time.Sleep(10 * time.Second)
 			if err != nil {
 				log.Printf("[ERROR] Failed to disable http access on controller %s", d.Id())
 				return err
 			}
+			if err := client.WaitForHttpAccess(false, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("failed to disable http access on controller: %s", err)
+			}
 		}
 		d.SetPartial("http_access")
 	}
@@ -292,6 +308,10 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 						if err != nil {
 							return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
 						}
+						err = client.WaitForVersion(version.Version, d.Timeout(schema.TimeoutUpdate))
+						if err != nil {
+							return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
+						}
 						break
 					}
 				}
@@ -301,6 +321,10 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 			if err != nil {
 				return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
 			}
+			err = client.WaitForVersion(version.Version, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return fmt.Errorf("failed to upgrade Aviatrix Controller: %s", err)
+			}
 		}
 		d.SetPartial("target_version")
 	}
@@ -310,16 +334,18 @@ func resourceAviatrixControllerConfigUpdate(d *schema.ResourceData, meta interfa
 }
 
 func resourceAviatrixControllerConfigDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.ControllerAPI)
 	d.Set("http_access", false)
 	curStatusHttp, _ := client.GetHttpAccessEnabled()
 	if curStatusHttp != "Disabled" {
 		err := client.DisableHttpAccess()
-		time.Sleep(10 * time.Second)
 		if err != nil {
 			log.Printf("[ERROR] Failed to disable http access on controller %s", d.Id())
 			return err
 		}
+		if err := client.WaitForHttpAccess(false, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("failed to disable http access on controller: %s", err)
+		}
 	}
 
 	d.Set("fqdn_exception_rule", true)
@@ -25,10 +25,11 @@ func resourceAviatrixFirewall() *schema.Resource {
 				Description: "The name of gateway.",
 			},
 			"base_policy": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "deny-all",
-				Description: "New base policy.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "deny-all",
+				ValidateFunc: validateStringInSlice([]string{"allow-all", "deny-all"}),
+				Description:  "New base policy.",
 			},
 			"base_log_enabled": {
 				Type:        schema.TypeBool,
@@ -53,19 +54,22 @@ func resourceAviatrixFirewall() *schema.Resource {
 							Description: "CIDRs separated by comma or tag names such 'HR' or 'marketing' etc.",
 						},
 						"protocol": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "'all', 'tcp', 'udp', 'icmp', 'sctp', 'rdp', 'dccp'.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringInSlice(goaviatrix.PolicyProtocols),
+							Description:  "'all', 'tcp', 'udp', 'icmp', 'sctp', 'rdp', 'dccp'.",
 						},
 						"port": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "A single port or a range of port numbers.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateAny(validatePort, validateStringInSlice([]string{""})),
+							Description:  "A single port or a range of port numbers.",
 						},
 						"action": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Valid values: 'allow' and 'deny'.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringInSlice([]string{"allow", "deny"}),
+							Description:  "Valid values: 'allow' and 'deny'.",
 						},
 						"log_enabled": {
 							Type:        schema.TypeBool,
@@ -80,7 +84,7 @@ func resourceAviatrixFirewall() *schema.Resource {
 }
 
 func resourceAviatrixFirewallCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	firewall := &goaviatrix.Firewall{
 		GwName:     d.Get("gw_name").(string),
@@ -146,7 +150,7 @@ func resourceAviatrixFirewallCreate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAviatrixFirewallRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	gwName := d.Get("gw_name").(string)
 	if gwName == "" {
@@ -211,7 +215,7 @@ func resourceAviatrixFirewallRead(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAviatrixFirewallUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	firewall := &goaviatrix.Firewall{
 		GwName: d.Get("gw_name").(string),
@@ -303,7 +307,7 @@ func resourceAviatrixFirewallUpdate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAviatrixFirewallDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	firewall := &goaviatrix.Firewall{
 		GwName: d.Get("gw_name").(string),
@@ -36,9 +36,10 @@ func resourceAviatrixFirewallTag() *schema.Resource {
 							Description: "The name attribute of a policy.",
 						},
 						"cidr": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The CIDR attribute of a policy.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCIDR,
+							Description:  "The CIDR attribute of a policy.",
 						},
 					},
 				},
@@ -48,7 +49,7 @@ func resourceAviatrixFirewallTag() *schema.Resource {
 }
 
 func resourceAviatrixFirewallTagCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	firewallTag := &goaviatrix.FirewallTag{
 		Name: d.Get("firewall_tag").(string),
@@ -93,7 +94,7 @@ func resourceAviatrixFirewallTagCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixFirewallTagRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	fTag := d.Get("firewall_tag").(string)
 	if fTag == "" {
@@ -136,7 +137,7 @@ func resourceAviatrixFirewallTagRead(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAviatrixFirewallTagUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	firewallTag := &goaviatrix.FirewallTag{
 		Name: d.Get("firewall_tag").(string),
@@ -171,7 +172,7 @@ func resourceAviatrixFirewallTagUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixFirewallTagDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FirewallAPI)
 
 	firewallTag := &goaviatrix.FirewallTag{
 		Name: d.Get("firewall_tag").(string),
@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -14,7 +13,7 @@ import (
 func TestAccAviatrixFirewallTag_basic(t *testing.T) {
 	var ftag goaviatrix.FirewallTag
 
-	rInt := acctest.RandInt()
+	rInt := testAccRandInt()
 	resourceName := "aviatrix_firewall_tag.foo"
 
 	skipAcc := os.Getenv("SKIP_FIREWALL_TAG")
@@ -33,9 +33,10 @@ func resourceAviatrixFQDN() *schema.Resource {
 				Description: "FQDN Filter Tag Status. Valid values: true or false.",
 			},
 			"fqdn_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Specify the tag color to be a white-list tag or black-list tag. 'white' or 'black'",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice([]string{"white", "black"}),
+				Description:  "Specify the tag color to be a white-list tag or black-list tag. 'white' or 'black'",
 			},
 			"gw_filter_tag_list": {
 				Type:        schema.TypeList,
@@ -69,14 +70,16 @@ func resourceAviatrixFQDN() *schema.Resource {
 							Description: "FQDN.",
 						},
 						"proto": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Protocol.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringInSlice([]string{"all", "tcp", "udp", "icmp"}),
+							Description:  "Protocol.",
 						},
 						"port": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Port.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateAny(validatePort, validateStringInSlice([]string{"all", "ping"})),
+							Description:  "Port.",
 						},
 					},
 				},
@@ -86,7 +89,7 @@ func resourceAviatrixFQDN() *schema.Resource {
 }
 
 func resourceAviatrixFQDNCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FQDNAPI)
 
 	fqdn := &goaviatrix.FQDN{
 		FQDNTag:  d.Get("fqdn_tag").(string),
@@ -189,7 +192,7 @@ func resourceAviatrixFQDNReadIfRequired(d *schema.ResourceData, meta interface{}
 }
 
 func resourceAviatrixFQDNRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FQDNAPI)
 
 	fqdnTag := d.Get("fqdn_tag").(string)
 	if fqdnTag == "" {
@@ -336,7 +339,7 @@ func resourceAviatrixFQDNRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAviatrixFQDNUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FQDNAPI)
 
 	fqdn := &goaviatrix.FQDN{
 		FQDNTag:  d.Get("fqdn_tag").(string),
@@ -458,7 +461,7 @@ func resourceAviatrixFQDNUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixFQDNDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.FQDNAPI)
 
 	fqdn := &goaviatrix.FQDN{
 		FQDNTag: d.Get("fqdn_tag").(string),
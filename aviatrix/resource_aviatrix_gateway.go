@@ -1,6 +1,7 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -19,12 +20,28 @@ func resourceAviatrixGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffAll(
+			resourceAviatrixGatewayCustomizeDiff,
+			forceNewIfChanged("cloud_type", "account_name", "gw_name", "vpc_id", "vpc_reg", "subnet",
+				"vpn_access", "enable_elb", "elb_name", "allocate_new_eip", "eip"),
+			forceNewIfPeeringHaEipChanged,
+			customizeDiffTagsAll,
+			requireCapabilities(
+				capabilityCheck{attribute: "max_vpn_conn", capability: goaviatrix.CapabilityMaxVpnConn},
+			),
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateCloudType(goaviatrix.AWS, goaviatrix.GCP, goaviatrix.ARM),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
@@ -52,9 +69,10 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "Size of Gateway Instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "A VPC Network address range selected from one of the available network ranges.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "A VPC Network address range selected from one of the available network ranges.",
 			},
 			"enable_snat": {
 				Type:        schema.TypeBool,
@@ -70,10 +88,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "Enable user access through VPN to this container.",
 			},
 			"vpn_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "VPN CIDR block for the container.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "VPN CIDR block for the container.",
 			},
 			"enable_elb": {
 				Type:        schema.TypeBool,
@@ -114,17 +133,19 @@ func resourceAviatrixGateway() *schema.Resource {
 					"when a specific name is not in the destination when Split Tunnel Mode is enabled.",
 			},
 			"additional_cidrs": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDRList,
 				Description: "A list of destination CIDR ranges that will also go through the VPN tunnel " +
 					"when Split Tunnel Mode is enabled.",
 			},
 			"otp_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Two step authentication mode.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateStringInSlice([]string{"", "2", "3"}),
+				Description:  "Two step authentication mode.",
 			},
 			"saml_enabled": {
 				Type:        schema.TypeBool,
@@ -171,10 +192,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "API hostname for DUO auth mode.",
 			},
 			"duo_push_mode": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Push mode for DUO auth.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateStringInSlice([]string{"", "auto", "selective", "token"}),
+				Description:  "Push mode for DUO auth.",
 			},
 			"enable_ldap": {
 				Type:        schema.TypeBool,
@@ -214,10 +236,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "LDAP user attribute. Required: Yes if enable_ldap is 'yes'.",
 			},
 			"peering_ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Public Subnet Information while creating Peering HA Gateway, only subnet is accepted. Required to create peering ha gateway if cloud_type = 1 or 8 (aws or arm)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Information while creating Peering HA Gateway, only subnet is accepted. Required to create peering ha gateway if cloud_type = 1 or 8 (aws or arm)",
 			},
 			"peering_ha_zone": {
 				Type:        schema.TypeString,
@@ -226,10 +249,11 @@ func resourceAviatrixGateway() *schema.Resource {
 				Description: "Zone information for creating Peering HA Gateway. Required to create peering ha gateway if cloud_type = 4 (gcp)",
 			},
 			"peering_ha_eip": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Public IP address that you want assigned to the HA peering instance.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateIPAddress,
+				Description:  "Public IP address that you want assigned to the HA peering instance.",
 			},
 			"peering_ha_gw_size": {
 				Type:        schema.TypeString,
@@ -251,17 +275,33 @@ func resourceAviatrixGateway() *schema.Resource {
 					"Otherwise, allocate a new Elastic IP and use it for this gateway.",
 			},
 			"eip": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Required when allocate_new_eip is 'off'. It uses specified EIP for this gateway.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateIPAddress,
+				Description:  "Required when allocate_new_eip is 'off'. It uses specified EIP for this gateway.",
 			},
 			"tag_list": {
-				Type:        schema.TypeList,
+				Type:          schema.TypeList,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				Default:       nil,
+				Deprecated:    tagListDeprecation,
+				ConflictsWith: []string{"tags"},
+				Description:   "Instance tag of cloud provider.",
+			},
+			"tags": {
+				Type:          schema.TypeMap,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				ConflictsWith: []string{"tag_list"},
+				Description:   "Map of tags to assign to the instance. Only supported for AWS.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "All tags of the instance, including the provider default_tags.",
 			},
 			"public_ip": {
 				Type:        schema.TypeString,
@@ -297,8 +337,76 @@ func resourceAviatrixGateway() *schema.Resource {
 	}
 }
 
+// resourceAviatrixGatewayCustomizeDiff checks the attributes that depend on
+// each other, so that inconsistent configurations fail at plan time.
+func resourceAviatrixGatewayCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	vpnAccess := d.Get("vpn_access").(bool)
+	otpMode := d.Get("otp_mode").(string)
+	enableLdap := d.Get("enable_ldap").(bool)
+
+	if d.NewValueKnown("vpn_access") && !vpnAccess {
+		for _, key := range []string{"vpn_cidr", "max_vpn_conn", "enable_elb", "otp_mode", "saml_enabled", "enable_ldap"} {
+			if diffIsSet(d, key) {
+				return fmt.Errorf("%q requires vpn_access to be true", key)
+			}
+		}
+	}
+	if d.Get("saml_enabled").(bool) && (enableLdap || otpMode != "") {
+		return fmt.Errorf("ldap and mfa can't be configured if saml is enabled")
+	}
+	if enableLdap && otpMode == "3" {
+		return fmt.Errorf("ldap can't be configured along with okta authentication")
+	}
+	if enableLdap {
+		for _, key := range []string{"ldap_server", "ldap_bind_dn", "ldap_password", "ldap_base_dn", "ldap_username_attribute"} {
+			if diffIsUnset(d, key) {
+				return fmt.Errorf("%q must be set if enable_ldap is true", key)
+			}
+		}
+	}
+	switch otpMode {
+	case "2":
+		for _, key := range []string{"duo_integration_key", "duo_secret_key", "duo_api_hostname", "duo_push_mode"} {
+			if diffIsUnset(d, key) {
+				return fmt.Errorf("%q must be set if otp_mode is 2 (DUO)", key)
+			}
+		}
+	case "3":
+		for _, key := range []string{"okta_token", "okta_url"} {
+			if diffIsUnset(d, key) {
+				return fmt.Errorf("%q must be set if otp_mode is 3 (Okta)", key)
+			}
+		}
+	}
+
+	if (diffIsSet(d, "peering_ha_subnet") || diffIsSet(d, "peering_ha_zone")) && diffIsUnset(d, "peering_ha_gw_size") {
+		return fmt.Errorf("A valid non empty peering_ha_gw_size parameter is mandatory for " +
+			"this resource if peering_ha_subnet or peering_ha_zone is set. Example: t2.micro")
+	}
+	if (diffIsSet(d, "tag_list") || diffIsSet(d, "tags")) && d.NewValueKnown("cloud_type") && d.Get("cloud_type").(int) != goaviatrix.AWS {
+		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
+	}
+	return nil
+}
+
+// forceNewIfPeeringHaEipChanged replaces the gateway when the EIP of an
+// existing peering HA gateway changes. Setting it along with a new peering
+// HA gateway is an in place update.
+func forceNewIfPeeringHaEipChanged(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("peering_ha_eip") {
+		return nil
+	}
+	o, n := d.GetChange("peering_ha_eip")
+	if o != "" && n != "" {
+		return d.ForceNew("peering_ha_eip")
+	}
+	return nil
+}
+
 func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.GatewayAPI)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	gateway := &goaviatrix.Gateway{
 		CloudType:          d.Get("cloud_type").(int),
@@ -390,73 +498,17 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 	} else {
 		return fmt.Errorf("invalid cloud type, it can only be aws (1), gcp (4), or arm (8)")
 	}
-	if gateway.OtpMode != "" && gateway.OtpMode != "2" && gateway.OtpMode != "3" {
-		return fmt.Errorf("otp_mode can only be '2' or '3' or empty string")
-	}
-	if gateway.SamlEnabled == "yes" {
-		if gateway.EnableLdap == "yes" || gateway.OtpMode != "" {
-			return fmt.Errorf("ldap and mfa can't be configured if saml is enabled")
-		}
-	}
-	if gateway.EnableLdap == "yes" && gateway.OtpMode == "3" {
-		return fmt.Errorf("ldap can't be configured along with okta authentication")
-	}
-	if gateway.EnableLdap == "yes" {
-		if gateway.LdapServer == "" {
-			return fmt.Errorf("ldap server must be set if ldap is enabled")
-		}
-		if gateway.LdapBindDn == "" {
-			return fmt.Errorf("ldap bind dn must be set if ldap is enabled")
-		}
-		if gateway.LdapPassword == "" {
-			return fmt.Errorf("ldap password must be set if ldap is enabled")
-		}
-		if gateway.LdapBaseDn == "" {
-			return fmt.Errorf("ldap base dn must be set if ldap is enabled")
-		}
-		if gateway.LdapUserAttr == "" {
-			return fmt.Errorf("ldap user attribute must be set if ldap is enabled")
-		}
-	}
-	if gateway.OtpMode == "2" {
-		if gateway.DuoIntegrationKey == "" {
-			return fmt.Errorf("duo integration key required if otp_mode set to 2")
-		}
-		if gateway.DuoSecretKey == "" {
-			return fmt.Errorf("duo secret key required if otp_mode set to 2")
-		}
-		if gateway.DuoAPIHostname == "" {
-			return fmt.Errorf("duo api hostname required if otp_mode set to 2")
-		}
-		if gateway.DuoPushMode != "auto" && gateway.DuoPushMode != "token" && gateway.DuoPushMode != "selective" {
-			return fmt.Errorf("duo push mode must be set to a valid value (auto, selective, or token)")
-		}
-	} else if gateway.OtpMode == "3" {
-		if gateway.OktaToken == "" {
-			return fmt.Errorf("okta token must be set if otp_mode is set to 3")
-		}
-		if gateway.OktaURL == "" {
-			return fmt.Errorf("okta url must be set if otp_mode is set to 3")
-		}
-	}
-
-	if gateway.EnableElb == "yes" && gateway.VpnStatus != "yes" {
-		return fmt.Errorf("can not enable elb without vpn access set to yes")
-	}
-
 	peeringHaGwSize := d.Get("peering_ha_gw_size").(string)
 	peeringHaSubnet := d.Get("peering_ha_subnet").(string)
 	peeringHaZone := d.Get("peering_ha_zone").(string)
-	if peeringHaSubnet != "" || peeringHaZone != "" {
-		if peeringHaGwSize == "" {
-			return fmt.Errorf("A valid non empty peering_ha_gw_size parameter is mandatory for " +
-				"this resource if peering_ha_subnet or peering_ha_zone is set. Example: t2.micro")
-		}
+
+	if gateway.CloudType == 1 {
+		gateway.TagList = launchTagList(meta.(goaviatrix.TagAPI), d)
 	}
 
 	log.Printf("[INFO] Creating Aviatrix gateway: %#v", gateway)
 
-	err := client.CreateGateway(gateway)
+	err := client.CreateGatewayWithContext(ctx, gateway)
 	if err != nil {
 		log.Printf("[INFO] failed to create Aviatrix gateway: %#v", gateway)
 		return fmt.Errorf("failed to create Aviatrix gateway: %s", err)
@@ -467,6 +519,11 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 	flag := false
 	defer resourceAviatrixGatewayReadIfRequired(d, meta, &flag)
 
+	err = client.WaitForGatewayReadyWithContext(ctx, gateway.GwName, 0)
+	if err != nil {
+		return fmt.Errorf("failed to create Aviatrix gateway: %s", err)
+	}
+
 	// single_AZ enabled for Gateway. https://docs.aviatrix.com/HowTos/gateway.html#high-availability
 	if singleAZ {
 		singleAZGateway := &goaviatrix.Gateway{
@@ -476,7 +533,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 
 		log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
 
-		err := client.EnableSingleAZGateway(gateway)
+		err := client.EnableSingleAZGatewayWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to create single AZ GW HA: %s", err)
 		}
@@ -497,7 +554,11 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 
 		log.Printf("[INFO] Enable peering HA: %#v", peeringHaGateway)
 
-		err := client.EnablePeeringHaGateway(peeringHaGateway)
+		err := client.EnablePeeringHaGatewayWithContext(ctx, peeringHaGateway)
+		if err != nil {
+			return fmt.Errorf("failed to create peering HA: %s", err)
+		}
+		err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 		if err != nil {
 			return fmt.Errorf("failed to create peering HA: %s", err)
 		}
@@ -514,7 +575,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 				// controller, test out first. just assuming it has that suffix
 			}
 			peeringHaGateway.GwSize = peeringHaGwSize
-			err := client.UpdateGateway(peeringHaGateway)
+			err := client.UpdateGatewayWithContext(ctx, peeringHaGateway)
 			log.Printf("[INFO] Resizing Peering Ha Gateway size to: %s,", peeringHaGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Peering HA Gateway size: %s", err)
@@ -522,31 +583,12 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok && gateway.CloudType == 1 {
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		gateway.TagList = strings.Join(tagListStr, ",")
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
-		}
-
-		err = client.AddTags(tags)
-		if err != nil {
-			return fmt.Errorf("failed to add tags: %s", err)
-		}
-	} else if ok && gateway.CloudType != 1 {
-		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
-	}
-
 	if vpnStatus {
 		gw := &goaviatrix.Gateway{
 			GwName: gateway.GwName,
 		}
 
-		gw1, err := client.GetGateway(gw)
+		gw1, err := client.GetGatewayWithContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("couldn't find Aviatrix Gateway: %s due to %v", gw.GwName, err)
 		}
@@ -569,8 +611,7 @@ func resourceAviatrixGatewayCreate(d *schema.ResourceData, meta interface{}) err
 		sTunnel.SplitTunnel = gateway.SplitTunnel
 		if sTunnel.SplitTunnel == "yes" {
 			if sTunnel.AdditionalCidrs != "" || sTunnel.NameServers != "" || sTunnel.SearchDomains != "" {
-				time.Sleep(10 * time.Second)
-				err = client.ModifySplitTunnel(sTunnel)
+				err = client.ModifySplitTunnelWithContext(ctx, sTunnel)
 				if err != nil {
 					return fmt.Errorf("failed to modify split tunnel: %s", err)
 				}
@@ -590,7 +631,7 @@ func resourceAviatrixGatewayReadIfRequired(d *schema.ResourceData, meta interfac
 }
 
 func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.GatewayAPI)
 
 	gwName := d.Get("gw_name").(string)
 	if gwName == "" {
@@ -605,12 +646,6 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		GwName:      d.Get("gw_name").(string),
 	}
 
-	if d.Get("single_az_ha").(bool) {
-		gateway.SingleAZ = "enabled"
-	} else {
-		gateway.SingleAZ = "disabled"
-	}
-
 	gw, err := client.GetGateway(gateway)
 	if err != nil {
 		if err == goaviatrix.ErrNotFound {
@@ -660,32 +695,20 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 			d.Set("allocate_new_eip", true)
 		}
 
-		if gw.EnableLdapRead {
+		// ldap can be combined with duo, in which case the controller
+		// reports it through auth_method only
+		if gw.EnableLdapRead || strings.HasSuffix(gw.AuthMethod, "LDAP") {
 			d.Set("enable_ldap", true)
 		} else {
 			d.Set("enable_ldap", false)
 		}
 
-		if gw.VpnStatus != "" {
-			if gw.VpnStatus == "disabled" {
-				d.Set("vpn_access", false)
-			} else if gw.VpnStatus == "enabled" {
-				d.Set("vpn_access", true)
-			}
-		}
-
-		vpnAccess := d.Get("vpn_access").(bool)
-		if !vpnAccess {
-			d.Set("split_tunnel", true)
-			d.Set("max_vpn_conn", "")
-		} else {
-			if gw.SplitTunnel == "yes" {
-				d.Set("split_tunnel", true)
-			} else {
-				d.Set("split_tunnel", false)
-			}
-
+		if gw.VpnStatus == "enabled" {
+			d.Set("vpn_access", true)
 			d.Set("max_vpn_conn", gw.MaxConn)
+		} else {
+			d.Set("vpn_access", false)
+			d.Set("max_vpn_conn", "")
 		}
 
 		d.Set("vpn_cidr", gw.VpnCidr)
@@ -698,7 +721,7 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 			d.Set("elb_name", "")
 		}
 
-		if gw.SamlEnabled == "yes" {
+		if gw.SamlEnabled == "yes" || gw.AuthMethod == "saml" {
 			d.Set("saml_enabled", true)
 		} else {
 			d.Set("saml_enabled", false)
@@ -722,16 +745,10 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("ldap_base_dn", gw.LdapBaseDn)
 		d.Set("ldap_username_attribute", gw.LdapUserAttr)
 
-		if gw.NewZone != "" {
-			d.Set("zone", gw.NewZone)
-		}
-
-		if gw.SingleAZ != "" {
-			if gw.SingleAZ == "yes" {
-				d.Set("single_az_ha", true)
-			} else {
-				d.Set("single_az_ha", false)
-			}
+		if gw.SingleAZ == "yes" {
+			d.Set("single_az_ha", true)
+		} else {
+			d.Set("single_az_ha", false)
 		}
 
 		d.Set("eip", gw.PublicIP)
@@ -752,48 +769,34 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("public_dns_server", gw.PublicDnsServer)
 		d.Set("security_group_id", gw.GwSecurityGroupID)
 
-		peeringHaSubnet := d.Get("peering_ha_subnet").(string)
-		peeringHaZone := d.Get("peering_ha_zone").(string)
-		if peeringHaSubnet != "" || peeringHaZone != "" || gwName == "" {
-			peeringHaGateway := &goaviatrix.Gateway{
-				AccountName: d.Get("account_name").(string),
-				GwName:      d.Get("gw_name").(string) + "-hagw",
-			}
-
-			gwHaGw, err := client.GetGateway(peeringHaGateway)
-			if err == nil {
-				d.Set("cloudn_bkup_gateway_inst_id", gwHaGw.CloudnGatewayInstID)
-				d.Set("backup_public_ip", gwHaGw.PublicIP)
-				if gwHaGw.CloudType == 1 || gwHaGw.CloudType == 8 {
-					d.Set("peering_ha_subnet", gwHaGw.VpcNet)
-					d.Set("peering_ha_zone", "")
-				} else if gwHaGw.CloudType == 4 {
-					d.Set("peering_ha_zone", gwHaGw.GatewayZone)
-					d.Set("Peering_ha_subnet", "")
-				} else {
-					d.Set("peering_ha_subnet", "")
-					log.Printf("[DEBUG] Invalid cloud type")
-				}
-				d.Set("peering_ha_eip", gwHaGw.PublicIP)
-				d.Set("peering_ha_gw_size", gwHaGw.GwSize)
+		// the ha gateway is always looked up so that one enabled or removed
+		// outside of terraform shows up as drift
+		peeringHaGateway := &goaviatrix.Gateway{
+			AccountName: d.Get("account_name").(string),
+			GwName:      d.Get("gw_name").(string) + "-hagw",
+		}
+
+		gwHaGw, err := client.GetGateway(peeringHaGateway)
+		if err == nil {
+			d.Set("cloudn_bkup_gateway_inst_id", gwHaGw.CloudnGatewayInstID)
+			d.Set("backup_public_ip", gwHaGw.PublicIP)
+			if gwHaGw.CloudType == 1 || gwHaGw.CloudType == 8 {
+				d.Set("peering_ha_subnet", gwHaGw.VpcNet)
+				d.Set("peering_ha_zone", "")
+			} else if gwHaGw.CloudType == 4 {
+				d.Set("peering_ha_zone", gwHaGw.GatewayZone)
+				d.Set("peering_ha_subnet", "")
 			} else {
-				if err != goaviatrix.ErrNotFound {
-					return fmt.Errorf("unable to find peering ha gateway: %s", err)
-				} else {
-					if gwName == "" {
-						log.Printf("[DEBUG] Peering HA Gateway was not found during import, please confirm " +
-							"if primary gateway has peering HA enabled.")
-					}
-					d.Set("cloudn_bkup_gateway_inst_id", "")
-					d.Set("backup_public_ip", "")
-					d.Set("peering_ha_subnet", "")
-					d.Set("peering_ha_zone", "")
-					d.Set("peering_ha_eip", "")
-					d.Set("peering_ha_gw_size", "")
-				}
+				d.Set("peering_ha_subnet", "")
+				log.Printf("[DEBUG] Invalid cloud type")
 			}
+			d.Set("peering_ha_eip", gwHaGw.PublicIP)
+			d.Set("peering_ha_gw_size", gwHaGw.GwSize)
 			log.Printf("[TRACE] reading peering HA gateway %s: %#v", d.Get("gw_name").(string), gwHaGw)
 		} else {
+			if err != goaviatrix.ErrNotFound {
+				return fmt.Errorf("unable to find peering ha gateway: %s", err)
+			}
 			d.Set("cloudn_bkup_gateway_inst_id", "")
 			d.Set("backup_public_ip", "")
 			d.Set("peering_ha_subnet", "")
@@ -808,46 +811,33 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 				ResourceType: "gw",
 				ResourceName: d.Get("gw_name").(string),
 			}
-
-			tagList, err := client.GetTags(tags)
+			err := readTags(meta.(goaviatrix.TagAPI), d, tags)
 			if err != nil {
-				return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-			}
-
-			var tagListStr []string
-			if _, ok := d.GetOk("tag_list"); ok {
-				tagList1 := d.Get("tag_list").([]interface{})
-				tagListStr = goaviatrix.ExpandStringList(tagList1)
-			}
-			if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-				if err := d.Set("tag_list", tagList); err != nil {
-					log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-				}
-			} else {
-				if err := d.Set("tag_list", tagListStr); err != nil {
-					log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-				}
+				return fmt.Errorf("unable to read tags for gateway: %v due to %v", gateway.GwName, err)
 			}
 		}
 
-		if gw.VpnStatus == "enabled" && gw.SplitTunnel == "yes" {
+		if gw.VpnStatus == "enabled" {
 			splitTunnel := &goaviatrix.SplitTunnel{
 				VpcID: gw.VpcID,
 			}
 
-			if gw.EnableElb != "yes" {
-				splitTunnel.ElbName = gw.GwName
-			} else {
+			// split tunnel settings belong to the elb when there is one
+			if gw.ElbState == "enabled" {
 				splitTunnel.ElbName = gw.ElbName
+			} else {
+				splitTunnel.ElbName = gw.GwName
 			}
 			splitTunnel1, err := client.GetSplitTunnel(splitTunnel)
 			if err != nil {
 				return fmt.Errorf("unable to read split information for gateway: %v due to %v", gw.GwName, err)
 			}
+			d.Set("split_tunnel", splitTunnel1.SplitTunnel == "yes")
 			d.Set("name_servers", splitTunnel1.NameServers)
 			d.Set("search_domains", splitTunnel1.SearchDomains)
 			d.Set("additional_cidrs", splitTunnel1.AdditionalCidrs)
 		} else {
+			d.Set("split_tunnel", true)
 			d.Set("name_servers", "")
 			d.Set("search_domains", "")
 			d.Set("additional_cidrs", "")
@@ -858,50 +848,12 @@ func resourceAviatrixGatewayRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.GatewayAPI)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 	log.Printf("[INFO] Updating Aviatrix gateway: %#v", d.Get("gw_name").(string))
 
 	d.Partial(true)
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
-	if d.HasChange("vpn_access") {
-		return fmt.Errorf("updating vpn_access is not allowed")
-	}
-	if d.HasChange("enable_elb") {
-		return fmt.Errorf("updating enable_elb is not allowed")
-	}
-	if d.HasChange("elb_name") {
-		return fmt.Errorf("updating elb_name is not allowed")
-	}
-	if d.HasChange("allocate_new_eip") {
-		return fmt.Errorf("updating allocate_new_eip is not allowed")
-	}
-	if d.HasChange("eip") {
-		return fmt.Errorf("updating eip is not allowed")
-	}
-	if d.HasChange("peering_ha_eip") {
-		o, n := d.GetChange("peering_ha_eip")
-		if o != "" && n != "" {
-			return fmt.Errorf("updating peering_ha_eip is not allowed")
-		}
-	}
-
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -925,7 +877,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 	if d.HasChange("gw_size") {
 		old, _ := d.GetChange("gw_size")
 		primaryGwSize = old.(string)
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix Gateway: %s", err)
 		}
@@ -938,10 +890,6 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		d.HasChange("duo_push_mode") || d.HasChange("ldap_server") || d.HasChange("ldap_bind_dn") ||
 		d.HasChange("ldap_password") || d.HasChange("ldap_base_dn") || d.HasChange("ldap_username_attribute") {
 
-		if vpnAccess := d.Get("vpn_access").(bool); !vpnAccess {
-			return fmt.Errorf("vpn_access must be set to yes to modify vpn authentication")
-		}
-
 		vpn_gw := &goaviatrix.VpnGatewayAuth{
 			VpcID:              d.Get("vpc_id").(string),
 			OtpMode:            d.Get("otp_mode").(string),
@@ -978,66 +926,20 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			gw := &goaviatrix.Gateway{
 				GwName: gateway.GwName,
 			}
-			gw1, err := client.GetGateway(gw)
+			gw1, err := client.GetGatewayWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("couldn't find Aviatrix Gateway: %s due to %v", gw.GwName, err)
 			}
 			vpn_gw.VpcID = gw1.VpcID
 		}
 
-		if vpn_gw.OtpMode != "" && vpn_gw.OtpMode != "2" && vpn_gw.OtpMode != "3" {
-			return fmt.Errorf("otp_mode can only be '2' or '3' or empty string")
-		}
-		if vpn_gw.SamlEnabled == "yes" {
-			if vpn_gw.EnableLdap == "yes" || vpn_gw.OtpMode != "" {
-				return fmt.Errorf("ldap and mfa can't be configured if saml is enabled")
-			}
-		}
-		if vpn_gw.EnableLdap == "yes" && vpn_gw.OtpMode == "3" {
-			return fmt.Errorf("ldap can't be configured along with okta authentication")
-		}
-		if vpn_gw.EnableLdap == "yes" {
-			if vpn_gw.LdapServer == "" {
-				return fmt.Errorf("ldap server must be set if ldap is enabled")
-			}
-			if vpn_gw.LdapBindDn == "" {
-				return fmt.Errorf("ldap bind dn must be set if ldap is enabled")
-			}
-			if vpn_gw.LdapPassword == "" {
-				return fmt.Errorf("ldap password must be set if ldap is enabled")
-			}
-			if vpn_gw.LdapBaseDn == "" {
-				return fmt.Errorf("ldap base dn must be set if ldap is enabled")
-			}
-			if vpn_gw.LdapUserAttr == "" {
-				return fmt.Errorf("ldap user attribute must be set if ldap is enabled")
-			}
-		}
 		if vpn_gw.OtpMode == "2" {
-			if vpn_gw.DuoIntegrationKey == "" {
-				return fmt.Errorf("duo integration key required if otp_mode set to 2")
-			}
-			if vpn_gw.DuoSecretKey == "" {
-				return fmt.Errorf("duo secret key required if otp_mode set to 2")
-			}
-			if vpn_gw.DuoAPIHostname == "" {
-				return fmt.Errorf("duo api hostname required if otp_mode set to 2")
-			}
-			if vpn_gw.DuoPushMode != "auto" && vpn_gw.DuoPushMode != "token" && vpn_gw.DuoPushMode != "selective" {
-				return fmt.Errorf("duo push mode must be set to a valid value (auto, selective, or token)")
-			}
 			if vpn_gw.EnableLdap == "yes" {
 				vpn_gw.AuthType = "duo_ldap_auth"
 			} else {
 				vpn_gw.AuthType = "duo_auth"
 			}
 		} else if vpn_gw.OtpMode == "3" {
-			if vpn_gw.OktaToken == "" {
-				return fmt.Errorf("okta token must be set if otp_mode is set to 3")
-			}
-			if vpn_gw.OktaURL == "" {
-				return fmt.Errorf("okta url must be set if otp_mode is set to 3")
-			}
 			vpn_gw.AuthType = "okta_auth"
 		} else {
 			if vpn_gw.EnableLdap == "yes" {
@@ -1054,49 +956,24 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			vpn_gw.LbOrGatewayName = d.Get("gw_name").(string)
 		}
 
-		err := client.SetVpnGatewayAuthentication(vpn_gw)
+		err := client.SetVpnGatewayAuthenticationWithContext(ctx, vpn_gw)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix VPN Gateway Authentication: %s", err)
 		}
 	}
-	if d.HasChange("tag_list") && gateway.CloudType == 1 {
+	if hasTagsChange(d) && gateway.CloudType == 1 {
 		tags := &goaviatrix.Tags{
 			CloudType:    1,
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
 		}
-		o, n := d.GetChange("tag_list")
-		if o == nil {
-			o = new([]interface{})
-		}
-		if n == nil {
-			n = new([]interface{})
-		}
-		os := o.([]interface{})
-		ns := n.([]interface{})
-		oldList := goaviatrix.ExpandStringList(os)
-		newList := goaviatrix.ExpandStringList(ns)
-		oldTagList := goaviatrix.Difference(oldList, newList)
-		newTagList := goaviatrix.Difference(newList, oldList)
-		if len(oldTagList) != 0 || len(newTagList) != 0 {
-			if len(oldTagList) != 0 {
-				tags.TagList = strings.Join(oldTagList, ",")
-				err := client.DeleteTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to delete tags : %s", err)
-				}
-			}
-			if len(newTagList) != 0 {
-				tags.TagList = strings.Join(newTagList, ",")
-				err := client.AddTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to add tags : %s", err)
-				}
-			}
+		err := updateTags(ctx, meta.(goaviatrix.TagAPI), d, tags)
+		if err != nil {
+			return err
 		}
 		d.SetPartial("tag_list")
-	} else if d.HasChange("tag_list") && gateway.CloudType != 1 {
-		return fmt.Errorf("adding tags is only supported for aws, cloud_type must be set to 1")
+		d.SetPartial("tags")
+		d.SetPartial("tags_all")
 	}
 
 	if d.HasChange("split_tunnel") || d.HasChange("additional_cidrs") ||
@@ -1121,7 +998,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 					GwName: gateway.GwName,
 				}
 
-				gw1, err := client.GetGateway(gw)
+				gw1, err := client.GetGatewayWithContext(ctx, gw)
 				if err != nil {
 					return fmt.Errorf("couldn't find Aviatrix Gateway: %s due to %v", gw.GwName, err)
 				}
@@ -1134,7 +1011,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 				sTunnel.VpcID = gw1.VpcID
 			}
 
-			err := client.ModifySplitTunnel(sTunnel)
+			err := client.ModifySplitTunnelWithContext(ctx, sTunnel)
 			if err != nil {
 				return fmt.Errorf("failed to modify split tunnel: %s", err)
 			}
@@ -1157,14 +1034,14 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 		if singleAZGateway.SingleAZ == "enabled" {
 			log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.EnableSingleAZGateway(gateway)
+			err := client.EnableSingleAZGatewayWithContext(ctx, gateway)
 			if err != nil {
 				return fmt.Errorf("failed to create single AZ GW HA: %s", err)
 			}
 		}
 		if singleAZGateway.SingleAZ == "disabled" {
 			log.Printf("[INFO] Disable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.DisableSingleAZGateway(gateway)
+			err := client.DisableSingleAZGatewayWithContext(ctx, gateway)
 			if err != nil {
 				return fmt.Errorf("failed to disable single AZ GW HA: %s", err)
 			}
@@ -1184,12 +1061,12 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 
 		if enableNat {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
 		} else {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
@@ -1209,7 +1086,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			_, n := d.GetChange("vpn_cidr")
 			gw.VpnCidr = n.(string)
 
-			err := client.UpdateVpnCidr(gw)
+			err := client.UpdateVpnCidrWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to update vpn cidr: %s", err)
 			}
@@ -1235,7 +1112,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			_, n := d.GetChange("max_vpn_conn")
 			gw.MaxConn = n.(string)
 
-			err := client.UpdateMaxVpnConn(gw)
+			err := client.UpdateMaxVpnConnWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to update max vpn connections: %s", err)
 			}
@@ -1278,26 +1155,42 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			}
 		}
 		if newHaGwEnabled {
-			err := client.EnablePeeringHaGateway(gw)
+			err := client.EnablePeeringHaGatewayWithContext(ctx, gw)
+			if err != nil {
+				return fmt.Errorf("failed to enable Aviatrix peering HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 			if err != nil {
 				return fmt.Errorf("failed to enable Aviatrix peering HA gateway: %s", err)
 			}
 		} else if deleteHaGw {
-			err := client.DeleteGateway(peeringHaGateway)
+			err := client.DeleteGatewayWithContext(ctx, peeringHaGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix peering HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, peeringHaGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix peering HA gateway: %s", err)
 			}
 		} else if changeHaGw {
-			err := client.DeleteGateway(peeringHaGateway)
+			err := client.DeleteGatewayWithContext(ctx, peeringHaGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix peering HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, peeringHaGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix peering HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("gw_name").(string)
-			haErr := client.EnablePeeringHaGateway(gw)
+			haErr := client.EnablePeeringHaGatewayWithContext(ctx, gw)
 			if haErr != nil {
 				return fmt.Errorf("failed to enable Aviatrix peering HA gateway: %s", err)
 			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
+			if err != nil {
+				return fmt.Errorf("failed to enable Aviatrix peering HA gateway: %s", err)
+			}
 		}
 
 		d.SetPartial("peering_ha_subnet")
@@ -1312,7 +1205,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 			// OR
 			// newly configured peering HA gateway is set to be different size than primary gateway
 			// (when peering ha gateway is enabled, it's size is by default the same as primary gateway)
-			_, err := client.GetGateway(peeringHaGateway)
+			_, err := client.GetGatewayWithContext(ctx, peeringHaGateway)
 			if err != nil {
 				if err == goaviatrix.ErrNotFound {
 					d.Set("peering_ha_gw_size", "")
@@ -1328,7 +1221,7 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 				return fmt.Errorf("A valid non empty peering_ha_gw_size parameter is mandatory for this resource if " +
 					"peering_ha_subnet or peering_ha_zone is set. Example: t2.micro or us-west1-b respectively")
 			}
-			err = client.UpdateGateway(peeringHaGateway)
+			err = client.UpdateGatewayWithContext(ctx, peeringHaGateway)
 			log.Printf("[INFO] Updating Peering HA Gateway size to: %s ", peeringHaGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Peering HA Gw size: %s", err)
@@ -1344,7 +1237,9 @@ func resourceAviatrixGatewayUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixGatewayDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.GatewayAPI)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -1357,7 +1252,11 @@ func resourceAviatrixGatewayDelete(d *schema.ResourceData, meta interface{}) err
 		//Delete backup gateway first
 		gateway.GwName += "-hagw"
 		log.Printf("[INFO] Deleting Aviatrix Backup Gateway [-hagw]: %#v", gateway)
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayWithContext(ctx, gateway)
+		if err != nil {
+			return fmt.Errorf("failed to delete backup [-hgw] gateway: %s", err)
+		}
+		err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 		if err != nil {
 			return fmt.Errorf("failed to delete backup [-hgw] gateway: %s", err)
 		}
@@ -1367,7 +1266,11 @@ func resourceAviatrixGatewayDelete(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[INFO] Deleting Aviatrix gateway: %#v", gateway)
 
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayWithContext(ctx, gateway)
+	if err != nil {
+		return fmt.Errorf("failed to delete Aviatrix Gateway: %s", err)
+	}
+	err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix Gateway: %s", err)
 	}
@@ -1,14 +1,18 @@
 package aviatrix
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/fake"
 )
 
 func preGatewayCheck(t *testing.T, msgCommon string) (string, string, string) {
@@ -320,3 +324,68 @@ func testAccCheckGatewayDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+// TestResourceAviatrixGatewayReadOnly refreshes a VPN gateway, which reads
+// its split tunnel settings, with read-only and dry-run clients.
+func TestResourceAviatrixGatewayReadOnly(t *testing.T) {
+	controller := fake.NewController()
+	defer controller.Close()
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	client, err := goaviatrix.NewClient(controller.Username, controller.Password, controller.URL(), httpClient)
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	err = client.CreateAccount(&goaviatrix.Account{AccountName: "tfa-test", CloudType: 1, AwsAccountNumber: "123456789012"})
+	if err != nil {
+		t.Fatalf("CreateAccount: %s", err)
+	}
+	err = client.CreateGateway(&goaviatrix.Gateway{
+		CloudType:   1,
+		AccountName: "tfa-test",
+		GwName:      "tfg-vpn",
+		VpcID:       "vpc-0123",
+		VpcRegion:   "us-west-1",
+		VpcSize:     "t2.micro",
+		VpcNet:      "10.0.0.0/24",
+		VpnStatus:   "yes",
+		VpnCidr:     "192.168.43.0/24",
+		MaxConn:     "100",
+		SplitTunnel: "yes",
+	})
+	if err != nil {
+		t.Fatalf("CreateGateway: %s", err)
+	}
+	err = client.ModifySplitTunnel(&goaviatrix.SplitTunnel{VpcID: "vpc-0123", SplitTunnel: "yes", NameServers: "10.0.0.2"})
+	if err != nil {
+		t.Fatalf("ModifySplitTunnel: %s", err)
+	}
+
+	for _, mode := range []struct {
+		name string
+		set  func(*goaviatrix.Client)
+	}{
+		{"read-only", func(c *goaviatrix.Client) { c.ReadOnly, c.DryRun = true, false }},
+		{"dry run", func(c *goaviatrix.Client) { c.ReadOnly, c.DryRun = false, true }},
+	} {
+		mode.set(client)
+		d := schema.TestResourceDataRaw(t, resourceAviatrixGateway().Schema, map[string]interface{}{
+			"cloud_type":   1,
+			"account_name": "tfa-test",
+			"gw_name":      "tfg-vpn",
+		})
+		d.SetId("tfg-vpn")
+		if err := resourceAviatrixGatewayRead(d, client); err != nil {
+			t.Fatalf("%s: read: %s", mode.name, err)
+		}
+		for k, want := range map[string]interface{}{
+			"vpn_access":   true,
+			"vpn_cidr":     "192.168.43.0/24",
+			"split_tunnel": true,
+			"name_servers": "10.0.0.2",
+		} {
+			if got := d.Get(k); got != want {
+				t.Errorf("%s: %s = %v, want %v", mode.name, k, got, want)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -19,6 +20,10 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		SchemaVersion: 1,
 		MigrateState:  resourceAviatrixSite2CloudMigrateState,
 
@@ -34,20 +39,23 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Site2Cloud Connection Name.",
 			},
 			"remote_gateway_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateStringInSlice([]string{"generic", "avx", "aws", "azure", "sonicwall", "oracle"}),
 				Description: "Remote gateway type. Valid values: 'generic', 'avx', 'aws', 'azure', 'sonicwall', " +
 					"and 'oracle'.",
 			},
 			"connection_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Connection Type. Valid values: 'mapped' and 'unmapped'.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateStringInSlice([]string{"mapped", "unmapped"}),
+				Description:  "Connection Type. Valid values: 'mapped' and 'unmapped'.",
 			},
 			"tunnel_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Site2Cloud Tunnel Type. Valid values: 'udp' and 'tcp'",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateStringInSlice([]string{"udp", "tcp"}),
+				Description:  "Site2Cloud Tunnel Type. Valid values: 'udp' and 'tcp'",
 			},
 			"primary_cloud_gateway_name": {
 				Type:        schema.TypeString,
@@ -55,14 +63,16 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Primary Cloud Gateway Name.",
 			},
 			"remote_gateway_ip": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Remote Gateway IP.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateIPAddress,
+				Description:  "Remote Gateway IP.",
 			},
 			"remote_subnet_cidr": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Remote Subnet CIDR.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Remote Subnet CIDR.",
 			},
 			"backup_gateway_name": {
 				Type:        schema.TypeString,
@@ -76,10 +86,11 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Pre-Shared Key.",
 			},
 			"local_subnet_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Local Subnet CIDR.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Local Subnet CIDR.",
 			},
 			"ha_enabled": {
 				Type:        schema.TypeBool,
@@ -88,9 +99,10 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Specify whether enabling HA or not.",
 			},
 			"backup_remote_subnet_cidr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Backup remote subnet CIDR.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Backup remote subnet CIDR.",
 			},
 			"backup_remote_gateway_name": {
 				Type:        schema.TypeString,
@@ -98,9 +110,10 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Backup remote gateway name.",
 			},
 			"backup_remote_gateway_ip": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Backup remote remote gateway IP.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateIPAddress,
+				Description:  "Backup remote remote gateway IP.",
 			},
 			"backup_pre_shared_key": {
 				Type:        schema.TypeString,
@@ -109,14 +122,16 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Backup Pre-Shared Key.",
 			},
 			"remote_subnet_virtual": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Remote Subnet CIDR (Virtual).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Remote Subnet CIDR (Virtual).",
 			},
 			"local_subnet_virtual": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Local Subnet CIDR (Virtual).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCIDRList,
+				Description:  "Local Subnet CIDR (Virtual).",
 			},
 			"custom_algorithms": {
 				Type:        schema.TypeBool,
@@ -124,35 +139,41 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Switch to enable custom/non-default algorithms for IPSec Authentication/Encryption.",
 			},
 			"phase_1_authentication": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Phase one Authentication. Valid values: 'SHA-1', 'SHA-256', 'SHA-384' and 'SHA-512'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice(goaviatrix.Phase1AuthValues),
+				Description:  "Phase one Authentication. Valid values: 'SHA-1', 'SHA-256', 'SHA-384' and 'SHA-512'.",
 			},
 			"phase_2_authentication": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice(goaviatrix.Phase2AuthValues),
 				Description: "Phase two Authentication. Valid values: 'NO-AUTH', 'HMAC-SHA-1', 'HMAC-SHA-256', " +
 					"'HMAC-SHA-384' and 'HMAC-SHA-512'.",
 			},
 			"phase_1_dh_groups": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Phase one DH Groups. Valid values: '1', '2', '5', '14', '15', '16', '17' and '18'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice(goaviatrix.Phase1DhGroupValues),
+				Description:  "Phase one DH Groups. Valid values: '1', '2', '5', '14', '15', '16', '17' and '18'.",
 			},
 			"phase_2_dh_groups": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Phase two DH Groups. Valid values: '1', '2', '5', '14', '15', '16', '17' and '18'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice(goaviatrix.Phase2DhGroupValues),
+				Description:  "Phase two DH Groups. Valid values: '1', '2', '5', '14', '15', '16', '17' and '18'.",
 			},
 			"phase_1_encryption": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice(goaviatrix.Phase1EncryptionValues),
 				Description: "Phase one Encryption. Valid values: '3DES', 'AES-128-CBC', 'AES-192-CBC' and " +
 					"'AES-256-CBC'.",
 			},
 			"phase_2_encryption": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice(goaviatrix.Phase2EncryptionValues),
 				Description: "Phase two Encryption. Valid values: '3DES', 'AES-128-CBC', 'AES-192-CBC', " +
 					"'AES-256-CBC', 'AES-128-GCM-64', 'AES-128-GCM-96' and 'AES-128-GCM-128'.",
 			},
@@ -168,29 +189,34 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 				Description: "Route tables to modify.",
 			},
 			"remote_gateway_latitude": {
-				Type:        schema.TypeFloat,
-				Optional:    true,
-				Description: "Latitude of remote gateway.",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validateFloatBetween(-90, 90),
+				Description:  "Latitude of remote gateway.",
 			},
 			"remote_gateway_longitude": {
-				Type:        schema.TypeFloat,
-				Optional:    true,
-				Description: "Longitude of remote gateway.",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validateFloatBetween(-180, 180),
+				Description:  "Longitude of remote gateway.",
 			},
 			"backup_remote_gateway_latitude": {
-				Type:        schema.TypeFloat,
-				Optional:    true,
-				Description: "Latitude of backup remote gateway.",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validateFloatBetween(-90, 90),
+				Description:  "Latitude of backup remote gateway.",
 			},
 			"backup_remote_gateway_longitude": {
-				Type:        schema.TypeFloat,
-				Optional:    true,
-				Description: "Longitude of backup remote gateway.",
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validateFloatBetween(-180, 180),
+				Description:  "Longitude of backup remote gateway.",
 			},
 			"ssl_server_pool": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Specify ssl_server_pool for tunnel_type 'tcp'. Default value is '192.168.44.0/24'",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Specify ssl_server_pool for tunnel_type 'tcp'. Default value is '192.168.44.0/24'",
 			},
 			"enable_dead_peer_detection": {
 				Type:        schema.TypeBool,
@@ -203,7 +229,7 @@ func resourceAviatrixSite2Cloud() *schema.Resource {
 }
 
 func resourceAviatrixSite2CloudCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.Site2CloudAPI)
 
 	s2c := &goaviatrix.Site2Cloud{
 		GwName:              d.Get("primary_cloud_gateway_name").(string),
@@ -354,6 +380,14 @@ func resourceAviatrixSite2CloudCreate(d *schema.ResourceData, meta interface{})
 	flag := false
 	defer resourceAviatrixSite2CloudReadIfRequired(d, meta, &flag)
 
+	// The tunnel comes up only once the remote end is configured, so only
+	// wait for the controller to set it up on the gateway.
+	err = client.WaitForSite2CloudTunnel(s2c, []string{goaviatrix.Site2CloudTunnelUp, goaviatrix.Site2CloudTunnelDown},
+		d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("failed to wait for Site2Cloud tunnel: %s", err)
+	}
+
 	enableDeadPeerDetection := d.Get("enable_dead_peer_detection").(bool)
 	if !enableDeadPeerDetection {
 		err := client.DisableDeadPeerDetection(s2c)
@@ -374,7 +408,7 @@ func resourceAviatrixSite2CloudReadIfRequired(d *schema.ResourceData, meta inter
 }
 
 func resourceAviatrixSite2CloudRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.Site2CloudAPI)
 
 	tunnelName := d.Get("connection_name").(string)
 	vpcID := d.Get("vpc_id").(string)
@@ -461,7 +495,7 @@ func resourceAviatrixSite2CloudRead(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAviatrixSite2CloudUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.Site2CloudAPI)
 
 	editSite2cloud := &goaviatrix.EditSite2Cloud{
 		GwName:   d.Get("primary_cloud_gateway_name").(string),
@@ -592,7 +626,7 @@ func resourceAviatrixSite2CloudUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixSite2CloudDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.Site2CloudAPI)
 
 	s2c := &goaviatrix.Site2Cloud{
 		VpcID:      d.Get("vpc_id").(string),
@@ -1,9 +1,11 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -18,12 +20,23 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffAll(
+			resourceAviatrixSpokeGatewayCustomizeDiff,
+			forceNewIfChanged("cloud_type", "account_name", "gw_name", "vpc_id", "vpc_reg", "subnet"),
+			customizeDiffTagsAll,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateCloudType(goaviatrix.AWS, goaviatrix.GCP, goaviatrix.ARM),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
@@ -51,9 +64,10 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Info.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Info.",
 			},
 			"enable_snat": {
 				Type:        schema.TypeBool,
@@ -62,10 +76,11 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Description: "Specify whether enabling Source NAT feature on the gateway or not.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet. Required if enabling HA for AWS/ARM.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet. Required if enabling HA for AWS/ARM.",
 			},
 			"ha_zone": {
 				Type:        schema.TypeString,
@@ -92,11 +107,26 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 				Description: "Specify the transit Gateway.",
 			},
 			"tag_list": {
-				Type:        schema.TypeList,
+				Type:          schema.TypeList,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				Default:       nil,
+				Deprecated:    tagListDeprecation,
+				ConflictsWith: []string{"tags"},
+				Description:   "Instance tag of cloud provider.",
+			},
+			"tags": {
+				Type:          schema.TypeMap,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				ConflictsWith: []string{"tag_list"},
+				Description:   "Map of tags to assign to the instance. Only supported for AWS.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "All tags of the instance, including the provider default_tags.",
 			},
 			"cloud_instance_id": {
 				Type:        schema.TypeString,
@@ -107,8 +137,38 @@ func resourceAviatrixSpokeGateway() *schema.Resource {
 	}
 }
 
+// resourceAviatrixSpokeGatewayCustomizeDiff checks the attributes that
+// depend on each other, so that inconsistent configurations fail at plan
+// time.
+func resourceAviatrixSpokeGatewayCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	cloudTypeKnown := d.NewValueKnown("cloud_type")
+	cloudType := d.Get("cloud_type").(int)
+	haSubnet := diffIsSet(d, "ha_subnet")
+	haZone := diffIsSet(d, "ha_zone")
+
+	if haSubnet && cloudTypeKnown && cloudType == goaviatrix.GCP {
+		return fmt.Errorf("ha_subnet is not supported for gcp (cloud_type = 4), use ha_zone")
+	}
+	if haZone && cloudTypeKnown && cloudType != goaviatrix.GCP {
+		return fmt.Errorf("ha_zone is only supported for gcp (cloud_type = 4), use ha_subnet")
+	}
+	if (haSubnet || haZone) && diffIsUnset(d, "ha_gw_size") {
+		return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
+			"ha_subnet or ha_zone is set. Example: t2.micro")
+	}
+	if d.Get("single_az_ha").(bool) && (haSubnet || haZone) {
+		return fmt.Errorf("single_az_ha can't be enabled along with ha_subnet or ha_zone")
+	}
+	if (diffIsSet(d, "tag_list") || diffIsSet(d, "tags")) && cloudTypeKnown && cloudType != goaviatrix.AWS {
+		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
+	}
+	return nil
+}
+
 func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	gateway := &goaviatrix.SpokeVpc{
 		CloudType:      d.Get("cloud_type").(int),
@@ -156,18 +216,16 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	haZone := d.Get("ha_zone").(string)
 	haSubnet := d.Get("ha_subnet").(string)
 	haGwSize := d.Get("ha_gw_size").(string)
-	if haZone != "" || haSubnet != "" {
-		if haGwSize == "" {
-			return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
-				"ha_subnet or ha_zone is set. Example: t2.micro")
-		}
+
+	if gateway.CloudType == 1 {
+		gateway.TagList = launchTagList(meta.(goaviatrix.TagAPI), d)
 	}
 
-	log.Printf("[INFO] Creating Aviatrix Spoke VPC: %#v", gateway)
+	log.Printf("[INFO] Creating Aviatrix Spoke Gateway: %#v", gateway)
 
-	err := client.LaunchSpokeVpc(gateway)
+	err := client.LaunchSpokeVpcWithContext(ctx, gateway)
 	if err != nil {
-		return fmt.Errorf("failed to create Aviatrix Spoke VPC: %s", err)
+		return fmt.Errorf("failed to create Aviatrix Spoke Gateway: %s", err)
 	}
 
 	d.SetId(gateway.GwName)
@@ -175,6 +233,11 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 	flag := false
 	defer resourceAviatrixSpokeGatewayReadIfRequired(d, meta, &flag)
 
+	err = client.WaitForGatewayReadyWithContext(ctx, gateway.GwName, 0)
+	if err != nil {
+		return fmt.Errorf("failed to create Aviatrix Spoke Gateway: %s", err)
+	}
+
 	if enableNat {
 		log.Printf("[INFO] Aviatrix NAT enabled gateway: %#v", gateway)
 	}
@@ -185,7 +248,7 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 			SingleAZ: "enabled",
 		}
 		log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-		err := client.EnableSingleAZGateway(singleAZGateway)
+		err := client.EnableSingleAZGatewayWithContext(ctx, singleAZGateway)
 		if err != nil {
 			return fmt.Errorf("failed to create single AZ GW HA: %s", err)
 		}
@@ -199,9 +262,13 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 			HASubnet:  haSubnet,
 			HAZone:    haZone,
 		}
-		err = client.EnableHaSpokeVpc(haGateway)
+		err = client.EnableHaSpokeVpcWithContext(ctx, haGateway)
 		if err != nil {
-			return fmt.Errorf("failed to enable HA Aviatrix TransitVpc: %s", err)
+			return fmt.Errorf("failed to enable HA Aviatrix Spoke Gateway: %s", err)
+		}
+		err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
+		if err != nil {
+			return fmt.Errorf("failed to enable HA Aviatrix Spoke Gateway: %s", err)
 		}
 
 		log.Printf("[INFO]Resizing Spoke HA Gateway: %#v", haGwSize)
@@ -216,42 +283,26 @@ func resourceAviatrixSpokeGatewayCreate(d *schema.ResourceData, meta interface{}
 				GwName:    d.Get("gw_name").(string) + "-hagw",
 			}
 			haGateway.GwSize = d.Get("ha_gw_size").(string)
-			err := client.UpdateGateway(haGateway)
+			err := client.UpdateGatewayWithContext(ctx, haGateway)
 			log.Printf("[INFO] Resizing Spoke HA GAteway size to: %s ", haGateway.GwSize)
 			if err != nil {
-				return fmt.Errorf("failed to update Aviatrix Transit HA Gateway size: %s", err)
+				return fmt.Errorf("failed to update Aviatrix Spoke HA Gateway size: %s", err)
 			}
 			d.Set("ha_gw_size", haGwSize)
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok && gateway.CloudType == 1 {
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		gateway.TagList = strings.Join(tagListStr, ",")
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
-		}
-		err = client.AddTags(tags)
-		if err != nil {
-			return fmt.Errorf("failed to add tags: %s", err)
-		}
-	} else if ok && gateway.CloudType != 1 {
-		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
-	}
-
 	if transitGwName := d.Get("transit_gw").(string); transitGwName != "" {
 		//No HA config, just return
-		err := client.SpokeJoinTransit(gateway)
+		aviatrixMutexKV.Lock(transitGatewayMutexKey(transitGwName))
+		err := client.SpokeJoinTransitWithContext(ctx, gateway)
+		aviatrixMutexKV.Unlock(transitGatewayMutexKey(transitGwName))
 		if err != nil {
-			return fmt.Errorf("failed to join TransitVpc: %s", err)
+			return fmt.Errorf("failed to join transit gateway: %s", err)
 		}
 	}
 
-	return resourceAviatrixSpokeVpcReadIfRequired(d, meta, &flag)
+	return resourceAviatrixSpokeGatewayReadIfRequired(d, meta, &flag)
 }
 
 func resourceAviatrixSpokeGatewayReadIfRequired(d *schema.ResourceData, meta interface{}, flag *bool) error {
@@ -262,8 +313,13 @@ func resourceAviatrixSpokeGatewayReadIfRequired(d *schema.ResourceData, meta int
 	return nil
 }
 
+// resourceAviatrixSpokeGatewayRead refreshes every attribute from
+// list_vpcs_summary so changes made on the controller show up as drift.
+// ha_subnet is read for AWS and ARM and ha_zone for GCP, the other one being
+// cleared. tag_list and tags are only read for AWS, the one cloud the
+// controller tags gateways in.
 func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
 
 	gwName := d.Get("gw_name").(string)
 	if gwName == "" {
@@ -284,7 +340,7 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("couldn't find Aviatrix SpokeVpc: %s", err)
+		return fmt.Errorf("couldn't find Aviatrix Spoke Gateway: %s", err)
 	}
 
 	log.Printf("[TRACE] reading spoke gateway %s: %#v", d.Get("gw_name").(string), gw)
@@ -333,23 +389,9 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
 		}
-		tagList, err := client.GetTags(tags)
+		err := readTags(meta.(goaviatrix.TagAPI), d, tags)
 		if err != nil {
-			return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-		}
-		var tagListStr []string
-		if _, ok := d.GetOk("tag_list"); ok {
-			tagList1 := d.Get("tag_list").([]interface{})
-			tagListStr = goaviatrix.ExpandStringList(tagList1)
-		}
-		if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-			if err := d.Set("tag_list", tagList); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
-		} else {
-			if err := d.Set("tag_list", tagListStr); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
+			return fmt.Errorf("unable to read tags for gateway: %v due to %v", gateway.GwName, err)
 		}
 	}
 
@@ -365,7 +407,7 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 			d.Set("ha_subnet", "")
 			d.Set("ha_zone", "")
 		} else {
-			return fmt.Errorf("couldn't find Aviatrix SpokeVpc HA Gateway: %s", err)
+			return fmt.Errorf("couldn't find Aviatrix Spoke HA Gateway: %s", err)
 		}
 	} else {
 		log.Printf("[INFO] Spoke HA Gateway size: %s", haGw.GwSize)
@@ -383,7 +425,9 @@ func resourceAviatrixSpokeGatewayRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
@@ -399,24 +443,6 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 
 	d.Partial(true)
 
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
 	if d.HasChange("single_az_ha") {
 		singleAZGateway := &goaviatrix.Gateway{
 			GwName: d.Get("gw_name").(string),
@@ -432,13 +458,13 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 
 		if singleAZGateway.SingleAZ == "enabled" {
 			log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.EnableSingleAZGateway(singleAZGateway)
+			err := client.EnableSingleAZGatewayWithContext(ctx, singleAZGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable single AZ GW HA: %s", err)
 			}
 		} else if singleAZGateway.SingleAZ == "disabled" {
 			log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.DisableSingleAZGateway(singleAZGateway)
+			err := client.DisableSingleAZGatewayWithContext(ctx, singleAZGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable single AZ GW HA: %s", err)
 			}
@@ -447,44 +473,19 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	if d.HasChange("tag_list") && gateway.CloudType == 1 {
+	if hasTagsChange(d) && gateway.CloudType == 1 {
 		tags := &goaviatrix.Tags{
 			CloudType:    1,
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
 		}
-		o, n := d.GetChange("tag_list")
-		if o == nil {
-			o = new([]interface{})
-		}
-		if n == nil {
-			n = new([]interface{})
-		}
-		os := o.([]interface{})
-		ns := n.([]interface{})
-		oldList := goaviatrix.ExpandStringList(os)
-		newList := goaviatrix.ExpandStringList(ns)
-		oldTagList := goaviatrix.Difference(oldList, newList)
-		newTagList := goaviatrix.Difference(newList, oldList)
-		if len(oldTagList) != 0 || len(newTagList) != 0 {
-			if len(oldTagList) != 0 {
-				tags.TagList = strings.Join(oldTagList, ",")
-				err := client.DeleteTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to delete tags : %s", err)
-				}
-			}
-			if len(newTagList) != 0 {
-				tags.TagList = strings.Join(newTagList, ",")
-				err := client.AddTags(tags)
-				if err != nil {
-					return fmt.Errorf("failed to add tags : %s", err)
-				}
-			}
+		err := updateTags(ctx, meta.(goaviatrix.TagAPI), d, tags)
+		if err != nil {
+			return err
 		}
 		d.SetPartial("tag_list")
-	} else if d.HasChange("tag_list") && gateway.CloudType != 1 {
-		return fmt.Errorf("adding tags is only supported for aws, cloud_type must be set to 1")
+		d.SetPartial("tags")
+		d.SetPartial("tags_all")
 	}
 
 	//Get primary gw size if gw_size changed, to be used later on for ha gateway size update
@@ -493,9 +494,9 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		old, _ := d.GetChange("gw_size")
 		primaryGwSize = old.(string)
 		gateway.GwSize = d.Get("gw_size").(string)
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayWithContext(ctx, gateway)
 		if err != nil {
-			return fmt.Errorf("failed to update Aviatrix SpokeVpc: %s", err)
+			return fmt.Errorf("failed to update Aviatrix Spoke Gateway: %s", err)
 		}
 		d.SetPartial("gw_size")
 	}
@@ -532,30 +533,46 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 		if newHaGwEnabled {
 			//New configuration to enable HA
-			err := client.EnableHaSpokeVpc(spokeGw)
+			err := client.EnableHaSpokeVpcWithContext(ctx, spokeGw)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix Spoke Gateway: %s", err)
+			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 			if err != nil {
-				return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
+				return fmt.Errorf("failed to enable HA Aviatrix Spoke Gateway: %s", err)
 			}
 			newHaGwEnabled = true
 		} else if deleteHaGw {
 			//Ha configuration has been deleted
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
 			if err != nil {
-				return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
+				return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %s", err)
 			}
 		} else if changeHaGw {
 			//HA subnet has been modified. Delete older HA GW,
 			// and launch new HA GW in new subnet.
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
 			if err != nil {
-				return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
+				return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("spokeGw_name").(string)
 			//New configuration to enable HA
-			haErr := client.EnableHaSpokeVpc(spokeGw)
+			haErr := client.EnableHaSpokeVpcWithContext(ctx, spokeGw)
 			if haErr != nil {
-				return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
+				return fmt.Errorf("failed to enable HA Aviatrix Spoke Gateway: %s", err)
+			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix Spoke Gateway: %s", err)
 			}
 		}
 		d.SetPartial("ha_subnet")
@@ -570,7 +587,7 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 			// OR
 			// newly configured Ha gateway is set to be different size than primary gateway
 			// (when ha gateway is enabled, it's size is by default the same as primary gateway)
-			_, err := client.GetGateway(haGateway)
+			_, err := client.GetGatewayWithContext(ctx, haGateway)
 			if err != nil {
 				if err == goaviatrix.ErrNotFound {
 					d.Set("ha_gw_size", "")
@@ -582,11 +599,7 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 					"size: %s", err)
 			}
 			haGateway.GwSize = d.Get("ha_gw_size").(string)
-			if haGateway.GwSize == "" {
-				return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
-					"ha_subnet or ha_zone is set. Example: t2.micro or us-west1-b")
-			}
-			err = client.UpdateGateway(haGateway)
+			err = client.UpdateGatewayWithContext(ctx, haGateway)
 			log.Printf("[INFO] Updating HA Gateway size to: %s ", haGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Spoke HA Gw size: %s", err)
@@ -604,12 +617,12 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		enableNat := d.Get("enable_snat").(bool)
 
 		if enableNat {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
 		} else {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
@@ -627,28 +640,37 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 		}
 
 		o, n := d.GetChange("transit_gw")
+		oldKey, newKey := transitGatewayMutexKey(o.(string)), transitGatewayMutexKey(n.(string))
 		if o == "" {
 			//New configuration to join to transit GW
-			err := client.SpokeJoinTransit(spokeVPC)
+			aviatrixMutexKV.Lock(newKey)
+			err := client.SpokeJoinTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(newKey)
 			if err != nil {
-				return fmt.Errorf("failed to join transit VPC: %s", err)
+				return fmt.Errorf("failed to join transit gateway: %s", err)
 			}
 		} else if n == "" {
 			//Transit GW has been deleted, leave transit GW.
-			err := client.SpokeLeaveTransit(spokeVPC)
+			aviatrixMutexKV.Lock(oldKey)
+			err := client.SpokeLeaveTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(oldKey)
 			if err != nil {
-				return fmt.Errorf("failed to leave transit VPC: %s", err)
+				return fmt.Errorf("failed to leave transit gateway: %s", err)
 			}
 		} else {
 			//Change transit GW
-			err := client.SpokeLeaveTransit(spokeVPC)
+			aviatrixMutexKV.Lock(oldKey)
+			err := client.SpokeLeaveTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(oldKey)
 			if err != nil {
-				return fmt.Errorf("failed to leave transit VPC: %s", err)
+				return fmt.Errorf("failed to leave transit gateway: %s", err)
 			}
 
-			err = client.SpokeJoinTransit(spokeVPC)
+			aviatrixMutexKV.Lock(newKey)
+			err = client.SpokeJoinTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(newKey)
 			if err != nil {
-				return fmt.Errorf("failed to join transit VPC: %s", err)
+				return fmt.Errorf("failed to join transit gateway: %s", err)
 			}
 		}
 		d.SetPartial("transit_gw")
@@ -656,27 +678,31 @@ func resourceAviatrixSpokeGatewayUpdate(d *schema.ResourceData, meta interface{}
 
 	d.Partial(false)
 	d.SetId(gateway.GwName)
-	return resourceAviatrixSpokeVpcRead(d, meta)
+	return resourceAviatrixSpokeGatewayRead(d, meta)
 }
 
 func resourceAviatrixSpokeGatewayDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
 	}
 
-	log.Printf("[INFO] Deleting Aviatrix Spoke VPC: %#v", gateway)
+	log.Printf("[INFO] Deleting Aviatrix Spoke Gateway: %#v", gateway)
 
 	if transitGw := d.Get("transit_gw").(string); transitGw != "" {
 		spokeVPC := &goaviatrix.SpokeVpc{
 			GwName: d.Get("gw_name").(string),
 		}
 
-		err := client.SpokeLeaveTransit(spokeVPC)
+		aviatrixMutexKV.Lock(transitGatewayMutexKey(transitGw))
+		err := client.SpokeLeaveTransitWithContext(ctx, spokeVPC)
+		aviatrixMutexKV.Unlock(transitGatewayMutexKey(transitGw))
 		if err != nil {
-			return fmt.Errorf("failed to leave transit VPC: %s", err)
+			return fmt.Errorf("failed to leave transit gateway: %s", err)
 		}
 	}
 
@@ -686,17 +712,25 @@ func resourceAviatrixSpokeGatewayDelete(d *schema.ResourceData, meta interface{}
 	if haSubnet != "" || haZone != "" {
 		//Delete HA Gw too
 		gateway.GwName += "-hagw"
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayWithContext(ctx, gateway)
 		if err != nil {
-			return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
+			return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %s", err)
+		}
+		err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
+		if err != nil {
+			return fmt.Errorf("failed to delete Aviatrix Spoke HA gateway: %s", err)
 		}
 	}
 
 	gateway.GwName = d.Get("gw_name").(string)
 
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayWithContext(ctx, gateway)
+	if err != nil {
+		return fmt.Errorf("failed to delete Aviatrix Spoke Gateway: %s", err)
+	}
+	err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 	if err != nil {
-		return fmt.Errorf("failed to delete Aviatrix SpokeVpc: %s", err)
+		return fmt.Errorf("failed to delete Aviatrix Spoke Gateway: %s", err)
 	}
 
 	return nil
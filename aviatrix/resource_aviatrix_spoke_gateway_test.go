@@ -7,8 +7,10 @@ import (
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/mock"
 )
 
 func TestAccAviatrixSpokeGateway_basic(t *testing.T) {
@@ -274,3 +276,98 @@ func testAccCheckSpokeGatewayDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceAviatrixSpokeGatewayRead(t *testing.T) {
+	gcpSpoke := &goaviatrix.Gateway{
+		CloudType:     4,
+		AccountName:   "tfa-test",
+		GwName:        "tfg-spoke",
+		VpcID:         "tfg-vpc~-~project",
+		GatewayZone:   "us-west1-b",
+		VpcNet:        "10.0.0.0/24",
+		GwSize:        "n1-standard-2",
+		EnableNat:     "yes",
+		SingleAZ:      "yes",
+		SpokeVpc:      "yes",
+		TransitGwName: "tfg-transit",
+	}
+	gcpHA := &goaviatrix.Gateway{CloudType: 4, GatewayZone: "us-west1-c", GwSize: "n1-standard-1"}
+
+	runCRUDTests(t, resourceAviatrixSpokeGateway(), []crudTestCase{
+		{
+			name: "read drift",
+			op:   resourceAviatrixSpokeGatewayRead,
+			raw: map[string]interface{}{
+				"cloud_type":   4,
+				"account_name": "tfa-test",
+				"gw_name":      "tfg-spoke",
+				"vpc_id":       "tfg-vpc",
+				"vpc_reg":      "us-west1-b",
+				"gw_size":      "n1-standard-1",
+				"subnet":       "10.0.0.0/24",
+			},
+			id: "tfg-spoke",
+			client: &mock.Client{
+				GetGatewayFunc: func(gw *goaviatrix.Gateway) (*goaviatrix.Gateway, error) {
+					if gw.GwName == "tfg-spoke-hagw" {
+						return gcpHA, nil
+					}
+					return gcpSpoke, nil
+				},
+			},
+			wantID: "tfg-spoke",
+			calls:  []string{"GetGateway", "GetGateway"},
+			check: func(t *testing.T, d *schema.ResourceData) {
+				want := map[string]interface{}{
+					"vpc_id":       "tfg-vpc",
+					"vpc_reg":      "us-west1-b",
+					"gw_size":      "n1-standard-2",
+					"enable_snat":  true,
+					"single_az_ha": true,
+					"transit_gw":   "tfg-transit",
+					"ha_zone":      "us-west1-c",
+					"ha_subnet":    "",
+					"ha_gw_size":   "n1-standard-1",
+				}
+				for k, v := range want {
+					if got := d.Get(k); got != v {
+						t.Errorf("%s = %v, want %v", k, got, v)
+					}
+				}
+			},
+		},
+		{
+			name: "read detached spoke",
+			op:   resourceAviatrixSpokeGatewayRead,
+			raw: map[string]interface{}{
+				"cloud_type":   1,
+				"account_name": "tfa-test",
+				"gw_name":      "tfg-spoke",
+				"transit_gw":   "tfg-transit",
+				"ha_subnet":    "10.0.1.0/24",
+				"ha_gw_size":   "t2.micro",
+			},
+			id: "tfg-spoke",
+			client: &mock.Client{
+				GetGatewayFunc: func(gw *goaviatrix.Gateway) (*goaviatrix.Gateway, error) {
+					if gw.GwName == "tfg-spoke-hagw" {
+						return nil, goaviatrix.ErrNotFound
+					}
+					return &goaviatrix.Gateway{CloudType: 1, GwName: "tfg-spoke", VpcID: "vpc-0123~~tfg-vpc", VpcRegion: "us-west-1"}, nil
+				},
+			},
+			wantID: "tfg-spoke",
+			calls:  []string{"GetGateway", "GetTags", "GetDefaultTags", "GetGateway"},
+			check: func(t *testing.T, d *schema.ResourceData) {
+				for _, k := range []string{"transit_gw", "ha_subnet", "ha_gw_size"} {
+					if got := d.Get(k); got != "" {
+						t.Errorf("%s = %q, want it cleared", k, got)
+					}
+				}
+				if got := d.Get("vpc_id"); got != "vpc-0123" {
+					t.Errorf("vpc_id = %q, want vpc-0123", got)
+				}
+			},
+		},
+	})
+}
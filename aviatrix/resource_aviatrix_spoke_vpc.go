@@ -1,9 +1,11 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -18,15 +20,21 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		SchemaVersion: 1,
 		MigrateState:  resourceSpokeVpcMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateCloudType(goaviatrix.AWS, goaviatrix.GCP, goaviatrix.ARM),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
@@ -54,21 +62,24 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Info.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Info.",
 			},
 			"enable_nat": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "no",
-				Description: "Specify whether enabling NAT feature on the gateway or not.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no",
+				ValidateFunc: validateStringInSlice([]string{"yes", "no"}),
+				Description:  "Specify whether enabling NAT feature on the gateway or not.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet. Required if enabling HA for AWS/ARM.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet. Required if enabling HA for AWS/ARM.",
 			},
 			"ha_zone": {
 				Type:        schema.TypeString,
@@ -83,10 +94,11 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 				Description: "HA Gateway Size.",
 			},
 			"single_az_ha": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "disabled",
-				Description: "Set to 'enabled' if this feature is desired.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validateStringInSlice([]string{"enabled", "disabled"}),
+				Description:  "Set to 'enabled' if this feature is desired.",
 			},
 			"transit_gw": {
 				Type:        schema.TypeString,
@@ -111,7 +123,9 @@ func resourceAviatrixSpokeVpc() *schema.Resource {
 }
 
 func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 	gateway := &goaviatrix.SpokeVpc{
 		CloudType:      d.Get("cloud_type").(int),
 		AccountName:    d.Get("account_name").(string),
@@ -155,7 +169,7 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 
 	log.Printf("[INFO] Creating Aviatrix Spoke VPC: %#v", gateway)
 
-	err := client.LaunchSpokeVpc(gateway)
+	err := client.LaunchSpokeVpcWithContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix Spoke VPC: %s", err)
 	}
@@ -165,6 +179,11 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 	flag := false
 	defer resourceAviatrixSpokeVpcReadIfRequired(d, meta, &flag)
 
+	err = client.WaitForGatewayReadyWithContext(ctx, gateway.GwName, 0)
+	if err != nil {
+		return fmt.Errorf("failed to create Aviatrix Spoke VPC: %s", err)
+	}
+
 	if enableNAT := d.Get("enable_nat").(string); enableNAT == "yes" {
 		log.Printf("[INFO] Aviatrix NAT enabled gateway: %#v", gateway)
 	}
@@ -175,7 +194,7 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 			SingleAZ: d.Get("single_az_ha").(string),
 		}
 		log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-		err := client.EnableSingleAZGateway(singleAZGateway)
+		err := client.EnableSingleAZGatewayWithContext(ctx, singleAZGateway)
 		if err != nil {
 			return fmt.Errorf("failed to create single AZ GW HA: %s", err)
 		}
@@ -189,9 +208,13 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 			HASubnet:  haSubnet,
 			HAZone:    haZone,
 		}
-		err = client.EnableHaSpokeVpc(haGateway)
+		err = client.EnableHaSpokeVpcWithContext(ctx, haGateway)
+		if err != nil {
+			return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
+		}
+		err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 		if err != nil {
-			return fmt.Errorf("failed to enable HA Aviatrix TransitVpc: %s", err)
+			return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
 		}
 
 		log.Printf("[INFO]Resizing Spoke HA Gateway: %#v", haGwSize)
@@ -206,10 +229,10 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 				GwName:    d.Get("gw_name").(string) + "-hagw",
 			}
 			haGateway.GwSize = d.Get("ha_gw_size").(string)
-			err := client.UpdateGateway(haGateway)
+			err := client.UpdateGatewayWithContext(ctx, haGateway)
 			log.Printf("[INFO] Resizing Spoke HA GAteway size to: %s ", haGateway.GwSize)
 			if err != nil {
-				return fmt.Errorf("failed to update Aviatrix Transit HA Gateway size: %s", err)
+				return fmt.Errorf("failed to update Aviatrix Spoke HA Gateway size: %s", err)
 			}
 			d.Set("ha_gw_size", haGwSize)
 		}
@@ -225,7 +248,7 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 			ResourceName: d.Get("gw_name").(string),
 			TagList:      gateway.TagList,
 		}
-		err = client.AddTags(tags)
+		err = client.AddTagsWithContext(ctx, tags)
 		if err != nil {
 			return fmt.Errorf("failed to add tags: %s", err)
 		}
@@ -235,7 +258,9 @@ func resourceAviatrixSpokeVpcCreate(d *schema.ResourceData, meta interface{}) er
 
 	if transitGwName := d.Get("transit_gw").(string); transitGwName != "" {
 		//No HA config, just return
-		err := client.SpokeJoinTransit(gateway)
+		aviatrixMutexKV.Lock(transitGatewayMutexKey(transitGwName))
+		err := client.SpokeJoinTransitWithContext(ctx, gateway)
+		aviatrixMutexKV.Unlock(transitGatewayMutexKey(transitGwName))
 		if err != nil {
 			return fmt.Errorf("failed to join TransitVpc: %s", err)
 		}
@@ -253,7 +278,7 @@ func resourceAviatrixSpokeVpcReadIfRequired(d *schema.ResourceData, meta interfa
 }
 
 func resourceAviatrixSpokeVpcRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
 
 	gwName := d.Get("gw_name").(string)
 	if gwName == "" {
@@ -365,7 +390,9 @@ func resourceAviatrixSpokeVpcRead(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -404,13 +431,13 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 		if singleAz == "enabled" {
 			log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.EnableSingleAZGateway(singleAZGateway)
+			err := client.EnableSingleAZGatewayWithContext(ctx, singleAZGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable single AZ GW HA: %s", err)
 			}
 		} else if singleAz == "disabled" {
 			log.Printf("[INFO] Enable Single AZ GW HA: %#v", singleAZGateway)
-			err := client.DisableSingleAZGateway(singleAZGateway)
+			err := client.DisableSingleAZGatewayWithContext(ctx, singleAZGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable single AZ GW HA: %s", err)
 			}
@@ -441,14 +468,14 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 		if len(oldTagList) != 0 || len(newTagList) != 0 {
 			if len(oldTagList) != 0 {
 				tags.TagList = strings.Join(oldTagList, ",")
-				err := client.DeleteTags(tags)
+				err := client.DeleteTagsWithContext(ctx, tags)
 				if err != nil {
 					return fmt.Errorf("failed to delete tags : %s", err)
 				}
 			}
 			if len(newTagList) != 0 {
 				tags.TagList = strings.Join(newTagList, ",")
-				err := client.AddTags(tags)
+				err := client.AddTagsWithContext(ctx, tags)
 				if err != nil {
 					return fmt.Errorf("failed to add tags : %s", err)
 				}
@@ -465,7 +492,7 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 		old, _ := d.GetChange("vpc_size")
 		primaryGwSize = old.(string)
 		gateway.GwSize = d.Get("vpc_size").(string)
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix SpokeVpc: %s", err)
 		}
@@ -504,31 +531,47 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 		if newHaGwEnabled {
 			//New configuration to enable HA
-			err := client.EnableHaSpokeVpc(spokeGw)
+			err := client.EnableHaSpokeVpcWithContext(ctx, spokeGw)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
+			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 			if err != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
 			}
 			newHaGwEnabled = true
 		} else if deleteHaGw {
 			//Ha configuration has been deleted
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
 			}
 		} else if changeHaGw {
 			//HA subnet has been modified. Delete older HA GW,
 			// and launch new HA GW in new subnet.
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("spokeGw_name").(string)
 			//New configuration to enable HA
-			haErr := client.EnableHaSpokeVpc(spokeGw)
+			haErr := client.EnableHaSpokeVpcWithContext(ctx, spokeGw)
 			if haErr != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
 			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix SpokeVpc: %s", err)
+			}
 		}
 		d.SetPartial("ha_subnet")
 		d.SetPartial("ha_zone")
@@ -542,7 +585,7 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 			// OR
 			// newly configured Ha gateway is set to be different size than primary gateway
 			// (when ha gateway is enabled, it's size is by default the same as primary gateway)
-			_, err := client.GetGateway(haGateway)
+			_, err := client.GetGatewayWithContext(ctx, haGateway)
 			if err != nil {
 				if err == goaviatrix.ErrNotFound {
 					d.Set("ha_gw_size", "")
@@ -558,7 +601,7 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 				return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
 					"ha_subnet or ha_zone is set. Example: t2.micro or us-west1-b")
 			}
-			err = client.UpdateGateway(haGateway)
+			err = client.UpdateGatewayWithContext(ctx, haGateway)
 			log.Printf("[INFO] Updating HA Gateway size to: %s ", haGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Spoke HA Gw size: %s", err)
@@ -574,13 +617,13 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 		o, n := d.GetChange("enable_nat")
 		if o == "yes" && n == "no" {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
 		}
 		if o == "no" && n == "yes" {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
@@ -597,26 +640,35 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 
 		o, n := d.GetChange("transit_gw")
+		oldKey, newKey := transitGatewayMutexKey(o.(string)), transitGatewayMutexKey(n.(string))
 		if o == "" {
 			//New configuration to join to transit GW
-			err := client.SpokeJoinTransit(spokeVPC)
+			aviatrixMutexKV.Lock(newKey)
+			err := client.SpokeJoinTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(newKey)
 			if err != nil {
 				return fmt.Errorf("failed to join transit VPC: %s", err)
 			}
 		} else if n == "" {
 			//Transit GW has been deleted, leave transit GW.
-			err := client.SpokeLeaveTransit(spokeVPC)
+			aviatrixMutexKV.Lock(oldKey)
+			err := client.SpokeLeaveTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(oldKey)
 			if err != nil {
 				return fmt.Errorf("failed to leave transit VPC: %s", err)
 			}
 		} else {
 			//Change transit GW
-			err := client.SpokeLeaveTransit(spokeVPC)
+			aviatrixMutexKV.Lock(oldKey)
+			err := client.SpokeLeaveTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(oldKey)
 			if err != nil {
 				return fmt.Errorf("failed to leave transit VPC: %s", err)
 			}
 
-			err = client.SpokeJoinTransit(spokeVPC)
+			aviatrixMutexKV.Lock(newKey)
+			err = client.SpokeJoinTransitWithContext(ctx, spokeVPC)
+			aviatrixMutexKV.Unlock(newKey)
 			if err != nil {
 				return fmt.Errorf("failed to join transit VPC: %s", err)
 			}
@@ -631,7 +683,9 @@ func resourceAviatrixSpokeVpcUpdate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAviatrixSpokeVpcDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -644,7 +698,9 @@ func resourceAviatrixSpokeVpcDelete(d *schema.ResourceData, meta interface{}) er
 			GwName: d.Get("gw_name").(string),
 		}
 
-		err := client.SpokeLeaveTransit(spokeVPC)
+		aviatrixMutexKV.Lock(transitGatewayMutexKey(transitGw))
+		err := client.SpokeLeaveTransitWithContext(ctx, spokeVPC)
+		aviatrixMutexKV.Unlock(transitGatewayMutexKey(transitGw))
 		if err != nil {
 			return fmt.Errorf("failed to leave transit VPC: %s", err)
 		}
@@ -656,13 +712,21 @@ func resourceAviatrixSpokeVpcDelete(d *schema.ResourceData, meta interface{}) er
 	if haSubnet != "" || haZone != "" {
 		//Delete HA Gw too
 		gateway.GwName += "-hagw"
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayWithContext(ctx, gateway)
+		if err != nil {
+			return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
+		}
+		err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 		if err != nil {
 			return fmt.Errorf("failed to delete Aviatrix SpokeVpc HA gateway: %s", err)
 		}
 	}
 	gateway.GwName = d.Get("gw_name").(string)
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayWithContext(ctx, gateway)
+	if err != nil {
+		return fmt.Errorf("failed to delete Aviatrix SpokeVpc: %s", err)
+	}
+	err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix SpokeVpc: %s", err)
 	}
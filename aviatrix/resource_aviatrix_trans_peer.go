@@ -32,17 +32,18 @@ func resourceAviatrixTransPeer() *schema.Resource {
 				Description: "Name of nexthop gateway.",
 			},
 			"reachable_cidr": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Destination CIDR.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Destination CIDR.",
 			},
 		},
 	}
 }
 
 func resourceAviatrixTransPeerCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	transPeer := &goaviatrix.TransPeer{
 		Source:        d.Get("source").(string),
@@ -62,7 +63,7 @@ func resourceAviatrixTransPeerCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAviatrixTransPeerRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	sourceGw := d.Get("source").(string)
 	nestHopGw := d.Get("nexthop").(string)
@@ -100,7 +101,7 @@ func resourceAviatrixTransPeerRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixTransPeerDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 	transPeer := &goaviatrix.TransPeer{
 		Source:        d.Get("source").(string),
 		Nexthop:       d.Get("nexthop").(string),
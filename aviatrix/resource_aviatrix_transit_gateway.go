@@ -1,9 +1,11 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -18,12 +20,24 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffAll(
+			resourceAviatrixTransitGatewayCustomizeDiff,
+			forceNewIfChanged("cloud_type", "account_name", "gw_name", "vpc_id", "vpc_reg", "subnet",
+				"insane_mode", "insane_mode_az"),
+			customizeDiffTagsAll,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateCloudType(goaviatrix.AWS, goaviatrix.ARM),
+				Description:  "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
@@ -51,9 +65,10 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Name.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Name.",
 			},
 			"insane_mode_az": {
 				Type:        schema.TypeString,
@@ -62,10 +77,11 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Description: "AZ of subnet being created for Insane Mode Transit Gateway. Required if insane_mode is enabled.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet.",
 			},
 			"ha_insane_mode_az": {
 				Type:        schema.TypeString,
@@ -86,11 +102,26 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 				Description: "Enable or disable Source NAT for this container.",
 			},
 			"tag_list": {
-				Type:        schema.TypeList,
+				Type:          schema.TypeList,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				Default:       nil,
+				Deprecated:    tagListDeprecation,
+				ConflictsWith: []string{"tags"},
+				Description:   "Instance tag of cloud provider.",
+			},
+			"tags": {
+				Type:          schema.TypeMap,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Optional:      true,
+				ConflictsWith: []string{"tag_list"},
+				Description:   "Map of tags to assign to the instance. Only supported for AWS.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Default:     nil,
-				Description: "Instance tag of cloud provider.",
+				Computed:    true,
+				Description: "All tags of the instance, including the provider default_tags.",
 			},
 			"enable_hybrid_connection": {
 				Type:        schema.TypeBool,
@@ -120,8 +151,43 @@ func resourceAviatrixTransitGateway() *schema.Resource {
 	}
 }
 
+// resourceAviatrixTransitGatewayCustomizeDiff checks the attributes that
+// depend on each other, so that inconsistent configurations fail at plan
+// time.
+func resourceAviatrixTransitGatewayCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	notAWS := d.NewValueKnown("cloud_type") && d.Get("cloud_type").(int) != goaviatrix.AWS
+
+	if d.Get("insane_mode").(bool) {
+		if notAWS {
+			return fmt.Errorf("insane_mode is only support for aws (cloud_type = 1)")
+		}
+		if diffIsUnset(d, "insane_mode_az") {
+			return fmt.Errorf("insane_mode_az needed if insane_mode is enabled")
+		}
+		if diffIsSet(d, "ha_subnet") && diffIsUnset(d, "ha_insane_mode_az") {
+			return fmt.Errorf("ha_insane_mode_az needed if insane_mode is enabled and ha_subnet is set")
+		}
+	}
+	if diffIsSet(d, "ha_insane_mode_az") && diffIsUnset(d, "ha_subnet") {
+		return fmt.Errorf("ha_subnet needed if ha_insane_mode_az is set")
+	}
+	if diffIsSet(d, "ha_subnet") && diffIsUnset(d, "ha_gw_size") {
+		return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
+			"ha_subnet is set. Example: t2.micro")
+	}
+	if (diffIsSet(d, "tag_list") || diffIsSet(d, "tags")) && notAWS {
+		return fmt.Errorf("'tags' and 'tag_list' are only supported for AWS cloud type 1")
+	}
+	if d.Get("enable_hybrid_connection").(bool) && notAWS {
+		return fmt.Errorf("'enable_hybrid_connection' is only supported for AWS cloud type 1")
+	}
+	return nil
+}
+
 func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	gateway := &goaviatrix.TransitVpc{
 		CloudType:              d.Get("cloud_type").(int),
@@ -162,17 +228,6 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 	}
 
 	insaneMode := d.Get("insane_mode").(bool)
-	if insaneMode == true {
-		if cloudType != 1 {
-			return fmt.Errorf("insane_mode is only support for aws (cloud_type = 1)")
-		}
-		if d.Get("insane_mode_az").(string) == "" {
-			return fmt.Errorf("insane_mode_az needed if insane_mode is enabled")
-		}
-		if d.Get("ha_subnet").(string) != "" && d.Get("ha_insane_mode_az").(string) == "" {
-			return fmt.Errorf("ha_insane_mode_az needed if insane_mode is enabled and ha_subnet is set")
-		}
-	}
 	if insaneMode == true {
 		gateway.InsaneMode = "on"
 
@@ -187,14 +242,14 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 
 	haSubnet := d.Get("ha_subnet").(string)
 	haGwSize := d.Get("ha_gw_size").(string)
-	if haGwSize == "" && haSubnet != "" {
-		return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
-			"ha_subnet is set. Example: t2.micro")
+
+	if gateway.CloudType == 1 {
+		gateway.TagList = launchTagList(meta.(goaviatrix.TagAPI), d)
 	}
 
 	log.Printf("[INFO] Creating Aviatrix Transit Gateway: %#v", gateway)
 
-	err := client.LaunchTransitVpc(gateway)
+	err := client.LaunchTransitVpcWithContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix Transit Gateway: %s", err)
 	}
@@ -204,6 +259,11 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 	flag := false
 	defer resourceAviatrixTransitGatewayReadIfRequired(d, meta, &flag)
 
+	err = client.WaitForGatewayReadyWithContext(ctx, gateway.GwName, 0)
+	if err != nil {
+		return fmt.Errorf("failed to create Aviatrix Transit Gateway: %s", err)
+	}
+
 	if haSubnet != "" {
 		//Enable HA
 		transitGateway := &goaviatrix.TransitVpc{
@@ -220,7 +280,11 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 
 		log.Printf("[INFO] Enabling HA on Transit Gateway: %#v", haSubnet)
 
-		err = client.EnableHaTransitVpc(transitGateway)
+		err = client.EnableHaTransitVpcWithContext(ctx, transitGateway)
+		if err != nil {
+			return fmt.Errorf("failed to enable2 HA Aviatrix Transit Gateway: %s", err)
+		}
+		err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 		if err != nil {
 			return fmt.Errorf("failed to enable2 HA Aviatrix Transit Gateway: %s", err)
 		}
@@ -237,7 +301,7 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 				GwName:    d.Get("gw_name").(string) + "-hagw",
 			}
 			haGateway.GwSize = d.Get("ha_gw_size").(string)
-			err := client.UpdateGateway(haGateway)
+			err := client.UpdateGatewayWithContext(ctx, haGateway)
 			log.Printf("[INFO] Resizing Transit HA GAteway size to: %s ", haGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Transit HA Gateway size: %s", err)
@@ -245,41 +309,16 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 		}
 	}
 
-	if _, ok := d.GetOk("tag_list"); ok {
-		if cloudType != 1 {
-			return fmt.Errorf("'tag_list' is only supported for AWS cloud type 1")
-		}
-		tagList := d.Get("tag_list").([]interface{})
-		tagListStr := goaviatrix.ExpandStringList(tagList)
-		gateway.TagList = strings.Join(tagListStr, ",")
-		tags := &goaviatrix.Tags{
-			CloudType:    1,
-			ResourceType: "gw",
-			ResourceName: d.Get("gw_name").(string),
-			TagList:      gateway.TagList,
-		}
-		err = client.AddTags(tags)
-		if err != nil {
-			return fmt.Errorf("failed to add tags: %s", err)
-		}
-	}
-
 	enableHybridConnection := d.Get("enable_hybrid_connection").(bool)
-	if enableHybridConnection && cloudType != 1 {
-		return fmt.Errorf("'enable_hybrid_connection' is only supported for AWS cloud type 1")
-	}
 	if enableHybridConnection == true {
-		if cloudType != 1 {
-			return fmt.Errorf("'enable_hybrid_connection' is only supported for AWS cloud type 1")
-		}
-		err := client.AttachTransitGWForHybrid(gateway)
+		err := client.AttachTransitGWForHybridWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable transit GW for Hybrid: %s", err)
 		}
 	}
 
 	if connectedTransit {
-		err := client.EnableConnectedTransit(gateway)
+		err := client.EnableConnectedTransitWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable connected transit: %s", err)
 		}
@@ -289,7 +328,7 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 		gw := &goaviatrix.Gateway{
 			GwName: gateway.GwName,
 		}
-		err := client.EnableSNat(gw)
+		err := client.EnableSNatWithContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("failed to enable SNAT: %s", err)
 		}
@@ -297,13 +336,13 @@ func resourceAviatrixTransitGatewayCreate(d *schema.ResourceData, meta interface
 
 	enableFireNetInterfaces := d.Get("enable_firenet_interfaces").(bool)
 	if enableFireNetInterfaces {
-		err := client.EnableGatewayFireNetInterfaces(gateway)
+		err := client.EnableGatewayFireNetInterfacesWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable transit GW for FireNet Interfaces: %s", err)
 		}
 	}
 
-	return resourceAviatrixTransitVpcReadIfRequired(d, meta, &flag)
+	return resourceAviatrixTransitGatewayReadIfRequired(d, meta, &flag)
 }
 
 func resourceAviatrixTransitGatewayReadIfRequired(d *schema.ResourceData, meta interface{}, flag *bool) error {
@@ -314,8 +353,14 @@ func resourceAviatrixTransitGatewayReadIfRequired(d *schema.ResourceData, meta i
 	return nil
 }
 
+// resourceAviatrixTransitGatewayRead refreshes every attribute from
+// list_vpcs_summary, and enable_firenet_interfaces from GetGatewayDetail, so
+// changes made on the controller show up as drift. enable_hybrid_connection
+// is only read for AWS and insane_mode_az and ha_insane_mode_az only for
+// insane mode gateways; they are cleared otherwise. tag_list and tags are
+// only read for AWS, the one cloud the controller tags gateways in.
 func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
 
 	gwName := d.Get("gw_name").(string)
 	if gwName == "" {
@@ -393,23 +438,9 @@ func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}
 			ResourceType: "gw",
 			ResourceName: d.Get("gw_name").(string),
 		}
-		tagList, err := client.GetTags(tags)
+		err := readTags(meta.(goaviatrix.TagAPI), d, tags)
 		if err != nil {
-			return fmt.Errorf("unable to read tag_list for gateway: %v due to %v", gateway.GwName, err)
-		}
-		var tagListStr []string
-		if _, ok := d.GetOk("tag_list"); ok {
-			tagList1 := d.Get("tag_list").([]interface{})
-			tagListStr = goaviatrix.ExpandStringList(tagList1)
-		}
-		if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
-			if err := d.Set("tag_list", tagList); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
-		} else {
-			if err := d.Set("tag_list", tagListStr); err != nil {
-				log.Printf("[WARN] Error setting tag_list for (%s): %s", d.Id(), err)
-			}
+			return fmt.Errorf("unable to read tags for gateway: %v due to %v", gateway.GwName, err)
 		}
 	}
 
@@ -439,7 +470,9 @@ func resourceAviatrixTransitGatewayRead(d *schema.ResourceData, meta interface{}
 }
 
 func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -452,34 +485,9 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 
 	d.Partial(true)
 
-	if d.HasChange("cloud_type") {
-		return fmt.Errorf("updating cloud_type is not allowed")
-	}
-	if d.HasChange("account_name") {
-		return fmt.Errorf("updating account_name is not allowed")
-	}
-	if d.HasChange("gw_name") {
-		return fmt.Errorf("updating gw_name is not allowed")
-	}
-	if d.HasChange("vpc_id") {
-		return fmt.Errorf("updating vpc_id is not allowed")
-	}
-	if d.HasChange("vpc_reg") {
-		return fmt.Errorf("updating vpc_reg is not allowed")
-	}
-	if d.HasChange("subnet") {
-		return fmt.Errorf("updating subnet is not allowed")
-	}
-	if d.HasChange("insane_mode") {
-		return fmt.Errorf("updating insane_mode is not allowed")
-	}
-	if d.HasChange("insane_mode_az") {
-		return fmt.Errorf("updating insane_mode_az is not allowed")
-	}
-
 	if d.HasChange("gw_size") {
 		gateway.GwSize = d.Get("gw_size").(string)
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix Transit Gateway: %s", err)
 		}
@@ -493,10 +501,6 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		if d.Get("insane_mode").(bool) == true {
 			var haStrs []string
 			insaneModeHaAz := d.Get("ha_insane_mode_az").(string)
-
-			if insaneModeHaAz == "" {
-				return fmt.Errorf("ha_insane_mode_az needed if insane_mode is enabled and ha_subnet is set")
-			}
 			haStrs = append(haStrs, transitGateway.HASubnet, insaneModeHaAz)
 			transitGateway.HASubnet = strings.Join(haStrs, "~~")
 		}
@@ -504,75 +508,62 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		o, n := d.GetChange("ha_subnet")
 		if o == "" {
 			//New configuration to enable HA
-			err := client.EnableHaTransitVpc(transitGateway)
+			err := client.EnableHaTransitVpcWithContext(ctx, transitGateway)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix Transit Gateway: %s", err)
+			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 			if err != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix Transit Gateway: %s", err)
 			}
 		} else if n == "" {
 			//Ha configuration has been deleted
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
 			}
 		} else {
 			//HA subnet has been modified. Delete older HA GW, and launch new HA GW in new subnet.
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("gw_name").(string)
 			//New configuration to enable HA
-			haErr := client.EnableHaTransitVpc(transitGateway)
+			haErr := client.EnableHaTransitVpcWithContext(ctx, transitGateway)
 			if haErr != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix Transit Gateway: %s", err)
 			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix Transit Gateway: %s", err)
+			}
 		}
 		d.SetPartial("ha_subnet")
 	}
 
-	if gateway.CloudType == 1 {
-		if d.HasChange("tag_list") {
-			tags := &goaviatrix.Tags{
-				CloudType:    1,
-				ResourceType: "gw",
-				ResourceName: d.Get("gw_name").(string),
-			}
-			o, n := d.GetChange("tag_list")
-			if o == nil {
-				o = new([]interface{})
-			}
-			if n == nil {
-				n = new([]interface{})
-			}
-			os := o.([]interface{})
-			ns := n.([]interface{})
-			oldList := goaviatrix.ExpandStringList(os)
-			newList := goaviatrix.ExpandStringList(ns)
-			oldTagList := goaviatrix.Difference(oldList, newList)
-			newTagList := goaviatrix.Difference(newList, oldList)
-			if len(oldTagList) != 0 || len(newTagList) != 0 {
-				if len(oldTagList) != 0 {
-					tags.TagList = strings.Join(oldTagList, ",")
-					err := client.DeleteTags(tags)
-					if err != nil {
-						return fmt.Errorf("failed to delete tags : %s", err)
-					}
-				}
-				if len(newTagList) != 0 {
-					tags.TagList = strings.Join(newTagList, ",")
-					err := client.AddTags(tags)
-					if err != nil {
-						return fmt.Errorf("failed to add tags : %s", err)
-					}
-				}
-			}
-			d.SetPartial("tag_list")
+	if hasTagsChange(d) && gateway.CloudType == 1 {
+		tags := &goaviatrix.Tags{
+			CloudType:    1,
+			ResourceType: "gw",
+			ResourceName: d.Get("gw_name").(string),
 		}
-	} else {
-		if d.HasChange("tag_list") {
-			return fmt.Errorf("'tag_list' is only supported for AWS cloud type 1")
+		err := updateTags(ctx, meta.(goaviatrix.TagAPI), d, tags)
+		if err != nil {
+			return err
 		}
+		d.SetPartial("tag_list")
+		d.SetPartial("tags")
+		d.SetPartial("tags_all")
 	}
 
 	if gateway.CloudType == 1 {
@@ -586,21 +577,17 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 			}
 			enableHybridConnection := d.Get("enable_hybrid_connection").(bool)
 			if enableHybridConnection == true {
-				err := client.AttachTransitGWForHybrid(transitGateway)
+				err := client.AttachTransitGWForHybridWithContext(ctx, transitGateway)
 				if err != nil {
 					return fmt.Errorf("failed to enable transit GW for Hybrid: %s", err)
 				}
 			} else {
-				err := client.DetachTransitGWForHybrid(transitGateway)
+				err := client.DetachTransitGWForHybridWithContext(ctx, transitGateway)
 				if err != nil {
 					return fmt.Errorf("failed to disable transit GW for Hybrid: %s", err)
 				}
 			}
 		}
-	} else {
-		if d.HasChange("enable_hybrid_connection") {
-			return fmt.Errorf("'enable_hybrid_connection' is only supported for AWS cloud type 1")
-		}
 	}
 
 	if d.HasChange("connected_transit") {
@@ -614,12 +601,12 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		connectedTransit := d.Get("connected_transit").(bool)
 
 		if connectedTransit {
-			err := client.EnableConnectedTransit(transitGateway)
+			err := client.EnableConnectedTransitWithContext(ctx, transitGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable connected transit: %s", err)
 			}
 		} else {
-			err := client.DisableConnectedTransit(transitGateway)
+			err := client.DisableConnectedTransitWithContext(ctx, transitGateway)
 			if err != nil {
 				return fmt.Errorf("failed to disable connected transit: %s", err)
 			}
@@ -629,7 +616,7 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if d.HasChange("ha_gw_size") {
-		_, err := client.GetGateway(haGateway)
+		_, err := client.GetGatewayWithContext(ctx, haGateway)
 		if err != nil {
 			if err == goaviatrix.ErrNotFound {
 				d.Set("ha_gw_size", "")
@@ -641,12 +628,7 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		}
 
 		haGateway.GwSize = d.Get("ha_gw_size").(string)
-		if haGateway.GwSize == "" {
-			return fmt.Errorf("A valid non empty ha_gw_size parameter is mandatory for this resource if " +
-				"ha_subnet is set. Example: t2.micro")
-		}
-
-		err = client.UpdateGateway(haGateway)
+		err = client.UpdateGatewayWithContext(ctx, haGateway)
 		log.Printf("[INFO] Updating Transit HA GAteway size to: %s ", haGateway.GwSize)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix Transit HA Gw size: %s", err)
@@ -663,12 +645,12 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		enableNat := d.Get("enable_snat").(bool)
 
 		if enableNat {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
 		} else {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
@@ -683,12 +665,12 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 		}
 		enableFireNetInterfaces := d.Get("enable_firenet_interfaces").(bool)
 		if enableFireNetInterfaces {
-			err := client.EnableGatewayFireNetInterfaces(transitGW)
+			err := client.EnableGatewayFireNetInterfacesWithContext(ctx, transitGW)
 			if err != nil {
 				return fmt.Errorf("failed to enable transit GW for FireNet Interfaces: %s", err)
 			}
 		} else {
-			err := client.DisableGatewayFireNetInterfaces(transitGW)
+			err := client.DisableGatewayFireNetInterfacesWithContext(ctx, transitGW)
 			if err != nil {
 				return fmt.Errorf("failed to remove transit GW for FireNet Interfaces: %s", err)
 			}
@@ -700,7 +682,9 @@ func resourceAviatrixTransitGatewayUpdate(d *schema.ResourceData, meta interface
 }
 
 func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
@@ -716,7 +700,7 @@ func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface
 			GwName:    d.Get("gw_name").(string),
 		}
 
-		err := client.DisableGatewayFireNetInterfaces(gw)
+		err := client.DisableGatewayFireNetInterfacesWithContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("failed to disable transit GW for FireNet Interfaces: %s", err)
 		}
@@ -726,7 +710,11 @@ func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface
 	if haSubnet := d.Get("ha_subnet").(string); haSubnet != "" {
 		gateway.GwName += "-hagw"
 
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayWithContext(ctx, gateway)
+		if err != nil {
+			return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
+		}
+		err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 		if err != nil {
 			return fmt.Errorf("failed to delete Aviatrix Transit Gateway HA gateway: %s", err)
 		}
@@ -734,7 +722,11 @@ func resourceAviatrixTransitGatewayDelete(d *schema.ResourceData, meta interface
 
 	gateway.GwName = d.Get("gw_name").(string)
 
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayWithContext(ctx, gateway)
+	if err != nil {
+		return fmt.Errorf("failed to delete Aviatrix Transit Gateway: %s", err)
+	}
+	err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix Transit Gateway: %s", err)
 	}
@@ -36,7 +36,7 @@ func resourceAviatrixTransitGatewayPeering() *schema.Resource {
 }
 
 func resourceAviatrixTransitGatewayPeeringCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TransitAPI)
 
 	transitGatewayPeering := &goaviatrix.TransitGatewayPeering{
 		TransitGatewayName1: d.Get("transit_gateway_name1").(string),
@@ -45,7 +45,13 @@ func resourceAviatrixTransitGatewayPeeringCreate(d *schema.ResourceData, meta in
 
 	log.Printf("[INFO] Creating Aviatrix Transit Gateway peering: %#v", transitGatewayPeering)
 
+	keys := []string{
+		transitGatewayMutexKey(transitGatewayPeering.TransitGatewayName1),
+		transitGatewayMutexKey(transitGatewayPeering.TransitGatewayName2),
+	}
+	aviatrixMutexKV.Lock(keys...)
 	err := client.CreateTransitGatewayPeering(transitGatewayPeering)
+	aviatrixMutexKV.Unlock(keys...)
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix Transit Gateway peering: %s", err)
 	}
@@ -55,7 +61,7 @@ func resourceAviatrixTransitGatewayPeeringCreate(d *schema.ResourceData, meta in
 }
 
 func resourceAviatrixTransitGatewayPeeringRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TransitAPI)
 
 	transitGwName1 := d.Get("transit_gateway_name1").(string)
 	transitGwName2 := d.Get("transit_gateway_name2").(string)
@@ -87,7 +93,7 @@ func resourceAviatrixTransitGatewayPeeringRead(d *schema.ResourceData, meta inte
 }
 
 func resourceAviatrixTransitGatewayPeeringDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TransitAPI)
 
 	transitGatewayPeering := &goaviatrix.TransitGatewayPeering{
 		TransitGatewayName1: d.Get("transit_gateway_name1").(string),
@@ -96,7 +102,13 @@ func resourceAviatrixTransitGatewayPeeringDelete(d *schema.ResourceData, meta in
 
 	log.Printf("[INFO] Deleting Aviatrix Transit Gateway peering: %#v", transitGatewayPeering)
 
+	keys := []string{
+		transitGatewayMutexKey(transitGatewayPeering.TransitGatewayName1),
+		transitGatewayMutexKey(transitGatewayPeering.TransitGatewayName2),
+	}
+	aviatrixMutexKV.Lock(keys...)
 	err := client.DeleteTransitGatewayPeering(transitGatewayPeering)
+	aviatrixMutexKV.Unlock(keys...)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix Transit Gateway peering: %s", err)
 	}
@@ -7,8 +7,10 @@ import (
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/mock"
 )
 
 func TestAccAviatrixTransitGateway_basic(t *testing.T) {
@@ -195,3 +197,122 @@ func testAccCheckTransitGatewayDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceAviatrixTransitGatewayRead(t *testing.T) {
+	raw := map[string]interface{}{
+		"cloud_type":   1,
+		"account_name": "tfa-test",
+		"gw_name":      "tfg-transit",
+		"vpc_id":       "vpc-0123",
+		"vpc_reg":      "us-west-1",
+		"gw_size":      "t2.micro",
+		"subnet":       "10.0.0.0/24",
+		"ha_subnet":    "10.0.1.0/24",
+		"ha_gw_size":   "t2.micro",
+		"tags":         map[string]interface{}{"env": "dev"},
+	}
+	// The controller reports settings changed outside terraform.
+	gateways := map[string]*goaviatrix.Gateway{
+		"tfg-transit": {
+			CloudType:              1,
+			AccountName:            "tfa-test",
+			GwName:                 "tfg-transit",
+			VpcID:                  "vpc-0123~~tfg-vpc",
+			VpcRegion:              "us-west-1",
+			VpcNet:                 "10.0.0.0/24",
+			GwSize:                 "c5.xlarge",
+			EnableNat:              "yes",
+			EnableHybridConnection: true,
+			ConnectedTransit:       "yes",
+			InsaneMode:             "yes",
+			GatewayZone:            "us-west-1a",
+		},
+		"tfg-transit-hagw": {
+			CloudType:   1,
+			VpcNet:      "10.0.2.0/24",
+			GwSize:      "c5.large",
+			InsaneMode:  "yes",
+			GatewayZone: "us-west-1b",
+		},
+	}
+	getGateway := func(gw *goaviatrix.Gateway) (*goaviatrix.Gateway, error) {
+		if found, ok := gateways[gw.GwName]; ok {
+			return found, nil
+		}
+		return nil, goaviatrix.ErrNotFound
+	}
+
+	runCRUDTests(t, resourceAviatrixTransitGateway(), []crudTestCase{
+		{
+			name: "read drift",
+			op:   resourceAviatrixTransitGatewayRead,
+			raw:  raw,
+			id:   "tfg-transit",
+			client: &mock.Client{
+				GetGatewayFunc: getGateway,
+				GetGatewayDetailFunc: func(*goaviatrix.Gateway) (*goaviatrix.GatewayDetail, error) {
+					return &goaviatrix.GatewayDetail{DMZEnabled: true}, nil
+				},
+				GetTagsFunc: func(*goaviatrix.Tags) ([]string, error) { return []string{"env:prod"}, nil },
+			},
+			wantID: "tfg-transit",
+			calls:  []string{"GetGateway", "GetGatewayDetail", "GetTags", "GetDefaultTags", "GetGateway"},
+			check: func(t *testing.T, d *schema.ResourceData) {
+				want := map[string]interface{}{
+					"vpc_id":                    "vpc-0123",
+					"gw_size":                   "c5.xlarge",
+					"enable_snat":               true,
+					"enable_hybrid_connection":  true,
+					"connected_transit":         true,
+					"insane_mode":               true,
+					"insane_mode_az":            "us-west-1a",
+					"enable_firenet_interfaces": true,
+					"tags.env":                  "prod",
+					"ha_subnet":                 "10.0.2.0/24",
+					"ha_gw_size":                "c5.large",
+					"ha_insane_mode_az":         "us-west-1b",
+				}
+				for k, v := range want {
+					if got := d.Get(k); got != v {
+						t.Errorf("%s = %v, want %v", k, got, v)
+					}
+				}
+			},
+		},
+		{
+			name: "read removed ha gateway",
+			op:   resourceAviatrixTransitGatewayRead,
+			raw:  raw,
+			id:   "tfg-transit",
+			client: &mock.Client{
+				GetGatewayFunc: func(gw *goaviatrix.Gateway) (*goaviatrix.Gateway, error) {
+					if gw.GwName == "tfg-transit-hagw" {
+						return nil, goaviatrix.ErrNotFound
+					}
+					return getGateway(gw)
+				},
+				GetGatewayDetailFunc: func(*goaviatrix.Gateway) (*goaviatrix.GatewayDetail, error) {
+					return &goaviatrix.GatewayDetail{}, nil
+				},
+			},
+			wantID: "tfg-transit",
+			calls:  []string{"GetGateway", "GetGatewayDetail", "GetTags", "GetDefaultTags", "GetGateway"},
+			check: func(t *testing.T, d *schema.ResourceData) {
+				for _, k := range []string{"ha_subnet", "ha_gw_size", "ha_insane_mode_az"} {
+					if got := d.Get(k); got != "" {
+						t.Errorf("%s = %q, want it cleared", k, got)
+					}
+				}
+			},
+		},
+		{
+			name:   "read deleted gateway",
+			op:     resourceAviatrixTransitGatewayRead,
+			raw:    raw,
+			id:     "tfg-transit",
+			client: &mock.Client{GetGatewayFunc: func(*goaviatrix.Gateway) (*goaviatrix.Gateway, error) { return nil, goaviatrix.ErrNotFound }},
+			wantID: "",
+			calls:  []string{"GetGateway"},
+		},
+	})
+}
@@ -1,9 +1,11 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
@@ -18,15 +20,21 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		SchemaVersion: 2,
 		MigrateState:  resourceTransitVpcMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateCloudType(goaviatrix.AWS, goaviatrix.ARM),
+				Description:  "Type of cloud service provider, requires an integer value. Use 1 for AWS.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
@@ -54,9 +62,10 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Description: "Size of the gateway instance.",
 			},
 			"subnet": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Public Subnet Name.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Public Subnet Name.",
 			},
 			"insane_mode_az": {
 				Type:        schema.TypeString,
@@ -65,10 +74,11 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Description: "AZ of subnet being created for Insane Mode Transit Gateway. Required if insane_mode is enabled.",
 			},
 			"ha_subnet": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "HA Subnet.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDR,
+				Description:  "HA Subnet.",
 			},
 			"ha_insane_mode_az": {
 				Type:        schema.TypeString,
@@ -83,10 +93,11 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Description: "HA Gateway Size. Mandatory if HA is enabled (ha_subnet is set).",
 			},
 			"enable_nat": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "no",
-				Description: "Enable NAT for this container.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no",
+				ValidateFunc: validateStringInSlice([]string{"", "yes", "no"}),
+				Description:  "Enable NAT for this container.",
 			},
 			"tag_list": {
 				Type:        schema.TypeList,
@@ -102,10 +113,11 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 				Description: "Sign of readiness for TGW connection.",
 			},
 			"connected_transit": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "no",
-				Description: "Specify Connected Transit status.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "no",
+				ValidateFunc: validateStringInSlice([]string{"yes", "no"}),
+				Description:  "Specify Connected Transit status.",
 			},
 			"insane_mode": {
 				Type:        schema.TypeBool,
@@ -124,7 +136,9 @@ func resourceAviatrixTransitVpc() *schema.Resource {
 }
 
 func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 	gateway := &goaviatrix.TransitVpc{
 		CloudType:              d.Get("cloud_type").(int),
 		AccountName:            d.Get("account_name").(string),
@@ -189,7 +203,7 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 
 	log.Printf("[INFO] Creating Aviatrix TransitVpc: %#v", gateway)
 
-	err := client.LaunchTransitVpc(gateway)
+	err := client.LaunchTransitVpcWithContext(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix TransitVpc: %s", err)
 	}
@@ -199,6 +213,11 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 	flag := false
 	defer resourceAviatrixTransitVpcReadIfRequired(d, meta, &flag)
 
+	err = client.WaitForGatewayReadyWithContext(ctx, gateway.GwName, 0)
+	if err != nil {
+		return fmt.Errorf("failed to create Aviatrix TransitVpc: %s", err)
+	}
+
 	if haSubnet != "" {
 		//Enable HA
 		transitGateway := &goaviatrix.TransitVpc{
@@ -215,7 +234,11 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 
 		log.Printf("[INFO] Enabling HA on Transit Gateway: %#v", haSubnet)
 
-		err = client.EnableHaTransitVpc(transitGateway)
+		err = client.EnableHaTransitVpcWithContext(ctx, transitGateway)
+		if err != nil {
+			return fmt.Errorf("failed to enable2 HA Aviatrix TransitVpc: %s", err)
+		}
+		err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 		if err != nil {
 			return fmt.Errorf("failed to enable2 HA Aviatrix TransitVpc: %s", err)
 		}
@@ -232,7 +255,7 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 				GwName:    d.Get("gw_name").(string) + "-hagw",
 			}
 			haGateway.GwSize = d.Get("ha_gw_size").(string)
-			err := client.UpdateGateway(haGateway)
+			err := client.UpdateGatewayWithContext(ctx, haGateway)
 			log.Printf("[INFO] Resizing Transit HA GAteway size to: %s ", haGateway.GwSize)
 			if err != nil {
 				return fmt.Errorf("failed to update Aviatrix Transit HA Gateway size: %s", err)
@@ -253,7 +276,7 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 			ResourceName: d.Get("gw_name").(string),
 			TagList:      gateway.TagList,
 		}
-		err = client.AddTags(tags)
+		err = client.AddTagsWithContext(ctx, tags)
 		if err != nil {
 			return fmt.Errorf("failed to add tags: %s", err)
 		}
@@ -267,7 +290,7 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 		if cloudType != 1 {
 			return fmt.Errorf("'enable_hybrid_connection' is only supported for AWS cloud type 1")
 		}
-		err := client.AttachTransitGWForHybrid(gateway)
+		err := client.AttachTransitGWForHybridWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable transit GW for Hybrid: %s", err)
 		}
@@ -275,7 +298,7 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 
 	connectedTransit := d.Get("connected_transit").(string)
 	if connectedTransit == "yes" {
-		err := client.EnableConnectedTransit(gateway)
+		err := client.EnableConnectedTransitWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable connected transit: %s", err)
 		}
@@ -285,7 +308,7 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 		gw := &goaviatrix.Gateway{
 			GwName: gateway.GwName,
 		}
-		err := client.EnableSNat(gw)
+		err := client.EnableSNatWithContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("failed to enable SNAT: %s", err)
 		}
@@ -293,7 +316,7 @@ func resourceAviatrixTransitVpcCreate(d *schema.ResourceData, meta interface{})
 
 	enableFireNetInterfaces := d.Get("enable_firenet_interfaces").(bool)
 	if enableFireNetInterfaces {
-		err := client.EnableGatewayFireNetInterfaces(gateway)
+		err := client.EnableGatewayFireNetInterfacesWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to enable transit GW for FireNet Interfaces: %s", err)
 		}
@@ -311,7 +334,7 @@ func resourceAviatrixTransitVpcReadIfRequired(d *schema.ResourceData, meta inter
 }
 
 func resourceAviatrixTransitVpcRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
 
 	gwName := d.Get("gw_name").(string)
 	if gwName == "" {
@@ -423,7 +446,9 @@ func resourceAviatrixTransitVpcRead(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -462,7 +487,7 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 
 	if d.HasChange("vpc_size") {
 		gateway.GwSize = d.Get("vpc_size").(string)
-		err := client.UpdateGateway(gateway)
+		err := client.UpdateGatewayWithContext(ctx, gateway)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix TransitVpc: %s", err)
 		}
@@ -487,29 +512,45 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 		o, n := d.GetChange("ha_subnet")
 		if o == "" {
 			//New configuration to enable HA
-			err := client.EnableHaTransitVpc(transitGateway)
+			err := client.EnableHaTransitVpcWithContext(ctx, transitGateway)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix TransitVpc: %s", err)
+			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
 			if err != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix TransitVpc: %s", err)
 			}
 		} else if n == "" {
 			//Ha configuration has been deleted
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix TransitVpc HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix TransitVpc HA gateway: %s", err)
 			}
 		} else {
 			//HA subnet has been modified. Delete older HA GW, and launch new HA GW in new subnet.
-			err := client.DeleteGateway(haGateway)
+			err := client.DeleteGatewayWithContext(ctx, haGateway)
+			if err != nil {
+				return fmt.Errorf("failed to delete Aviatrix TransitVpc HA gateway: %s", err)
+			}
+			err = client.WaitForGatewayDeletedWithContext(ctx, haGateway.GwName, 0)
 			if err != nil {
 				return fmt.Errorf("failed to delete Aviatrix TransitVpc HA gateway: %s", err)
 			}
 
 			gateway.GwName = d.Get("gw_name").(string)
 			//New configuration to enable HA
-			haErr := client.EnableHaTransitVpc(transitGateway)
+			haErr := client.EnableHaTransitVpcWithContext(ctx, transitGateway)
 			if haErr != nil {
 				return fmt.Errorf("failed to enable HA Aviatrix TransitVpc: %s", err)
 			}
+			err = client.WaitForGatewayReadyWithContext(ctx, d.Get("gw_name").(string)+"-hagw", 0)
+			if err != nil {
+				return fmt.Errorf("failed to enable HA Aviatrix TransitVpc: %s", err)
+			}
 		}
 		d.SetPartial("ha_subnet")
 	}
@@ -537,14 +578,14 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 			if len(oldTagList) != 0 || len(newTagList) != 0 {
 				if len(oldTagList) != 0 {
 					tags.TagList = strings.Join(oldTagList, ",")
-					err := client.DeleteTags(tags)
+					err := client.DeleteTagsWithContext(ctx, tags)
 					if err != nil {
 						return fmt.Errorf("failed to delete tags : %s", err)
 					}
 				}
 				if len(newTagList) != 0 {
 					tags.TagList = strings.Join(newTagList, ",")
-					err := client.AddTags(tags)
+					err := client.AddTagsWithContext(ctx, tags)
 					if err != nil {
 						return fmt.Errorf("failed to add tags : %s", err)
 					}
@@ -569,12 +610,12 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 			}
 			enableHybridConnection := d.Get("enable_hybrid_connection").(bool)
 			if enableHybridConnection == true {
-				err := client.AttachTransitGWForHybrid(transitGateway)
+				err := client.AttachTransitGWForHybridWithContext(ctx, transitGateway)
 				if err != nil {
 					return fmt.Errorf("failed to enable transit GW for Hybrid: %s", err)
 				}
 			} else {
-				err := client.DetachTransitGWForHybrid(transitGateway)
+				err := client.DetachTransitGWForHybridWithContext(ctx, transitGateway)
 				if err != nil {
 					return fmt.Errorf("failed to disable transit GW for Hybrid: %s", err)
 				}
@@ -599,13 +640,13 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 			return fmt.Errorf("connected_transit is not set correctly")
 		}
 		if connectedTransit == "yes" {
-			err := client.EnableConnectedTransit(transitGateway)
+			err := client.EnableConnectedTransitWithContext(ctx, transitGateway)
 			if err != nil {
 				return fmt.Errorf("failed to enable connected transit: %s", err)
 			}
 		}
 		if connectedTransit == "no" {
-			err := client.DisableConnectedTransit(transitGateway)
+			err := client.DisableConnectedTransitWithContext(ctx, transitGateway)
 			if err != nil {
 				return fmt.Errorf("failed to disable connected transit: %s", err)
 			}
@@ -613,7 +654,7 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	if d.HasChange("ha_gw_size") {
-		_, err := client.GetGateway(haGateway)
+		_, err := client.GetGatewayWithContext(ctx, haGateway)
 		if err != nil {
 			if err == goaviatrix.ErrNotFound {
 				d.Set("ha_gw_size", "")
@@ -630,7 +671,7 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 				"ha_subnet is set. Example: t2.micro")
 		}
 
-		err = client.UpdateGateway(haGateway)
+		err = client.UpdateGatewayWithContext(ctx, haGateway)
 		log.Printf("[INFO] Updating Transit HA GAteway size to: %s ", haGateway.GwSize)
 		if err != nil {
 			return fmt.Errorf("failed to update Aviatrix Transit HA Gw size: %s", err)
@@ -645,13 +686,13 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 		}
 		o, n := d.GetChange("enable_nat")
 		if o == "yes" && n == "no" {
-			err := client.DisableSNat(gw)
+			err := client.DisableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to disable SNAT: %s", err)
 			}
 		}
 		if o == "no" && n == "yes" {
-			err := client.EnableSNat(gw)
+			err := client.EnableSNatWithContext(ctx, gw)
 			if err != nil {
 				return fmt.Errorf("failed to enable SNAT: %s", err)
 			}
@@ -665,12 +706,12 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 		}
 		enableFireNetInterfaces := d.Get("enable_firenet_interfaces").(bool)
 		if enableFireNetInterfaces == true {
-			err := client.EnableGatewayFireNetInterfaces(transitGW)
+			err := client.EnableGatewayFireNetInterfacesWithContext(ctx, transitGW)
 			if err != nil {
 				return fmt.Errorf("failed to enable transit GW for FireNet Interfaces: %s", err)
 			}
 		} else {
-			err := client.DisableGatewayFireNetInterfaces(transitGW)
+			err := client.DisableGatewayFireNetInterfacesWithContext(ctx, transitGW)
 			if err != nil {
 				return fmt.Errorf("failed to remove transit GW for FireNet Interfaces: %s", err)
 			}
@@ -682,7 +723,9 @@ func resourceAviatrixTransitVpcUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAviatrixTransitVpcDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.API)
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 	gateway := &goaviatrix.Gateway{
 		CloudType: d.Get("cloud_type").(int),
 		GwName:    d.Get("gw_name").(string),
@@ -696,7 +739,7 @@ func resourceAviatrixTransitVpcDelete(d *schema.ResourceData, meta interface{})
 			CloudType: d.Get("cloud_type").(int),
 			GwName:    d.Get("gw_name").(string),
 		}
-		err := client.DisableGatewayFireNetInterfaces(gw)
+		err := client.DisableGatewayFireNetInterfacesWithContext(ctx, gw)
 		if err != nil {
 			return fmt.Errorf("failed to disable transit GW for FireNet Interfaces: %s", err)
 		}
@@ -705,14 +748,22 @@ func resourceAviatrixTransitVpcDelete(d *schema.ResourceData, meta interface{})
 	//If HA is enabled, delete HA GW first.
 	if haSubnet := d.Get("ha_subnet").(string); haSubnet != "" {
 		gateway.GwName += "-hagw"
-		err := client.DeleteGateway(gateway)
+		err := client.DeleteGatewayWithContext(ctx, gateway)
+		if err != nil {
+			return fmt.Errorf("failed to delete Aviatrix TransitVpc HA gateway: %s", err)
+		}
+		err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 		if err != nil {
 			return fmt.Errorf("failed to delete Aviatrix TransitVpc HA gateway: %s", err)
 		}
 	}
 
 	gateway.GwName = d.Get("gw_name").(string)
-	err := client.DeleteGateway(gateway)
+	err := client.DeleteGatewayWithContext(ctx, gateway)
+	if err != nil {
+		return fmt.Errorf("failed to delete Aviatrix TransitVpc: %s", err)
+	}
+	err = client.WaitForGatewayDeletedWithContext(ctx, gateway.GwName, 0)
 	if err != nil {
 		return fmt.Errorf("failed to delete Aviatrix TransitVpc: %s", err)
 	}
@@ -59,7 +59,7 @@ func resourceAviatrixTunnel() *schema.Resource {
 }
 
 func resourceAviatrixTunnelCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	tunnel := &goaviatrix.Tunnel{
 		VpcName1:        d.Get("gw_name1").(string),
@@ -88,7 +88,7 @@ func resourceAviatrixTunnelCreate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAviatrixTunnelRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	vpcName1 := d.Get("gw_name1").(string)
 	vpcName2 := d.Get("gw_name2").(string)
@@ -130,7 +130,7 @@ func resourceAviatrixTunnelRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixTunnelUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	tunnel := &goaviatrix.Tunnel{
 		VpcName1:        d.Get("gw_name1").(string),
@@ -152,7 +152,7 @@ func resourceAviatrixTunnelUpdate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAviatrixTunnelDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.PeeringAPI)
 
 	tunnel := &goaviatrix.Tunnel{
 		VpcName1: d.Get("gw_name1").(string),
@@ -44,9 +44,10 @@ func resourceAviatrixVGWConn() *schema.Resource {
 				Description: "Id of AWS's VGW that is used for this connection.",
 			},
 			"bgp_local_as_num": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "BGP Local ASN (Autonomous System Number). Integer between 1-65535.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateASN,
+				Description:  "BGP Local ASN (Autonomous System Number). Integer between 1-65535.",
 			},
 			"enable_advertise_transit_cidr": {
 				Type:        schema.TypeBool,
@@ -55,17 +56,18 @@ func resourceAviatrixVGWConn() *schema.Resource {
 				Description: "Switch to Enable/Disable advertise transit VPC network CIDR.",
 			},
 			"bgp_manual_spoke_advertise_cidrs": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Intended CIDR list to advertise to VGW.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateCIDRList,
+				Description:  "Intended CIDR list to advertise to VGW.",
 			},
 		},
 	}
 }
 
 func resourceAviatrixVGWConnCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TransitAPI)
 
 	vgwConn := &goaviatrix.VGWConn{
 		ConnName:      d.Get("conn_name").(string),
@@ -77,7 +79,9 @@ func resourceAviatrixVGWConnCreate(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[INFO] Creating Aviatrix VGW Connection: %#v", vgwConn)
 
+	aviatrixMutexKV.Lock(transitGatewayMutexKey(vgwConn.GwName))
 	err := client.CreateVGWConn(vgwConn)
+	aviatrixMutexKV.Unlock(transitGatewayMutexKey(vgwConn.GwName))
 	if err != nil {
 		return fmt.Errorf("failed to create Aviatrix VGWConn: %s", err)
 	}
@@ -118,7 +122,7 @@ func resourceAviatrixVGWConnReadIfRequired(d *schema.ResourceData, meta interfac
 }
 
 func resourceAviatrixVGWConnRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TransitAPI)
 
 	connName := d.Get("conn_name").(string)
 	vpcID := d.Get("vpc_id").(string)
@@ -162,7 +166,7 @@ func resourceAviatrixVGWConnRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixVGWConnUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TransitAPI)
 
 	vgwConn := &goaviatrix.VGWConn{
 		ConnName: d.Get("conn_name").(string),
@@ -226,7 +230,7 @@ func resourceAviatrixVGWConnUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixVGWConnDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.TransitAPI)
 
 	vgwConn := &goaviatrix.VGWConn{
 		ConnName: d.Get("conn_name").(string),
@@ -235,9 +239,11 @@ func resourceAviatrixVGWConnDelete(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[INFO] Deleting Aviatrix vgw_conn: %#v", vgwConn)
 
+	aviatrixMutexKV.Lock(transitGatewayMutexKey(d.Get("gw_name").(string)))
 	err := client.DeleteVGWConn(vgwConn)
+	aviatrixMutexKV.Unlock(transitGatewayMutexKey(d.Get("gw_name").(string)))
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
+		if goaviatrix.IsNotFound(err) {
 			return nil
 		}
 		return fmt.Errorf("failed to delete Aviatrix VGWConn: %s", err)
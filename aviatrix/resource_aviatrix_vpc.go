@@ -1,6 +1,7 @@
 package aviatrix
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -12,20 +13,26 @@ func resourceAviatrixVpc() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAviatrixVpcCreate,
 		Read:   resourceAviatrixVpcRead,
+		Update: resourceAviatrixVpcUpdate,
 		Delete: resourceAviatrixVpcDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffAll(
+			resourceAviatrixVpcCustomizeDiff,
+			customizeDiffTagsAll,
+		),
 
 		SchemaVersion: 1,
 		MigrateState:  resourceAviatrixVpcMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"cloud_type": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Type of cloud service provider.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCloudType(goaviatrix.CloudTypes...),
+				Description:  "Type of cloud service provider.",
 			},
 			"account_name": {
 				Type:        schema.TypeString,
@@ -46,10 +53,11 @@ func resourceAviatrixVpc() *schema.Resource {
 				Description: "Name of the VPC to be created.",
 			},
 			"cidr": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Subnet of the VPC to be created.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+				Description:  "Subnet of the VPC to be created.",
 			},
 			"aviatrix_transit_vpc": {
 				Type:        schema.TypeBool,
@@ -65,6 +73,18 @@ func resourceAviatrixVpc() *schema.Resource {
 				Default:     false,
 				Description: "Specify the VPC as Aviatrix FireNet VPC or not.",
 			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Map of tags to assign to the VPC. Only supported for AWS.",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "All tags of the VPC, including the provider default_tags.",
+			},
 			"vpc_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -77,9 +97,10 @@ func resourceAviatrixVpc() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"cidr": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Subnet cidr.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCIDR,
+							Description:  "Subnet cidr.",
 						},
 						"name": {
 							Type:        schema.TypeString,
@@ -94,7 +115,7 @@ func resourceAviatrixVpc() *schema.Resource {
 }
 
 func resourceAviatrixVpcCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPCAPI)
 
 	vpc := &goaviatrix.Vpc{
 		CloudType:   d.Get("cloud_type").(int),
@@ -138,11 +159,36 @@ func resourceAviatrixVpcCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.SetId(vpc.Name)
+
+	// the tags are added to the VPC ID, which is only known once it exists
+	if vpc.CloudType == 1 && len(withDefaultTags(meta.(goaviatrix.TagAPI), getTags(d))) != 0 {
+		vC, err := client.GetVpc(&goaviatrix.Vpc{Name: vpc.Name})
+		if err != nil {
+			return fmt.Errorf("couldn't find VPC: %s", err)
+		}
+		tags := &goaviatrix.Tags{
+			CloudType:    1,
+			ResourceType: "vpc",
+			ResourceName: vC.VpcID,
+		}
+		err = createTags(context.Background(), meta.(goaviatrix.TagAPI), d, tags)
+		if err != nil {
+			return fmt.Errorf("failed to add tags: %s", err)
+		}
+	}
+
 	return resourceAviatrixVpcRead(d, meta)
 }
 
+func resourceAviatrixVpcCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if diffIsSet(d, "tags") && d.NewValueKnown("cloud_type") && d.Get("cloud_type").(int) != goaviatrix.AWS {
+		return fmt.Errorf("adding tags only supported for aws, cloud_type must be 1")
+	}
+	return nil
+}
+
 func resourceAviatrixVpcRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPCAPI)
 
 	vpcName := d.Get("name").(string)
 	if vpcName == "" {
@@ -199,11 +245,45 @@ func resourceAviatrixVpcRead(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[WARN] Error setting subnets for (%s): %s", d.Id(), err)
 	}
 
+	if vC.CloudType == 1 {
+		tags := &goaviatrix.Tags{
+			CloudType:    1,
+			ResourceType: "vpc",
+			ResourceName: vC.VpcID,
+		}
+		err := readTags(meta.(goaviatrix.TagAPI), d, tags)
+		if err != nil {
+			return fmt.Errorf("unable to read tags for vpc: %v due to %v", vC.Name, err)
+		}
+	}
+
 	return nil
 }
 
+func resourceAviatrixVpcUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Updating VPC: %s", d.Get("name").(string))
+
+	d.Partial(true)
+	if hasTagsChange(d) && d.Get("cloud_type").(int) == 1 {
+		tags := &goaviatrix.Tags{
+			CloudType:    1,
+			ResourceType: "vpc",
+			ResourceName: d.Get("vpc_id").(string),
+		}
+		err := updateTags(context.Background(), meta.(goaviatrix.TagAPI), d, tags)
+		if err != nil {
+			return err
+		}
+		d.SetPartial("tags")
+		d.SetPartial("tags_all")
+	}
+	d.Partial(false)
+
+	return resourceAviatrixVpcRead(d, meta)
+}
+
 func resourceAviatrixVpcDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPCAPI)
 
 	vpc := &goaviatrix.Vpc{
 		AccountName: d.Get("account_name").(string),
@@ -1,14 +1,18 @@
 package aviatrix
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/mock"
 )
 
 func TestAccAviatrixVpc_basic(t *testing.T) {
@@ -123,3 +127,135 @@ func testAccCheckVpcDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceAviatrixVpcCRUD(t *testing.T) {
+	raw := map[string]interface{}{
+		"cloud_type":           1,
+		"account_name":         "tfa-test",
+		"region":               "us-west-1",
+		"name":                 "tfg-test",
+		"cidr":                 "10.0.0.0/16",
+		"aviatrix_transit_vpc": true,
+	}
+	found := &goaviatrix.Vpc{
+		CloudType:          1,
+		AccountName:        "tfa-test",
+		Region:             "us-west-1",
+		Name:               "tfg-test",
+		Cidr:               "10.0.0.0/16",
+		AviatrixTransitVpc: "yes",
+		VpcID:              "vpc-0123",
+		Subnets:            []goaviatrix.SubnetInfo{{Cidr: "10.0.0.0/24", Name: "public"}},
+	}
+
+	runCRUDTests(t, resourceAviatrixVpc(), []crudTestCase{
+		{
+			name: "create transit vpc",
+			op:   resourceAviatrixVpcCreate,
+			raw:  raw,
+			client: &mock.Client{
+				CreateVpcFunc: func(vpc *goaviatrix.Vpc) error {
+					if vpc.AviatrixTransitVpc != "yes" {
+						return errors.New("expected a transit vpc")
+					}
+					return nil
+				},
+				GetVpcFunc: func(*goaviatrix.Vpc) (*goaviatrix.Vpc, error) { return found, nil },
+			},
+			wantID: "tfg-test",
+			calls:  []string{"CreateVpc", "GetDefaultTags", "GetVpc", "GetTags", "GetDefaultTags"},
+		},
+		{
+			name: "create with tags",
+			op:   resourceAviatrixVpcCreate,
+			raw: map[string]interface{}{
+				"cloud_type":   1,
+				"account_name": "tfa-test",
+				"region":       "us-west-1",
+				"name":         "tfg-test",
+				"cidr":         "10.0.0.0/16",
+				"tags":         map[string]interface{}{"env": "dev"},
+			},
+			client: &mock.Client{
+				GetDefaultTagsFunc: func() map[string]string { return map[string]string{"owner": "ops", "env": "prod"} },
+				GetVpcFunc:         func(*goaviatrix.Vpc) (*goaviatrix.Vpc, error) { return found, nil },
+				AddTagsWithContextFunc: func(ctx context.Context, tags *goaviatrix.Tags) error {
+					if tags.ResourceType != "vpc" || tags.ResourceName != "vpc-0123" || tags.TagList != "env:dev,owner:ops" {
+						return fmt.Errorf("unexpected tags %#v", tags)
+					}
+					return nil
+				},
+			},
+			wantID: "tfg-test",
+			calls:  []string{"CreateVpc", "GetDefaultTags", "GetVpc", "GetDefaultTags", "AddTagsWithContext", "GetVpc", "GetTags", "GetDefaultTags"},
+		},
+		{
+			name: "create transit and firenet vpc",
+			op:   resourceAviatrixVpcCreate,
+			raw: map[string]interface{}{
+				"cloud_type":           1,
+				"account_name":         "tfa-test",
+				"region":               "us-west-1",
+				"name":                 "tfg-test",
+				"cidr":                 "10.0.0.0/16",
+				"aviatrix_transit_vpc": true,
+				"aviatrix_firenet_vpc": true,
+			},
+			client:  &mock.Client{},
+			wantErr: "at the same time",
+		},
+		{
+			name: "create rejected",
+			op:   resourceAviatrixVpcCreate,
+			raw:  raw,
+			client: &mock.Client{
+				CreateVpcFunc: func(*goaviatrix.Vpc) error { return errors.New("CIDR overlaps") },
+			},
+			wantErr: "failed to create a new Aviatrix Transit VPC: CIDR overlaps",
+			calls:   []string{"CreateVpc"},
+		},
+		{
+			name: "read gone",
+			op:   resourceAviatrixVpcRead,
+			raw:  raw,
+			id:   "tfg-test",
+			client: &mock.Client{
+				GetVpcFunc: func(*goaviatrix.Vpc) (*goaviatrix.Vpc, error) { return nil, goaviatrix.ErrNotFound },
+			},
+			wantID: "",
+			calls:  []string{"GetVpc"},
+		},
+		{
+			name:   "delete",
+			op:     resourceAviatrixVpcDelete,
+			raw:    raw,
+			id:     "tfg-test",
+			client: &mock.Client{},
+			wantID: "tfg-test",
+			calls:  []string{"DeleteVpc"},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceAviatrixVpc().Schema, map[string]interface{}{})
+	d.SetId("tfg-test")
+	client := &mock.Client{
+		GetVpcFunc:         func(*goaviatrix.Vpc) (*goaviatrix.Vpc, error) { return found, nil },
+		GetDefaultTagsFunc: func() map[string]string { return map[string]string{"owner": "ops"} },
+		GetTagsFunc:        func(*goaviatrix.Tags) ([]string, error) { return []string{"env:dev", "owner:ops"}, nil },
+	}
+	if err := resourceAviatrixVpcRead(d, client); err != nil {
+		t.Fatalf("import read: %s", err)
+	}
+	for k, want := range map[string]string{
+		"name":                 "tfg-test",
+		"vpc_id":               "vpc-0123",
+		"aviatrix_transit_vpc": "true",
+		"subnets.0.name":       "public",
+		"tags.%":               "1",
+		"tags.env":             "dev",
+	} {
+		if got := d.State().Attributes[k]; got != want {
+			t.Errorf("import read: got %s = %q, want %q", k, got, want)
+		}
+	}
+}
@@ -25,9 +25,10 @@ func resourceAviatrixProfile() *schema.Resource {
 				Description: "name for the VPN profile.",
 			},
 			"base_rule": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Base policy rule of  the profile to be added. Enter 'allow_all' or 'deny_all'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateStringInSlice([]string{"allow_all", "deny_all"}),
+				Description:  "Base policy rule of  the profile to be added. Enter 'allow_all' or 'deny_all'.",
 			},
 			"users": {
 				Type:        schema.TypeList,
@@ -42,24 +43,28 @@ func resourceAviatrixProfile() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"action": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The opposite of the base rule for correct behaviour. 'allow' or 'deny'.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringInSlice([]string{"allow", "deny"}),
+							Description:  "The opposite of the base rule for correct behaviour. 'allow' or 'deny'.",
 						},
 						"proto": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Protocol to allow or deny.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringInSlice(goaviatrix.PolicyProtocols),
+							Description:  "Protocol to allow or deny.",
 						},
 						"port": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Port to be allowed or denied.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validatePort,
+							Description:  "Port to be allowed or denied.",
 						},
 						"target": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "CIDR to be allowed or denied.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCIDR,
+							Description:  "CIDR to be allowed or denied.",
 						},
 					},
 				},
@@ -69,7 +74,7 @@ func resourceAviatrixProfile() *schema.Resource {
 }
 
 func resourceAviatrixProfileCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	log.Printf("[INFO] Creating Aviatrix Profile: %v %T", d.Get("users"), d.Get("users"))
 
@@ -117,7 +122,7 @@ func resourceAviatrixProfileCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixProfileRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	profileName := d.Get("name").(string)
 	if profileName == "" {
@@ -187,7 +192,7 @@ func resourceAviatrixProfileRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixProfileUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	profile := &goaviatrix.Profile{
 		Name: d.Get("name").(string),
@@ -273,7 +278,7 @@ func resourceAviatrixProfileUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixProfileDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	profile := &goaviatrix.Profile{
 		Name: d.Get("name").(string),
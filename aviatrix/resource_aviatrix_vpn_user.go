@@ -55,7 +55,7 @@ func resourceAviatrixVPNUser() *schema.Resource {
 }
 
 func resourceAviatrixVPNUserCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	vpnUser := &goaviatrix.VPNUser{
 		VpcID:        d.Get("vpc_id").(string),
@@ -83,7 +83,7 @@ func resourceAviatrixVPNUserCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAviatrixVPNUserRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	userName := d.Get("user_name").(string)
 	if userName == "" {
@@ -120,7 +120,7 @@ func resourceAviatrixVPNUserRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAviatrixVPNUserDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	vpnUser := &goaviatrix.VPNUser{
 		UserName: d.Get("user_name").(string),
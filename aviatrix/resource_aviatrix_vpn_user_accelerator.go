@@ -18,6 +18,12 @@ func resourceAviatrixVPNUserAccelerator() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: requireCapabilities(
+			capabilityCheck{capability: goaviatrix.CapabilityVpnUserAccelerator},
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"elb_name": {
@@ -31,7 +37,7 @@ func resourceAviatrixVPNUserAccelerator() *schema.Resource {
 }
 
 func resourceAviatrixVPNUserAcceleratorCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	elb := d.Get("elb_name").(string)
 	// compare if elb is in elb list for current elbs
@@ -53,9 +59,11 @@ func resourceAviatrixVPNUserAcceleratorCreate(d *schema.ResourceData, meta inter
 		log.Printf("[INFO] Creating User Accelerator.")
 		err := client.UpdateVpnUserAccelerator(xlr)
 		if err != nil {
-			// retry in case of not found elb after waiting
+			// a new elb is not found until the controller lists it
 			if strings.Contains(err.Error(), "Endpoint not found") {
-				time.Sleep(180 * time.Second)
+				if err := client.WaitForVpnUserAcceleratorElb(elb, d.Timeout(schema.TimeoutCreate)); err != nil {
+					return fmt.Errorf("failed to create Vpn User Accelerator: %s", err)
+				}
 				err := client.UpdateVpnUserAccelerator(xlr)
 				if err != nil {
 					return fmt.Errorf("failed to create Vpn User Accelerator: %s", err)
@@ -71,7 +79,7 @@ func resourceAviatrixVPNUserAcceleratorCreate(d *schema.ResourceData, meta inter
 }
 
 func resourceAviatrixVPNUserAcceleratorRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	elbName := d.Get("elb_name").(string)
 	if elbName == "" {
@@ -110,7 +118,7 @@ func resourceAviatrixVPNUserAcceleratorRead(d *schema.ResourceData, meta interfa
 }
 
 func resourceAviatrixVPNUserAcceleratorDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*goaviatrix.Client)
+	client := meta.(goaviatrix.VPNAPI)
 
 	elbName := d.Get("elb_name").(string)
 	toDelete := []string{elbName}
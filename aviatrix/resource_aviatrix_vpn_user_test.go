@@ -1,14 +1,17 @@
 package aviatrix
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/mock"
 )
 
 func TestAccAviatrixVPNUser_basic(t *testing.T) {
@@ -138,3 +141,100 @@ func testAccCheckVPNUserDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceAviatrixVPNUserCRUD(t *testing.T) {
+	raw := map[string]interface{}{
+		"vpc_id":     "vpc-0123",
+		"gw_name":    "tfg-elb",
+		"user_name":  "tfu-test",
+		"user_email": "user@example.com",
+	}
+	found := &goaviatrix.VPNUser{
+		VpcID:     "vpc-0123",
+		GwName:    "tfg-elb",
+		UserName:  "tfu-test",
+		UserEmail: "user@example.com",
+	}
+	getFound := func(*goaviatrix.VPNUser) (*goaviatrix.VPNUser, error) { return found, nil }
+
+	checkGwName := func(t *testing.T, d *schema.ResourceData) {
+		if got := d.Get("gw_name").(string); got != "tfg-elb" {
+			t.Errorf("got gw_name %q, want %q", got, "tfg-elb")
+		}
+	}
+
+	runCRUDTests(t, resourceAviatrixVPNUser(), []crudTestCase{
+		{
+			name:   "create",
+			op:     resourceAviatrixVPNUserCreate,
+			raw:    raw,
+			client: &mock.Client{GetVPNUserFunc: getFound},
+			wantID: "tfu-test",
+			check:  checkGwName,
+			calls:  []string{"CreateVPNUser", "GetVPNUser"},
+		},
+		{
+			name: "create rejected",
+			op:   resourceAviatrixVPNUserCreate,
+			raw:  raw,
+			client: &mock.Client{
+				CreateVPNUserFunc: func(*goaviatrix.VPNUser) error { return errors.New("user exists") },
+			},
+			wantErr: "failed to create Aviatrix VPNUser: user exists",
+			calls:   []string{"CreateVPNUser"},
+		},
+		{
+			name:   "import read",
+			op:     resourceAviatrixVPNUserRead,
+			raw:    map[string]interface{}{},
+			id:     "tfu-test",
+			client: &mock.Client{GetVPNUserFunc: getFound},
+			wantID: "tfu-test",
+			check:  checkGwName,
+			calls:  []string{"GetVPNUser"},
+		},
+		{
+			name: "read gone",
+			op:   resourceAviatrixVPNUserRead,
+			raw:  raw,
+			id:   "tfu-test",
+			client: &mock.Client{
+				GetVPNUserFunc: func(*goaviatrix.VPNUser) (*goaviatrix.VPNUser, error) { return nil, goaviatrix.ErrNotFound },
+			},
+			wantID: "",
+			calls:  []string{"GetVPNUser"},
+		},
+		{
+			name: "read failed",
+			op:   resourceAviatrixVPNUserRead,
+			raw:  raw,
+			id:   "tfu-test",
+			client: &mock.Client{
+				GetVPNUserFunc: func(*goaviatrix.VPNUser) (*goaviatrix.VPNUser, error) { return nil, errors.New("timeout") },
+			},
+			wantErr: "couldn't find Aviatrix VPNUser: timeout",
+			calls:   []string{"GetVPNUser"},
+		},
+		{
+			name:   "delete",
+			op:     resourceAviatrixVPNUserDelete,
+			raw:    raw,
+			id:     "tfu-test",
+			client: &mock.Client{},
+			wantID: "tfu-test",
+			check:  checkGwName,
+			calls:  []string{"DeleteVPNUser"},
+		},
+	})
+
+	client := &mock.Client{}
+	d := schema.TestResourceDataRaw(t, resourceAviatrixVPNUser().Schema, raw)
+	d.SetId("tfu-test")
+	if err := resourceAviatrixVPNUserDelete(d, client); err != nil {
+		t.Fatal(err)
+	}
+	deleted := client.Calls()[0].Args[0].(*goaviatrix.VPNUser)
+	if deleted.UserName != "tfu-test" || deleted.VpcID != "vpc-0123" {
+		t.Errorf("deleted %#v, want user tfu-test in vpc-0123", deleted)
+	}
+}
@@ -0,0 +1,55 @@
+package aviatrix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/mock"
+)
+
+// crudTestCase calls one create, read, update or delete function of a
+// resource against a mock client.
+type crudTestCase struct {
+	name    string
+	op      func(*schema.ResourceData, interface{}) error
+	raw     map[string]interface{}
+	id      string
+	client  *mock.Client
+	wantErr string
+	wantID  string
+	calls   []string
+	// check, if set, inspects the resource data after op succeeded
+	check func(t *testing.T, d *schema.ResourceData)
+}
+
+// runCRUDTests runs each test case as a subtest on resource data built
+// from the resource's schema, checking the error, the resulting ID and the
+// mock client calls made, in order.
+func runCRUDTests(t *testing.T, resource *schema.Resource, tests []crudTestCase) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resource.Schema, tt.raw)
+			d.SetId(tt.id)
+
+			err := tt.op(d, tt.client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr == "" && d.Id() != tt.wantID {
+				t.Errorf("got id %q, want %q", d.Id(), tt.wantID)
+			}
+			if got := tt.client.Methods(); !reflect.DeepEqual(got, tt.calls) {
+				t.Errorf("got calls %v, want %v", got, tt.calls)
+			}
+			if tt.check != nil && tt.wantErr == "" {
+				tt.check(t, d)
+			}
+		})
+	}
+}
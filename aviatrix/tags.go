@@ -0,0 +1,183 @@
+package aviatrix
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// tagListDeprecation is the deprecation message of the tag_list attributes
+// replaced by tags.
+const tagListDeprecation = "Use 'tags' instead. 'tag_list' will be removed in a future release."
+
+// expandTags merges a tags map and a deprecated tag_list of "key:value"
+// strings, as read from a resource, into one map. Either may be nil.
+func expandTags(tags interface{}, tagList interface{}) map[string]string {
+	m := map[string]string{}
+	if list, ok := tagList.([]interface{}); ok {
+		for k, v := range goaviatrix.TagListToMap(goaviatrix.ExpandStringList(list)) {
+			m[k] = v
+		}
+	}
+	if tm, ok := tags.(map[string]interface{}); ok {
+		for k, v := range tm {
+			m[k] = v.(string)
+		}
+	}
+	return m
+}
+
+// getTags returns the tags configured for a resource through tags or, for
+// resources still having it, tag_list.
+func getTags(d *schema.ResourceData) map[string]string {
+	var tagList interface{}
+	if v, ok := d.GetOk("tag_list"); ok {
+		tagList = v
+	}
+	return expandTags(d.Get("tags"), tagList)
+}
+
+// withDefaultTags returns tags merged over the provider default tags
+func withDefaultTags(client goaviatrix.TagAPI, tags map[string]string) map[string]string {
+	m := map[string]string{}
+	for k, v := range client.GetDefaultTags() {
+		m[k] = v
+	}
+	for k, v := range tags {
+		m[k] = v
+	}
+	return m
+}
+
+// launchTagList returns the tags to launch a gateway with, as the TagList
+// of connect_container, create_transit_gw and create_spoke_gw.
+func launchTagList(client goaviatrix.TagAPI, d *schema.ResourceData) string {
+	return strings.Join(goaviatrix.TagMapToList(withDefaultTags(client, getTags(d))), ",")
+}
+
+// hasTagsChange reports whether tags, tag_list or tags_all changed
+func hasTagsChange(d *schema.ResourceData) bool {
+	return d.HasChange("tags") || d.HasChange("tag_list") || d.HasChange("tags_all")
+}
+
+// customizeDiffTagsAll plans tags_all of an AWS resource as its tags merged
+// over the provider default tags. The refreshed tags_all holds the tags the
+// controller reports, so a default added, changed or removed in the
+// provider block alone plans an update of every existing resource.
+func customizeDiffTagsAll(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("cloud_type") {
+		return nil
+	}
+	if d.Get("cloud_type").(int) != goaviatrix.AWS {
+		// Other clouds are never tagged and never read tags_all.
+		return d.Clear("tags_all")
+	}
+	if !d.NewValueKnown("tags") || !d.NewValueKnown("tag_list") {
+		return d.SetNewComputed("tags_all")
+	}
+	var tagList interface{}
+	if v, ok := d.GetOk("tag_list"); ok {
+		tagList = v
+	}
+	all := withDefaultTags(meta.(goaviatrix.TagAPI), expandTags(d.Get("tags"), tagList))
+	if reflect.DeepEqual(expandTags(d.Get("tags_all"), nil), all) {
+		return nil
+	}
+	return d.SetNew("tags_all", all)
+}
+
+// createTags adds the configured and default tags to a newly created
+// resource
+func createTags(ctx context.Context, client goaviatrix.TagAPI, d *schema.ResourceData, tags *goaviatrix.Tags) error {
+	m := withDefaultTags(client, getTags(d))
+	if len(m) == 0 {
+		return nil
+	}
+	tags.TagList = strings.Join(goaviatrix.TagMapToList(m), ",")
+	return client.AddTagsWithContext(ctx, tags)
+}
+
+// updateTags brings the tags of a resource from tags_all, the tags it had
+// when last read, to its tags merged over the default tags. Only the keys
+// removed are deleted and only the keys added or changed are added, so
+// moving a tag from tag_list to tags calls the controller for nothing.
+func updateTags(ctx context.Context, client goaviatrix.TagAPI, d *schema.ResourceData, tags *goaviatrix.Tags) error {
+	oldAll, _ := d.GetChange("tags_all")
+	o := expandTags(oldAll, nil)
+	n := withDefaultTags(client, getTags(d))
+
+	var deleted, added []string
+	for k, v := range o {
+		if _, ok := n[k]; !ok {
+			deleted = append(deleted, k+":"+v)
+		}
+	}
+	for k, v := range n {
+		if old, ok := o[k]; !ok || old != v {
+			added = append(added, k+":"+v)
+		}
+	}
+	sort.Strings(deleted)
+	sort.Strings(added)
+
+	if len(deleted) != 0 {
+		tags.TagList = strings.Join(deleted, ",")
+		if err := client.DeleteTagsWithContext(ctx, tags); err != nil {
+			return fmt.Errorf("failed to delete tags: %s", err)
+		}
+	}
+	if len(added) != 0 {
+		tags.TagList = strings.Join(added, ",")
+		if err := client.AddTagsWithContext(ctx, tags); err != nil {
+			return fmt.Errorf("failed to add tags: %s", err)
+		}
+	}
+	return nil
+}
+
+// readTags sets tags_all to the tags the controller reports for a resource
+// and tags to the same tags less the default tags the resource does not
+// set, so those never show up as a diff there; tags_all tracks them. A
+// resource still configured with tag_list gets tag_list set instead of
+// tags, keeping its order when only the order differs.
+func readTags(client goaviatrix.TagAPI, d *schema.ResourceData, tags *goaviatrix.Tags) error {
+	remote, err := client.GetTags(tags)
+	if err != nil {
+		return err
+	}
+	all := goaviatrix.TagListToMap(remote)
+	if err := d.Set("tags_all", all); err != nil {
+		return err
+	}
+
+	configured := getTags(d)
+	defaults := client.GetDefaultTags()
+	var tagList []string
+	for k, v := range all {
+		if _, ok := configured[k]; !ok {
+			if _, ok := defaults[k]; ok {
+				continue
+			}
+		}
+		tagList = append(tagList, k+":"+v)
+	}
+	sort.Strings(tagList)
+
+	v, ok := d.GetOk("tag_list")
+	if !ok {
+		return d.Set("tags", goaviatrix.TagListToMap(tagList))
+	}
+	tagListStr := goaviatrix.ExpandStringList(v.([]interface{}))
+	if len(goaviatrix.Difference(tagListStr, tagList)) != 0 || len(goaviatrix.Difference(tagList, tagListStr)) != 0 {
+		tagListStr = tagList
+	}
+	if err := d.Set("tag_list", tagListStr); err != nil {
+		return err
+	}
+	return d.Set("tags", nil)
+}
@@ -0,0 +1,134 @@
+package aviatrix
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix/mock"
+)
+
+// TestTagsAll refreshes, plans and applies a VPC tagged env:dev and launched
+// with default tags owner:ops and cost-center:1.
+func TestTagsAll(t *testing.T) {
+	found := &goaviatrix.Vpc{
+		CloudType:   1,
+		AccountName: "tfa-test",
+		Region:      "us-west-1",
+		Name:        "tfg-test",
+		Cidr:        "10.0.0.0/16",
+		VpcID:       "vpc-0123",
+	}
+	launched := []string{"cost-center:1", "env:dev", "owner:ops"}
+	defaults := map[string]string{"owner": "ops", "cost-center": "1"}
+	cases := []struct {
+		name     string
+		tags     map[string]interface{}
+		defaults map[string]string
+		// remote are the tags the controller reports
+		remote []string
+		// wantDiff is whether the plan after the refresh has changes
+		wantDiff               bool
+		wantDeleted, wantAdded string
+	}{
+		{"unchanged", map[string]interface{}{"env": "dev"}, defaults, launched, false, "", ""},
+		{"changed tag", map[string]interface{}{"env": "prod"}, defaults, launched, true, "", "env:prod"},
+		{"tag added outside terraform", map[string]interface{}{"env": "dev"}, defaults,
+			append([]string{"debug:yes"}, launched...), true, "debug:yes", ""},
+		{"resource tag over a default", map[string]interface{}{"env": "dev", "owner": "net"}, defaults, launched,
+			true, "", "owner:net"},
+		{"changed default", map[string]interface{}{"env": "dev"}, map[string]string{"owner": "ops", "cost-center": "2"},
+			launched, true, "", "cost-center:2"},
+		{"removed default", map[string]interface{}{"env": "dev"}, map[string]string{"owner": "ops"}, launched,
+			true, "cost-center:1", ""},
+		{"added default", map[string]interface{}{"env": "dev"},
+			map[string]string{"owner": "ops", "cost-center": "1", "team": "net"}, launched, true, "", "team:net"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cloud_type":   1,
+				"account_name": "tfa-test",
+				"region":       "us-west-1",
+				"name":         "tfg-test",
+				"cidr":         "10.0.0.0/16",
+				"tags":         tc.tags,
+			}
+			var deleted, added string
+			client := &mock.Client{
+				GetVpcFunc:         func(*goaviatrix.Vpc) (*goaviatrix.Vpc, error) { return found, nil },
+				GetDefaultTagsFunc: func() map[string]string { return tc.defaults },
+				GetTagsFunc:        func(*goaviatrix.Tags) ([]string, error) { return tc.remote, nil },
+				DeleteTagsWithContextFunc: func(ctx context.Context, tags *goaviatrix.Tags) error {
+					deleted = tags.TagList
+					return nil
+				},
+				AddTagsWithContextFunc: func(ctx context.Context, tags *goaviatrix.Tags) error {
+					added = tags.TagList
+					return nil
+				},
+			}
+			r := resourceAviatrixVpc()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, raw)
+			d.SetId("tfg-test")
+			if err := resourceAviatrixVpcRead(d, client); err != nil {
+				t.Fatalf("refresh: %s", err)
+			}
+			state := d.State()
+			if got, want := state.Attributes["tags_all.%"], fmt.Sprint(len(tc.remote)); got != want {
+				t.Errorf("refreshed %s tags_all, want %s", got, want)
+			}
+
+			c, err := config.NewRawConfig(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			diff, err := r.Diff(state, terraform.NewResourceConfig(c), client)
+			if err != nil {
+				t.Fatalf("diff: %s", err)
+			}
+			if gotDiff := diff != nil && !diff.Empty(); gotDiff != tc.wantDiff {
+				t.Fatalf("got diff %v, want a diff: %v", diff, tc.wantDiff)
+			}
+			if !tc.wantDiff {
+				return
+			}
+			if _, err := r.Apply(state, diff, client); err != nil {
+				t.Fatalf("apply: %s", err)
+			}
+			if deleted != tc.wantDeleted || added != tc.wantAdded {
+				t.Errorf("deleted %q and added %q, want %q and %q", deleted, added, tc.wantDeleted, tc.wantAdded)
+			}
+		})
+	}
+}
+
+// TestTagsAllNotAWS checks that tags_all is not planned for other clouds,
+// which are never tagged.
+func TestTagsAllNotAWS(t *testing.T) {
+	c, err := config.NewRawConfig(map[string]interface{}{
+		"cloud_type":   8,
+		"account_name": "tfa-test",
+		"region":       "West US",
+		"name":         "tfg-test",
+		"cidr":         "10.0.0.0/16",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &mock.Client{
+		GetDefaultTagsFunc: func() map[string]string { return map[string]string{"owner": "ops"} },
+	}
+	diff, err := resourceAviatrixVpc().Diff(nil, terraform.NewResourceConfig(c), client)
+	if err != nil {
+		t.Fatalf("diff: %s", err)
+	}
+	if a, ok := diff.Attributes["tags_all.owner"]; ok {
+		t.Errorf("planned tags_all.owner %q for an ARM VPC", a.New)
+	}
+}
@@ -0,0 +1,168 @@
+package aviatrix
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// maxASN is the largest 4-byte AS number
+const maxASN = 4294967295
+
+// validateCIDR checks that a string is a CIDR such as "10.0.0.0/16". Like
+// validateCIDRList and validateIPAddress it accepts "", the default of the
+// optional attributes it is used for.
+func validateCIDR(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	if _, _, err := net.ParseCIDR(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %q to be a valid CIDR, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// validateCIDRList checks that a string is a comma separated list of CIDRs
+func validateCIDRList(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	var errs []error
+	for _, cidr := range strings.Split(v, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, fmt.Errorf("expected %q to be a comma separated list of CIDRs, got %q in %q", k, cidr, v))
+		}
+	}
+	return nil, errs
+}
+
+// validateIPAddress checks that a string is an IPv4 or IPv6 address
+func validateIPAddress(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	if net.ParseIP(v) == nil {
+		return nil, []error{fmt.Errorf("expected %q to be a valid IP address, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// validateCloudType returns a function checking that an int is one of the
+// given cloud types.
+func validateCloudType(cloudTypes ...int) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(int)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %q to be int", k)}
+		}
+		names := make([]string, 0, len(cloudTypes))
+		for _, cloudType := range cloudTypes {
+			if v == cloudType {
+				return nil, nil
+			}
+			names = append(names, fmt.Sprintf("%d (%s)", cloudType, goaviatrix.CloudTypeName(cloudType)))
+		}
+		return nil, []error{fmt.Errorf("expected %q to be one of %s, got %d", k, strings.Join(names, ", "), v)}
+	}
+}
+
+// validateStringInSlice returns a function checking that a string is one
+// of valid.
+func validateStringInSlice(valid []string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+		}
+		if !goaviatrix.Contains(valid, v) {
+			return nil, []error{fmt.Errorf("expected %q to be one of %q, got %q", k, valid, v)}
+		}
+		return nil, nil
+	}
+}
+
+// validatePort checks that a string is a port, such as "443", or a port
+// range, such as "1024:65535".
+func validatePort(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	parts := strings.Split(v, ":")
+	if len(parts) > 2 {
+		return nil, []error{fmt.Errorf("expected %q to be a port or a port range, got %q", k, v)}
+	}
+	var ports []int
+	for _, part := range parts {
+		port, err := strconv.Atoi(part)
+		if err != nil || port < 0 || port > 65535 {
+			return nil, []error{fmt.Errorf("expected %q to be a port or a port range between 0 and 65535, got %q", k, v)}
+		}
+		ports = append(ports, port)
+	}
+	if len(ports) == 2 && ports[0] > ports[1] {
+		return nil, []error{fmt.Errorf("expected %q to be a port range with the lower port first, got %q", k, v)}
+	}
+	return nil, nil
+}
+
+// validateASN checks that a string is an AS number
+func validateASN(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	asn, err := strconv.ParseUint(v, 10, 64)
+	if err != nil || asn < 1 || asn > maxASN {
+		return nil, []error{fmt.Errorf("expected %q to be an AS number between 1 and %d, got %q", k, uint64(maxASN), v)}
+	}
+	return nil, nil
+}
+
+// validateFloatBetween returns a function checking that a float is within
+// [min, max].
+func validateFloatBetween(min, max float64) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		v, ok := i.(float64)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %q to be float", k)}
+		}
+		if v < min || v > max {
+			return nil, []error{fmt.Errorf("expected %q to be between %v and %v, got %v", k, min, max, v)}
+		}
+		return nil, nil
+	}
+}
+
+// validateAny returns a function accepting a value that any of validators
+// accepts. Otherwise the errors of all of them are returned.
+func validateAny(validators ...schema.SchemaValidateFunc) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		var allErrs []error
+		for _, validator := range validators {
+			ws, errs := validator(i, k)
+			if len(errs) == 0 {
+				return ws, nil
+			}
+			allErrs = append(allErrs, errs...)
+		}
+		return nil, allErrs
+	}
+}
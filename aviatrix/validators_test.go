@@ -0,0 +1,156 @@
+package aviatrix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestValidators(t *testing.T) {
+	cases := []struct {
+		name     string
+		validate schema.SchemaValidateFunc
+		valid    []interface{}
+		invalid  []interface{}
+	}{
+		{
+			name:     "validateCIDR",
+			validate: validateCIDR,
+			valid:    []interface{}{"", "10.0.0.0/16", "2001:db8::/32"},
+			invalid:  []interface{}{"10.0.0.0", "10.0.0.0/33", "vpc", 16},
+		},
+		{
+			name:     "validateCIDRList",
+			validate: validateCIDRList,
+			valid:    []interface{}{"", "10.0.0.0/16", "10.0.0.0/16, 10.1.0.0/16"},
+			invalid:  []interface{}{"10.0.0.0/16,", "10.0.0.0/16,10.1.0.0", 16},
+		},
+		{
+			name:     "validateIPAddress",
+			validate: validateIPAddress,
+			valid:    []interface{}{"", "10.0.0.1", "2001:db8::1"},
+			invalid:  []interface{}{"10.0.0.256", "10.0.0.0/16", "host", 1},
+		},
+		{
+			name:     "validateCloudType",
+			validate: validateCloudType(1, 8),
+			valid:    []interface{}{1, 8},
+			invalid:  []interface{}{0, 4, "1"},
+		},
+		{
+			name:     "validateStringInSlice",
+			validate: validateStringInSlice([]string{"", "auto"}),
+			valid:    []interface{}{"", "auto"},
+			invalid:  []interface{}{"Auto", "token", 1},
+		},
+		{
+			name:     "validatePort",
+			validate: validatePort,
+			valid:    []interface{}{"0", "443", "1024:65535"},
+			invalid:  []interface{}{"", "65536", "80:22", "1:2:3", "http", 443},
+		},
+		{
+			name:     "validateASN",
+			validate: validateASN,
+			valid:    []interface{}{"1", "65000", "4294967295"},
+			invalid:  []interface{}{"", "0", "4294967296", "as65000", 65000},
+		},
+		{
+			name:     "validateFloatBetween",
+			validate: validateFloatBetween(0, 1),
+			valid:    []interface{}{0.0, 0.5, 1.0},
+			invalid:  []interface{}{-0.1, 1.1, "0.5"},
+		},
+		{
+			name:     "validateAny",
+			validate: validateAny(validateCIDR, validateIPAddress),
+			valid:    []interface{}{"", "10.0.0.1", "10.0.0.0/16"},
+			invalid:  []interface{}{"host"},
+		},
+	}
+	for _, tc := range cases {
+		for _, v := range tc.valid {
+			if _, errs := tc.validate(v, "key"); len(errs) != 0 {
+				t.Errorf("%s(%#v) = %v, want no errors", tc.name, v, errs)
+			}
+		}
+		for _, v := range tc.invalid {
+			if _, errs := tc.validate(v, "key"); len(errs) == 0 {
+				t.Errorf("%s(%#v) succeeded, want an error", tc.name, v)
+			}
+		}
+	}
+}
+
+// TestValidateEmptyOptionalAttributes validates configurations setting
+// optional CIDR and IP attributes to "" explicitly.
+func TestValidateEmptyOptionalAttributes(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource *schema.Resource
+		raw      map[string]interface{}
+	}{
+		{
+			name:     "spoke gateway",
+			resource: resourceAviatrixSpokeGateway(),
+			raw: map[string]interface{}{
+				"cloud_type":   1,
+				"account_name": "tfa-test",
+				"gw_name":      "tfg-spoke",
+				"vpc_id":       "vpc-0123",
+				"vpc_reg":      "us-west-1",
+				"gw_size":      "t2.micro",
+				"subnet":       "10.0.0.0/24",
+				"ha_subnet":    "",
+			},
+		},
+		{
+			name:     "gateway",
+			resource: resourceAviatrixGateway(),
+			raw: map[string]interface{}{
+				"cloud_type":        1,
+				"account_name":      "tfa-test",
+				"gw_name":           "tfg-gw",
+				"vpc_id":            "vpc-0123",
+				"vpc_reg":           "us-west-1",
+				"gw_size":           "t2.micro",
+				"subnet":            "10.0.0.0/24",
+				"peering_ha_subnet": "",
+				"vpn_cidr":          "",
+				"eip":               "",
+				"peering_ha_eip":    "",
+				"additional_cidrs":  "",
+			},
+		},
+		{
+			name:     "site2cloud",
+			resource: resourceAviatrixSite2Cloud(),
+			raw: map[string]interface{}{
+				"vpc_id":                     "vpc-0123",
+				"connection_name":            "tfs-test",
+				"remote_gateway_type":        "generic",
+				"connection_type":            "unmapped",
+				"tunnel_type":                "udp",
+				"primary_cloud_gateway_name": "tfg-gw",
+				"remote_gateway_ip":          "8.8.8.8",
+				"remote_subnet_cidr":         "10.23.0.0/24",
+				"local_subnet_cidr":          "",
+				"backup_remote_gateway_ip":   "",
+				"backup_remote_subnet_cidr":  "",
+				"remote_subnet_virtual":      "",
+				"local_subnet_virtual":       "",
+			},
+		},
+	}
+	for _, tc := range cases {
+		c, err := config.NewRawConfig(tc.raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ws, errs := tc.resource.Validate(terraform.NewResourceConfig(c)); len(errs) != 0 {
+			t.Errorf("%s: got warnings %v and errors %v, want none", tc.name, ws, errs)
+		}
+	}
+}
@@ -1,11 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
+	"context"
 	"log"
-	"net/url"
 )
 
 type Account struct {
@@ -54,43 +51,21 @@ type AccountListResp struct {
 }
 
 func (c *Client) CreateAccount(account *Account) error {
-	account.CID = c.CID
-	account.Action = "setup_account_profile"
-	resp, err := c.Post(c.baseURL, account)
-	if err != nil {
-		return errors.New("HTTP Post setup_account_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode setup_account_profile failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API setup_account_profile Post failed: " + data.Reason)
-	}
-	return nil
+	return c.CreateAccountWithContext(context.Background(), account)
+}
+
+func (c *Client) CreateAccountWithContext(ctx context.Context, account *Account) error {
+	return c.CallWithContext(ctx, "POST", "setup_account_profile", account, nil)
 }
 
 func (c *Client) GetAccount(account *Account) (*Account, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for list_accounts " + err.Error())
-	}
-	listAccounts := url.Values{}
-	listAccounts.Add("CID", c.CID)
-	listAccounts.Add("action", "list_accounts")
-	Url.RawQuery = listAccounts.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetAccountWithContext(context.Background(), account)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_accounts failed: " + err.Error())
-	}
+func (c *Client) GetAccountWithContext(ctx context.Context, account *Account) (*Account, error) {
 	var data AccountListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode  failed: " + err.Error())
-	}
-
-	if !data.Return {
-		return nil, errors.New("Rest API list_accounts Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_accounts", nil, &data); err != nil {
+		return nil, err
 	}
 	accList := data.Results.AccountList
 	for i := range accList {
@@ -104,52 +79,28 @@ func (c *Client) GetAccount(account *Account) (*Account, error) {
 }
 
 func (c *Client) UpdateAccount(account *Account) error {
-	account.CID = c.CID
-	account.Action = "edit_account_profile"
-	resp, err := c.Post(c.baseURL, account)
-	if err != nil {
-		return errors.New("HTTP Post edit_account_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_account_profile failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_account_profile Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UpdateAccountWithContext(context.Background(), account)
+}
+
+func (c *Client) UpdateAccountWithContext(ctx context.Context, account *Account) error {
+	return c.CallWithContext(ctx, "POST", "edit_account_profile", account, nil)
 }
 
 func (c *Client) DeleteAccount(account *Account) error {
-	path := c.baseURL + fmt.Sprintf("?action=delete_account_profile&CID=%s&account_name=%s",
-		c.CID, account.AccountName)
-	resp, err := c.Delete(path, nil)
-	if err != nil {
-		return errors.New("HTTP delete_account_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_account_profile failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_account_profile Post failed: " + data.Reason)
+	return c.DeleteAccountWithContext(context.Background(), account)
+}
+
+func (c *Client) DeleteAccountWithContext(ctx context.Context, account *Account) error {
+	form := map[string]string{
+		"account_name": account.AccountName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "delete_account_profile", form, nil)
 }
 
 func (c *Client) UploadGcloudProjectCredentialsFile(account *Account) error {
-	account.CID = c.CID
-	account.Action = "upload_file"
-	resp, err := c.Post(c.baseURL, account)
-	if err != nil {
-		return errors.New("HTTP Post upload_file failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode upload_file failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API upload_file Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UploadGcloudProjectCredentialsFileWithContext(context.Background(), account)
+}
+
+func (c *Client) UploadGcloudProjectCredentialsFileWithContext(ctx context.Context, account *Account) error {
+	return c.CallWithContext(ctx, "POST", "upload_file", account, nil)
 }
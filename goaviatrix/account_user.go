@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 )
 
 type AccountUser struct {
@@ -34,43 +32,21 @@ type AccountUserListResp struct {
 }
 
 func (c *Client) CreateAccountUser(user *AccountUser) error {
-	user.CID = c.CID
-	user.Action = "add_account_user"
-	resp, err := c.Post(c.baseURL, user)
-	if err != nil {
-		return errors.New("HTTP Post add_account_user failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_account_user failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_account_user Post failed: " + data.Reason)
-	}
-	return nil
+	return c.CreateAccountUserWithContext(context.Background(), user)
+}
+
+func (c *Client) CreateAccountUserWithContext(ctx context.Context, user *AccountUser) error {
+	return c.CallWithContext(ctx, "POST", "add_account_user", user, nil)
 }
 
 func (c *Client) GetAccountUser(user *AccountUser) (*AccountUser, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_account_users ") + err.Error())
-	}
-	listAccountUsers := url.Values{}
-	listAccountUsers.Add("CID", c.CID)
-	listAccountUsers.Add("action", "list_account_users")
-	Url.RawQuery = listAccountUsers.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetAccountUserWithContext(context.Background(), user)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_account_users failed: " + err.Error())
-	}
+func (c *Client) GetAccountUserWithContext(ctx context.Context, user *AccountUser) (*AccountUser, error) {
 	var data AccountUserListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_account_users failed: " + err.Error())
-	}
-
-	if !data.Return {
-		return nil, errors.New("Rest API enable_transit_ha Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_account_users", nil, &data); err != nil {
+		return nil, err
 	}
 	users := data.AccountUserList
 	for i := range users {
@@ -85,43 +61,20 @@ func (c *Client) GetAccountUser(user *AccountUser) (*AccountUser, error) {
 }
 
 func (c *Client) UpdateAccountUserObject(user *AccountUserEdit) error {
-	user.CID = c.CID
-	user.Action = "edit_account_user"
-	resp, err := c.Post(c.baseURL, user)
-	if err != nil {
-		return errors.New("HTTP Post edit_account_user failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_account_user failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_account_user Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UpdateAccountUserObjectWithContext(context.Background(), user)
+}
+
+func (c *Client) UpdateAccountUserObjectWithContext(ctx context.Context, user *AccountUserEdit) error {
+	return c.CallWithContext(ctx, "POST", "edit_account_user", user, nil)
 }
 
 func (c *Client) DeleteAccountUser(user *AccountUser) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_account_user ") + err.Error())
-	}
-	deleteAccountUsers := url.Values{}
-	deleteAccountUsers.Add("CID", c.CID)
-	deleteAccountUsers.Add("action", "delete_account_user")
-	deleteAccountUsers.Add("username", user.UserName)
-	Url.RawQuery = deleteAccountUsers.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteAccountUserWithContext(context.Background(), user)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_account_user failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_account_user failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_account_user Get failed: " + data.Reason)
+func (c *Client) DeleteAccountUserWithContext(ctx context.Context, user *AccountUser) error {
+	form := map[string]string{
+		"username": user.UserName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "delete_account_user", form, nil)
 }
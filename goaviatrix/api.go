@@ -0,0 +1,281 @@
+package goaviatrix
+
+import (
+	"context"
+	"time"
+)
+
+// The interfaces below group the Client methods used by the Terraform
+// provider by domain, so that resources can be tested against a mock (see
+// the mock package) instead of a live controller. *Client implements all of
+// them. The WithContext variants are those resources call under the
+// deadline of their create, update or delete timeout.
+
+// AccountAPI manages cloud accounts and controller user accounts
+type AccountAPI interface {
+	CreateAccount(account *Account) error
+	GetAccount(account *Account) (*Account, error)
+	UpdateAccount(account *Account) error
+	DeleteAccount(account *Account) error
+	UploadGcloudProjectCredentialsFile(account *Account) error
+
+	CreateAccountUser(user *AccountUser) error
+	GetAccountUser(user *AccountUser) (*AccountUser, error)
+	UpdateAccountUserObject(user *AccountUserEdit) error
+	DeleteAccountUser(user *AccountUser) error
+}
+
+// GatewayAPI manages gateways and their split tunnel settings
+type GatewayAPI interface {
+	CreateGateway(gateway *Gateway) error
+	CreateGatewayWithContext(ctx context.Context, gateway *Gateway) error
+	GetGateway(gateway *Gateway) (*Gateway, error)
+	GetGatewayWithContext(ctx context.Context, gateway *Gateway) (*Gateway, error)
+	GetGatewayDetail(gateway *Gateway) (*GatewayDetail, error)
+	UpdateGateway(gateway *Gateway) error
+	UpdateGatewayWithContext(ctx context.Context, gateway *Gateway) error
+	DeleteGateway(gateway *Gateway) error
+	DeleteGatewayWithContext(ctx context.Context, gateway *Gateway) error
+	EnableSingleAZGateway(gateway *Gateway) error
+	EnableSingleAZGatewayWithContext(ctx context.Context, gateway *Gateway) error
+	DisableSingleAZGateway(gateway *Gateway) error
+	DisableSingleAZGatewayWithContext(ctx context.Context, gateway *Gateway) error
+	EnablePeeringHaGateway(gateway *Gateway) error
+	EnablePeeringHaGatewayWithContext(ctx context.Context, gateway *Gateway) error
+	EnableSNat(gateway *Gateway) error
+	EnableSNatWithContext(ctx context.Context, gateway *Gateway) error
+	DisableSNat(gateway *Gateway) error
+	DisableSNatWithContext(ctx context.Context, gateway *Gateway) error
+	UpdateVpnCidr(gateway *Gateway) error
+	UpdateVpnCidrWithContext(ctx context.Context, gateway *Gateway) error
+	UpdateMaxVpnConn(gateway *Gateway) error
+	UpdateMaxVpnConnWithContext(ctx context.Context, gateway *Gateway) error
+	SetVpnGatewayAuthentication(gateway *VpnGatewayAuth) error
+	SetVpnGatewayAuthenticationWithContext(ctx context.Context, gateway *VpnGatewayAuth) error
+	WaitForGatewayReady(gwName string, timeout time.Duration) error
+	WaitForGatewayReadyWithContext(ctx context.Context, gwName string, timeout time.Duration) error
+	WaitForGatewayDeleted(gwName string, timeout time.Duration) error
+	WaitForGatewayDeletedWithContext(ctx context.Context, gwName string, timeout time.Duration) error
+
+	GetSplitTunnel(splitTunnel *SplitTunnel) (*SplitTunnelUnit, error)
+	ModifySplitTunnel(splitTunnel *SplitTunnel) error
+	ModifySplitTunnelWithContext(ctx context.Context, splitTunnel *SplitTunnel) error
+}
+
+// TagAPI manages the cloud tags of gateways and VPCs
+type TagAPI interface {
+	GetDefaultTags() map[string]string
+	GetTags(tags *Tags) ([]string, error)
+	AddTags(tags *Tags) error
+	AddTagsWithContext(ctx context.Context, tags *Tags) error
+	DeleteTags(tags *Tags) error
+	DeleteTagsWithContext(ctx context.Context, tags *Tags) error
+}
+
+// TransitAPI manages transit gateways, their peerings and VGW connections
+type TransitAPI interface {
+	LaunchTransitVpc(gateway *TransitVpc) error
+	LaunchTransitVpcWithContext(ctx context.Context, gateway *TransitVpc) error
+	EnableHaTransitVpc(gateway *TransitVpc) error
+	EnableHaTransitVpcWithContext(ctx context.Context, gateway *TransitVpc) error
+	AttachTransitGWForHybrid(gateway *TransitVpc) error
+	AttachTransitGWForHybridWithContext(ctx context.Context, gateway *TransitVpc) error
+	DetachTransitGWForHybrid(gateway *TransitVpc) error
+	DetachTransitGWForHybridWithContext(ctx context.Context, gateway *TransitVpc) error
+	EnableConnectedTransit(gateway *TransitVpc) error
+	EnableConnectedTransitWithContext(ctx context.Context, gateway *TransitVpc) error
+	DisableConnectedTransit(gateway *TransitVpc) error
+	DisableConnectedTransitWithContext(ctx context.Context, gateway *TransitVpc) error
+	EnableGatewayFireNetInterfaces(gateway *TransitVpc) error
+	EnableGatewayFireNetInterfacesWithContext(ctx context.Context, gateway *TransitVpc) error
+	DisableGatewayFireNetInterfaces(gateway *TransitVpc) error
+	DisableGatewayFireNetInterfacesWithContext(ctx context.Context, gateway *TransitVpc) error
+
+	CreateTransitGatewayPeering(transitGatewayPeering *TransitGatewayPeering) error
+	GetTransitGatewayPeering(transitGatewayPeering *TransitGatewayPeering) error
+	DeleteTransitGatewayPeering(transitGatewayPeering *TransitGatewayPeering) error
+
+	CreateVGWConn(vgwConn *VGWConn) error
+	GetVGWConn(vgwConn *VGWConn) (*VGWConn, error)
+	GetVGWConnDetail(vgwConn *VGWConn) (*VGWConn, error)
+	DeleteVGWConn(vgwConn *VGWConn) error
+	EnableAdvertiseTransitCidr(vgwConn *VGWConn) error
+	DisableAdvertiseTransitCidr(vgwConn *VGWConn) error
+	SetBgpManualSpokeAdvertisedNetworks(vgwConn *VGWConn) error
+	DisableBgpManualSpokeAdvertisedNetworks(vgwConn *VGWConn) error
+}
+
+// SpokeAPI manages spoke gateways and their transit attachment
+type SpokeAPI interface {
+	LaunchSpokeVpc(spoke *SpokeVpc) error
+	LaunchSpokeVpcWithContext(ctx context.Context, spoke *SpokeVpc) error
+	EnableHaSpokeVpc(spoke *SpokeVpc) error
+	EnableHaSpokeVpcWithContext(ctx context.Context, spoke *SpokeVpc) error
+	SpokeJoinTransit(spoke *SpokeVpc) error
+	SpokeJoinTransitWithContext(ctx context.Context, spoke *SpokeVpc) error
+	SpokeLeaveTransit(spoke *SpokeVpc) error
+	SpokeLeaveTransitWithContext(ctx context.Context, spoke *SpokeVpc) error
+}
+
+// FQDNAPI manages FQDN filter tags
+type FQDNAPI interface {
+	CreateFQDN(fqdn *FQDN) error
+	GetFQDNTag(fqdn *FQDN) (*FQDN, error)
+	DeleteFQDN(fqdn *FQDN) error
+	UpdateFQDNStatus(fqdn *FQDN) error
+	UpdateFQDNMode(fqdn *FQDN) error
+	UpdateDomains(fqdn *FQDN) error
+	ListDomains(fqdn *FQDN) (*FQDN, error)
+	ListGws(fqdn *FQDN) ([]string, error)
+	AttachTagToGw(fqdn *FQDN, gateway *Gateway) error
+	DetachGws(fqdn *FQDN, gwList []string) error
+	GetGwFilterTagList(fqdn *FQDN) (*FQDN, error)
+	UpdateSourceIPFilters(fqdn *FQDN, gateway *Gateway, sourceIPs []string) error
+}
+
+// FirewallAPI manages gateway firewall policies and firewall tags
+type FirewallAPI interface {
+	SetBasePolicy(firewall *Firewall) error
+	GetPolicy(firewall *Firewall) (*Firewall, error)
+	UpdatePolicy(firewall *Firewall) error
+	ValidatePolicy(policy *Policy) error
+
+	CreateFirewallTag(firewallTag *FirewallTag) error
+	GetFirewallTag(firewallTag *FirewallTag) (*FirewallTag, error)
+	UpdateFirewallTag(firewallTag *FirewallTag) error
+	DeleteFirewallTag(firewallTag *FirewallTag) error
+}
+
+// Site2CloudAPI manages site2cloud connections
+type Site2CloudAPI interface {
+	CreateSite2Cloud(site2cloud *Site2Cloud) error
+	GetSite2Cloud(site2cloud *Site2Cloud) (*Site2Cloud, error)
+	GetSite2CloudConnDetail(site2cloud *Site2Cloud) (*Site2Cloud, error)
+	UpdateSite2Cloud(site2cloud *EditSite2Cloud) error
+	DeleteSite2Cloud(site2cloud *Site2Cloud) error
+	Site2CloudAlgorithmCheck(site2cloud *Site2Cloud) error
+	EnableDeadPeerDetection(site2cloud *Site2Cloud) error
+	DisableDeadPeerDetection(site2cloud *Site2Cloud) error
+	WaitForSite2CloudTunnel(site2cloud *Site2Cloud, statuses []string, timeout time.Duration) error
+}
+
+// TGWAPI manages AWS transit gateways, their security domains and
+// attachments
+type TGWAPI interface {
+	CreateAWSTgw(awsTgw *AWSTgw) error
+	GetAWSTgw(awsTgw *AWSTgw) (*AWSTgw, error)
+	ListTgwDetails(awsTgw *AWSTgw) (*AWSTgw, error)
+	DeleteAWSTgw(awsTgw *AWSTgw) error
+	WaitForAWSTgwAvailable(awsTgw *AWSTgw, timeout time.Duration) error
+	WaitForAWSTgwDeleted(awsTgw *AWSTgw, timeout time.Duration) error
+	ValidateAWSTgwDomains(domainsAll []string, domainConnAll [][]string, attachedVPCAll [][]string) ([]string, [][]string, [][]string, error)
+	AttachAviatrixTransitGWToAWSTgw(awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error
+	DetachAviatrixTransitGWFromAWSTgw(awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error
+	AttachVpcToAWSTgw(awsTgw *AWSTgw, vpcSolo VPCSolo, SecurityDomainName string) error
+	DetachVpcFromAWSTgw(awsTgw *AWSTgw, vpcID string) error
+	IsVpcAttachedToTgw(awsTgw *AWSTgw, vpcSolo *VPCSolo) (bool, error)
+
+	CreateSecurityDomain(securityDomain *SecurityDomain) error
+	DeleteSecurityDomain(securityDomain *SecurityDomain) error
+	CreateDomainConnection(awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error
+	DeleteDomainConnection(awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error
+
+	CreateAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) error
+	GetAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error)
+	DeleteAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) error
+
+	CreateAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (string, error)
+	GetAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (*AwsTgwVpnConn, error)
+	DeleteAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) error
+	WaitForAwsTgwVpnConnDeleted(awsTgwVpnConn *AwsTgwVpnConn, timeout time.Duration) error
+}
+
+// VPNAPI manages VPN users, VPN profiles and the VPN user accelerator
+type VPNAPI interface {
+	CreateVPNUser(vpnUser *VPNUser) error
+	GetVPNUser(vpnUser *VPNUser) (*VPNUser, error)
+	DeleteVPNUser(vpnUser *VPNUser) error
+
+	CreateProfile(profile *Profile) error
+	GetProfile(profile *Profile) (*Profile, error)
+	GetProfileBasePolicy(profile *Profile) (*Profile, error)
+	UpdateProfilePolicy(profile *Profile) error
+	DeleteProfile(profile *Profile) error
+	AttachUsers(profile *Profile) error
+	DetachUsers(profile *Profile) error
+	ValidateProfileRule(profileRule *ProfileRule) error
+
+	GetVpnUserAccelerator() ([]string, error)
+	UpdateVpnUserAccelerator(xlr *VpnUserXlr) error
+	WaitForVpnUserAcceleratorElb(elb string, timeout time.Duration) error
+}
+
+// ControllerAPI manages controller wide settings and upgrades
+type ControllerAPI interface {
+	GetCID() string
+	GetControllerIP() string
+	ControllerVersionValidation(supported VersionRange) error
+	RequireCapability(capability Capability) error
+	GetCurrentVersion() (string, *AviatrixVersion, error)
+	GetLatestVersion() (string, error)
+	Upgrade(version *Version) error
+	WaitForVersion(version string, timeout time.Duration) error
+
+	GetHttpAccessEnabled() (string, error)
+	EnableHttpAccess() error
+	DisableHttpAccess() error
+	WaitForHttpAccess(enabled bool, timeout time.Duration) error
+	GetExceptionRuleStatus() (bool, error)
+	EnableExceptionRule() error
+	DisableExceptionRule() error
+	GetSecurityGroupManagementStatus() (*SecurityGroupInfo, error)
+	EnableSecurityGroupManagement(account string) error
+	DisableSecurityGroupManagement() error
+}
+
+// PeeringAPI manages AWS, ARM and transitive peerings and encrypted peering
+// tunnels
+type PeeringAPI interface {
+	CreateAWSPeer(awsPeer *AWSPeer) (string, error)
+	GetAWSPeer(awsPeer *AWSPeer) (*AWSPeer, error)
+	DeleteAWSPeer(awsPeer *AWSPeer) error
+
+	CreateARMPeer(armPeer *ARMPeer) error
+	GetARMPeer(armPeer *ARMPeer) (*ARMPeer, error)
+	DeleteARMPeer(armPeer *ARMPeer) error
+
+	CreateTransPeer(transPeer *TransPeer) error
+	GetTransPeer(transPeer *TransPeer) (*TransPeer, error)
+	DeleteTransPeer(transPeer *TransPeer) error
+
+	CreateTunnel(tunnel *Tunnel) error
+	GetTunnel(tunnel *Tunnel) (*Tunnel, error)
+	UpdateTunnel(tunnel *Tunnel) error
+	DeleteTunnel(tunnel *Tunnel) error
+}
+
+// VPCAPI manages VPCs created by the controller
+type VPCAPI interface {
+	CreateVpc(vpc *Vpc) error
+	GetVpc(vpc *Vpc) (*Vpc, error)
+	DeleteVpc(vpc *Vpc) error
+}
+
+// API is everything the provider needs from the controller
+type API interface {
+	AccountAPI
+	GatewayAPI
+	TagAPI
+	TransitAPI
+	SpokeAPI
+	FQDNAPI
+	FirewallAPI
+	Site2CloudAPI
+	TGWAPI
+	VPNAPI
+	ControllerAPI
+	PeeringAPI
+	VPCAPI
+}
+
+var _ API = (*Client)(nil)
@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 )
 
 // ARMPeer simple struct to hold arm_peer details
@@ -28,45 +26,27 @@ type ArmPeerAPIResp struct {
 }
 
 func (c *Client) CreateARMPeer(armPeer *ARMPeer) error {
-	armPeer.CID = c.CID
-	armPeer.Action = "arm_peer_vnet_pair"
-	resp, err := c.Post(c.baseURL, armPeer)
-	if err != nil {
-		return errors.New("HTTP Post arm_peer_vnet_pair failed: " + err.Error())
-	}
-	var data ArmPeerAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode arm_peer_vnet_pair failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API arm_peer_vnet_pair Post failed: " + data.Reason)
-	}
+	return c.CreateARMPeerWithContext(context.Background(), armPeer)
+}
 
-	return nil
+func (c *Client) CreateARMPeerWithContext(ctx context.Context, armPeer *ARMPeer) error {
+	return c.CallWithContext(ctx, "POST", "arm_peer_vnet_pair", armPeer, nil)
 }
 
 func (c *Client) GetARMPeer(armPeer *ARMPeer) (*ARMPeer, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_arm_peer_vnet_pairs ") + err.Error())
-	}
-	listArmPeering := url.Values{}
-	listArmPeering.Add("CID", c.CID)
-	listArmPeering.Add("action", "list_arm_peer_vnet_pairs")
-	Url.RawQuery = listArmPeering.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetARMPeerWithContext(context.Background(), armPeer)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_arm_peer_vnet_pairs failed: " + err.Error())
-	}
+func (c *Client) GetARMPeerWithContext(ctx context.Context, armPeer *ARMPeer) (*ARMPeer, error) {
 	var data map[string]interface{}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_arm_peer_vnet_pairs failed: " + err.Error())
-	}
-	if _, ok := data["reason"]; ok {
-		log.Printf("[INFO] Couldn't find ARM peering between VPCs %s and %s: %s", armPeer.VNet1, armPeer.VNet2, data["reason"])
+	err := c.CallWithContext(ctx, "GET", "list_arm_peer_vnet_pairs", nil, &data)
+	if isRejection(err) {
+		log.Printf("[INFO] Couldn't find ARM peering between VPCs %s and %s: %s", armPeer.VNet1, armPeer.VNet2, err)
 		return nil, ErrNotFound
 	}
+	if err != nil {
+		return nil, err
+	}
 	if val, ok := data["results"]; ok {
 		pairList := val.(interface{}).([]interface{})
 		for i := range pairList {
@@ -107,28 +87,13 @@ func (c *Client) UpdateARMPeer(armPeer *ARMPeer) error {
 }
 
 func (c *Client) DeleteARMPeer(armPeer *ARMPeer) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for arm_unpeer_vnet_pair") + err.Error())
-	}
-	armUnpeerVNetPair := url.Values{}
-	armUnpeerVNetPair.Add("CID", c.CID)
-	armUnpeerVNetPair.Add("action", "arm_unpeer_vnet_pair")
-	armUnpeerVNetPair.Add("vpc_name1", armPeer.VNet1)
-	armUnpeerVNetPair.Add("vpc_name2", armPeer.VNet2)
-	Url.RawQuery = armUnpeerVNetPair.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Post arm_unpeer_vnet_pair failed: " + err.Error())
-	}
-
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode arm_unpeer_vnet_pair failed: " + err.Error())
-	}
+	return c.DeleteARMPeerWithContext(context.Background(), armPeer)
+}
 
-	if !data.Return {
-		return errors.New("Rest API arm_unpeer_vnet_pair Post failed: " + data.Reason)
+func (c *Client) DeleteARMPeerWithContext(ctx context.Context, armPeer *ARMPeer) error {
+	form := map[string]string{
+		"vpc_name1": armPeer.VNet1,
+		"vpc_name2": armPeer.VNet2,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "arm_unpeer_vnet_pair", form, nil)
 }
@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 	"regexp"
 )
 
@@ -29,18 +27,13 @@ type AwsPeerAPIResp struct {
 }
 
 func (c *Client) CreateAWSPeer(awsPeer *AWSPeer) (string, error) {
-	awsPeer.CID = c.CID
-	awsPeer.Action = "create_aws_peering"
-	resp, err := c.Post(c.baseURL, awsPeer)
-	if err != nil {
-		return "", errors.New("HTTP Post create_aws_peering failed: " + err.Error())
-	}
+	return c.CreateAWSPeerWithContext(context.Background(), awsPeer)
+}
+
+func (c *Client) CreateAWSPeerWithContext(ctx context.Context, awsPeer *AWSPeer) (string, error) {
 	var data AwsPeerAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode create_aws_peering failed: " + err.Error())
-	}
-	if !data.Return {
-		return "", errors.New("Rest API create_aws_peering Post failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "POST", "create_aws_peering", awsPeer, &data); err != nil {
+		return "", err
 	}
 	r, _ := regexp.Compile(`pcx-\w+`)
 	id := r.FindString(data.Results["text"])
@@ -48,31 +41,22 @@ func (c *Client) CreateAWSPeer(awsPeer *AWSPeer) (string, error) {
 }
 
 func (c *Client) GetAWSPeer(awsPeer *AWSPeer) (*AWSPeer, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_aws_peerings ") + err.Error())
-	}
-	listAwsPeering := url.Values{}
-	listAwsPeering.Add("CID", c.CID)
-	listAwsPeering.Add("action", "list_aws_peerings")
-	Url.RawQuery = listAwsPeering.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get list_aws_peerings failed: " + err.Error())
-	}
+	return c.GetAWSPeerWithContext(context.Background(), awsPeer)
+}
 
+func (c *Client) GetAWSPeerWithContext(ctx context.Context, awsPeer *AWSPeer) (*AWSPeer, error) {
 	//Output result for this query cannot be unmarshalled
 	//easily into our defined struct AWSPeer.
 	//So using a map of string->interface{}
 	var data map[string]interface{}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_aws_peerings failed: " + err.Error())
-	}
-	if _, ok := data["reason"]; ok {
-		log.Printf("[INFO] Couldn't find AWS peering between VPCs %s and %s: %s", awsPeer.VpcID1, awsPeer.VpcID2, data["reason"])
+	err := c.CallWithContext(ctx, "GET", "list_aws_peerings", nil, &data)
+	if isRejection(err) {
+		log.Printf("[INFO] Couldn't find AWS peering between VPCs %s and %s: %s", awsPeer.VpcID1, awsPeer.VpcID2, err)
 		return nil, ErrNotFound
 	}
+	if err != nil {
+		return nil, err
+	}
 	if val, ok := data["results"]; ok {
 		if pairList, ok1 := val.(map[string]interface{})["pair_list"].([]interface{}); ok1 {
 			for i := range pairList {
@@ -100,19 +84,9 @@ func (c *Client) UpdateAWSPeer(awsPeer *AWSPeer) error {
 }
 
 func (c *Client) DeleteAWSPeer(awsPeer *AWSPeer) error {
-	awsPeer.CID = c.CID
-	awsPeer.Action = "delete_aws_peering"
-	resp, err := c.Post(c.baseURL, awsPeer)
-	if err != nil {
-		return errors.New("HTTP Post delete_aws_peering failed: " + err.Error())
-	}
+	return c.DeleteAWSPeerWithContext(context.Background(), awsPeer)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_aws_peering failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_aws_peering Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) DeleteAWSPeerWithContext(ctx context.Context, awsPeer *AWSPeer) error {
+	return c.CallWithContext(ctx, "POST", "delete_aws_peering", awsPeer, nil)
 }
@@ -1,14 +1,13 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
 	"log"
-	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // AwsTGW simple struct to hold aws_tgw details
@@ -110,50 +109,28 @@ type listAttachedVpcNamesResp struct {
 }
 
 func (c *Client) CreateAWSTgw(awsTgw *AWSTgw) error {
-	awsTgw.CID = c.CID
-	awsTgw.Action = "add_aws_tgw"
-	resp, err := c.Post(c.baseURL, awsTgw)
-	if err != nil {
-		return errors.New("HTTP Post add_aws_tgw failed: " + err.Error())
-	}
-
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_aws_tgw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_aws_tgw Post failed: " + data.Reason)
-	}
+	return c.CreateAWSTgwWithContext(context.Background(), awsTgw)
+}
 
-	return nil
+func (c *Client) CreateAWSTgwWithContext(ctx context.Context, awsTgw *AWSTgw) error {
+	return c.CallWithContext(ctx, "POST", "add_aws_tgw", awsTgw, nil)
 }
 
 func (c *Client) GetAWSTgw(awsTgw *AWSTgw) (*AWSTgw, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_route_domain_names") + err.Error())
-	}
-	listRouteDomainNames := url.Values{}
-	listRouteDomainNames.Add("CID", c.CID)
-	listRouteDomainNames.Add("action", "list_route_domain_names")
-	listRouteDomainNames.Add("tgw_name", awsTgw.Name)
-	Url.RawQuery = listRouteDomainNames.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetAWSTgwWithContext(context.Background(), awsTgw)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_route_domain_names failed: " + err.Error())
+func (c *Client) GetAWSTgwWithContext(ctx context.Context, awsTgw *AWSTgw) (*AWSTgw, error) {
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
-
 	data := AWSTgwAPIResp{
 		Return:  false,
 		Results: make([]string, 0),
 		Reason:  "",
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_route_domain_names failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_route_domain_names Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_route_domain_names", form, &data); err != nil {
+		return nil, err
 	}
 
 	connectedDomainList := data.Results
@@ -162,25 +139,13 @@ func (c *Client) GetAWSTgw(awsTgw *AWSTgw) (*AWSTgw, error) {
 	for i := range connectedDomainList {
 		dm := connectedDomainList[i]
 
-		viewRouteDomainDetails := url.Values{}
-		viewRouteDomainDetails.Add("CID", c.CID)
-		viewRouteDomainDetails.Add("action", "view_route_domain_details")
-		viewRouteDomainDetails.Add("tgw_name", awsTgw.Name)
-		viewRouteDomainDetails.Add("route_domain_name", dm)
-
-		Url.RawQuery = viewRouteDomainDetails.Encode()
-		resp, err := c.Get(Url.String(), nil)
-
-		if err != nil {
-			return nil, errors.New("HTTP Get view_route_domain_details failed: " + err.Error())
+		form := map[string]string{
+			"tgw_name":          awsTgw.Name,
+			"route_domain_name": dm,
 		}
-
 		var data1 RouteDomainAPIResp
-		if err = json.NewDecoder(resp.Body).Decode(&data1); err != nil {
-			return nil, errors.New("Json Decode view_route_domain_details failed: " + err.Error())
-		}
-		if !data1.Return {
-			return nil, errors.New("Rest API view_route_domain_details Get failed: " + data1.Reason)
+		if err := c.CallWithContext(ctx, "GET", "view_route_domain_details", form, &data1); err != nil {
+			return nil, err
 		}
 		routeDomainDetail := data1.Results
 
@@ -205,7 +170,7 @@ func (c *Client) GetAWSTgw(awsTgw *AWSTgw) (*AWSTgw, error) {
 				gateway := &Gateway{
 					VpcID: attachedVPCs[i].VPCId,
 				}
-				gateway, err = c.GetTransitGwFromVpcID(gateway)
+				gateway, err := c.GetTransitGwFromVpcIDWithContext(ctx, gateway)
 				if err != nil {
 					return nil, err
 				}
@@ -224,22 +189,69 @@ func (c *Client) UpdateAWSTgw(awsTgw *AWSTgw) error {
 }
 
 func (c *Client) DeleteAWSTgw(awsTgw *AWSTgw) error {
-	awsTgw.CID = c.CID
-	awsTgw.Action = "delete_aws_tgw"
-	resp, err := c.Post(c.baseURL, awsTgw)
-	if err != nil {
-		return errors.New("HTTP Post delete_aws_tgw failed: " + err.Error())
-	}
+	return c.DeleteAWSTgwWithContext(context.Background(), awsTgw)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_aws_tgw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_aws_tgw Get failed: " + data.Reason)
+func (c *Client) DeleteAWSTgwWithContext(ctx context.Context, awsTgw *AWSTgw) error {
+	return c.CallWithContext(ctx, "POST", "delete_aws_tgw", awsTgw, nil)
+}
+
+func (c *Client) WaitForAWSTgwAvailable(awsTgw *AWSTgw, timeout time.Duration) error {
+	return c.WaitForAWSTgwAvailableWithContext(context.Background(), awsTgw, timeout)
+}
+
+// WaitForAWSTgwAvailableWithContext waits until the controller lists the
+// security domains of a new TGW, after which attachments can be made.
+func (c *Client) WaitForAWSTgwAvailableWithContext(ctx context.Context, awsTgw *AWSTgw, timeout time.Duration) error {
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
+	}
+	w := &Waiter{
+		Description: "AWS TGW " + awsTgw.Name,
+		Target:      []string{"available"},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			err := c.CallWithContext(ctx, "GET", "list_route_domain_names", form, nil)
+			if IsNotFound(err) {
+				return "pending", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return "available", nil
+		},
 	}
+	_, err := w.Wait(ctx)
+	return err
+}
 
-	return nil
+func (c *Client) WaitForAWSTgwDeleted(awsTgw *AWSTgw, timeout time.Duration) error {
+	return c.WaitForAWSTgwDeletedWithContext(context.Background(), awsTgw, timeout)
+}
+
+// WaitForAWSTgwDeletedWithContext waits until the controller no longer
+// knows the TGW.
+func (c *Client) WaitForAWSTgwDeletedWithContext(ctx context.Context, awsTgw *AWSTgw, timeout time.Duration) error {
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
+	}
+	w := &Waiter{
+		Description: "deletion of AWS TGW " + awsTgw.Name,
+		Target:      []string{"deleted"},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			err := c.CallWithContext(ctx, "GET", "list_route_domain_names", form, nil)
+			if IsNotFound(err) {
+				return "deleted", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return "deleting", nil
+		},
+	}
+	_, err := w.Wait(ctx)
+	return err
 }
 
 func (c *Client) ValidateAWSTgwDomains(domainsAll []string, domainConnAll [][]string, attachedVPCAll [][]string,
@@ -354,162 +366,84 @@ func (c *Client) ValidateAWSTgwDomains(domainsAll []string, domainConnAll [][]st
 }
 
 func (c *Client) AttachAviatrixTransitGWToAWSTgw(awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
-	transitGw, err := c.GetGateway(gateway)
-	if err != nil {
-		return err
-	}
-
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_vpc_to_tgw") + err.Error())
-	}
-	attachVpcToTgw := url.Values{}
-	attachVpcToTgw.Add("CID", c.CID)
-	attachVpcToTgw.Add("action", "attach_vpc_to_tgw")
-	attachVpcToTgw.Add("region", awsTgw.Region)
-	attachVpcToTgw.Add("vpc_account_name", transitGw.AccountName)
-	attachVpcToTgw.Add("vpc_name", transitGw.VpcID)
-	attachVpcToTgw.Add("gateway_name", transitGw.GwName)
-	attachVpcToTgw.Add("tgw_account_name", awsTgw.AccountName)
-	attachVpcToTgw.Add("tgw_name", awsTgw.Name)
-	attachVpcToTgw.Add("route_domain_name", SecurityDomainName)
-	Url.RawQuery = attachVpcToTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.AttachAviatrixTransitGWToAWSTgwWithContext(context.Background(), awsTgw, gateway, SecurityDomainName)
+}
 
+func (c *Client) AttachAviatrixTransitGWToAWSTgwWithContext(ctx context.Context, awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
+	transitGw, err := c.GetGatewayWithContext(ctx, gateway)
 	if err != nil {
-		return errors.New("HTTP Get attach_vpc_to_tgw failed: " + err.Error())
+		return err
 	}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_vpc_to_tgw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API attach_vpc_to_tgw Get failed: " + data.Reason)
+	form := map[string]string{
+		"region":            awsTgw.Region,
+		"vpc_account_name":  transitGw.AccountName,
+		"vpc_name":          transitGw.VpcID,
+		"gateway_name":      transitGw.GwName,
+		"tgw_account_name":  awsTgw.AccountName,
+		"tgw_name":          awsTgw.Name,
+		"route_domain_name": SecurityDomainName,
 	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "attach_vpc_to_tgw", form, nil)
 }
 
 func (c *Client) DetachAviatrixTransitGWFromAWSTgw(awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
-	transitGw, err := c.GetGateway(gateway)
-
-	if err != nil {
-		return err
-	}
+	return c.DetachAviatrixTransitGWFromAWSTgwWithContext(context.Background(), awsTgw, gateway, SecurityDomainName)
+}
 
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for detach_vpc_from_tgw") + err.Error())
-	}
-	detachVpcFromTgw := url.Values{}
-	detachVpcFromTgw.Add("CID", c.CID)
-	detachVpcFromTgw.Add("action", "detach_vpc_from_tgw")
-	detachVpcFromTgw.Add("tgw_name", awsTgw.Name)
-	detachVpcFromTgw.Add("vpc_name", transitGw.VpcID)
-	Url.RawQuery = detachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+func (c *Client) DetachAviatrixTransitGWFromAWSTgwWithContext(ctx context.Context, awsTgw *AWSTgw, gateway *Gateway, SecurityDomainName string) error {
+	transitGw, err := c.GetGatewayWithContext(ctx, gateway)
 
 	if err != nil {
-		return errors.New("HTTP Get detach_vpc_from_tgw failed: " + err.Error())
+		return err
 	}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpc_from_tgw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API detach_vpc_from_tgw Get failed: " + data.Reason)
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
+		"vpc_name": transitGw.VpcID,
 	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "detach_vpc_from_tgw", form, nil)
 }
 
 func (c *Client) AttachVpcToAWSTgw(awsTgw *AWSTgw, vpcSolo VPCSolo, SecurityDomainName string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_vpc_to_tgw") + err.Error())
-	}
-	attachVpcFromTgw := url.Values{}
-	attachVpcFromTgw.Add("CID", c.CID)
-	attachVpcFromTgw.Add("action", "attach_vpc_to_tgw")
-	attachVpcFromTgw.Add("region", awsTgw.Region)
-	attachVpcFromTgw.Add("vpc_account_name", vpcSolo.AccountName)
-	attachVpcFromTgw.Add("vpc_name", vpcSolo.VpcID)
-	attachVpcFromTgw.Add("tgw_name", awsTgw.Name)
-	attachVpcFromTgw.Add("route_domain_name", SecurityDomainName)
-	Url.RawQuery = attachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get attach_vpc_to_tgw failed: " + err.Error())
-	}
+	return c.AttachVpcToAWSTgwWithContext(context.Background(), awsTgw, vpcSolo, SecurityDomainName)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_vpc_to_tgw failed: " + err.Error())
+func (c *Client) AttachVpcToAWSTgwWithContext(ctx context.Context, awsTgw *AWSTgw, vpcSolo VPCSolo, SecurityDomainName string) error {
+	form := map[string]string{
+		"region":            awsTgw.Region,
+		"vpc_account_name":  vpcSolo.AccountName,
+		"vpc_name":          vpcSolo.VpcID,
+		"tgw_name":          awsTgw.Name,
+		"route_domain_name": SecurityDomainName,
 	}
-	if !data.Return {
-		return errors.New("Rest API attach_vpc_to_tgw Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "attach_vpc_to_tgw", form, nil)
 }
 
 func (c *Client) DetachVpcFromAWSTgw(awsTgw *AWSTgw, vpcID string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for detach_vpc_from_tgw") + err.Error())
-	}
-	detachVpcFromTgw := url.Values{}
-	detachVpcFromTgw.Add("CID", c.CID)
-	detachVpcFromTgw.Add("action", "detach_vpc_from_tgw")
-	detachVpcFromTgw.Add("tgw_name", awsTgw.Name)
-	detachVpcFromTgw.Add("vpc_name", vpcID)
-	Url.RawQuery = detachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get detach_vpc_from_tgw failed: " + err.Error())
-	}
+	return c.DetachVpcFromAWSTgwWithContext(context.Background(), awsTgw, vpcID)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpc_from_tgw failed: " + err.Error())
+func (c *Client) DetachVpcFromAWSTgwWithContext(ctx context.Context, awsTgw *AWSTgw, vpcID string) error {
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
+		"vpc_name": vpcID,
 	}
-	if !data.Return {
-		return errors.New("Rest API detach_vpc_from_tgw Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "detach_vpc_from_tgw", form, nil)
 }
 
 func (c *Client) GetTransitGwFromVpcID(gateway *Gateway) (*Gateway, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_vpcs_summary") + err.Error())
-	}
-	listVPCsSummary := url.Values{}
-	listVPCsSummary.Add("CID", c.CID)
-	listVPCsSummary.Add("action", "list_vpcs_summary")
-	Url.RawQuery = listVPCsSummary.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vpcs_summary failed: " + err.Error())
-	}
+	return c.GetTransitGwFromVpcIDWithContext(context.Background(), gateway)
+}
 
+func (c *Client) GetTransitGwFromVpcIDWithContext(ctx context.Context, gateway *Gateway) (*Gateway, error) {
 	data := VPCList{
 		Return:  false,
 		Results: make([]VPCInfo, 0),
 		Reason:  "",
 	}
-
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpcs_summary failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpcs_summary Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_vpcs_summary", nil, &data); err != nil {
+		return nil, err
 	}
 
 	vpcLists := data.Results
@@ -531,31 +465,20 @@ func (c *Client) GetTransitGwFromVpcID(gateway *Gateway) (*Gateway, error) {
 }
 
 func (c *Client) ListTgwDetails(awsTgw *AWSTgw) (*AWSTgw, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_tgw_details") + err.Error())
-	}
-	listTgwDetails := url.Values{}
-	listTgwDetails.Add("CID", c.CID)
-	listTgwDetails.Add("action", "list_tgw_details")
-	listTgwDetails.Add("tgw_name", awsTgw.Name)
-
-	Url.RawQuery = listTgwDetails.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.ListTgwDetailsWithContext(context.Background(), awsTgw)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_tgw_details failed: " + err.Error())
+func (c *Client) ListTgwDetailsWithContext(ctx context.Context, awsTgw *AWSTgw) (*AWSTgw, error) {
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
-
 	var data TGWInfoResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_tgw_details failed: " + err.Error())
+	err := c.CallWithContext(ctx, "GET", "list_tgw_details", form, &data)
+	if reasonContains(err, "does not exist") {
+		return nil, ErrNotFound
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "does not exist") {
-			return nil, ErrNotFound
-		}
-		return nil, errors.New("Rest API list_tgw_details Get failed: " + data.Reason)
+	if err != nil {
+		return nil, err
 	}
 
 	tgwInfoList := data.Results
@@ -571,32 +494,20 @@ func (c *Client) ListTgwDetails(awsTgw *AWSTgw) (*AWSTgw, error) {
 }
 
 func (c *Client) IsVpcAttachedToTgw(awsTgw *AWSTgw, vpcSolo *VPCSolo) (bool, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return false, errors.New(("url Parsing failed for list_attached_vpc_names_to_route_domain") + err.Error())
-	}
-	listAttachedVpcNames := url.Values{}
-	listAttachedVpcNames.Add("CID", c.CID)
-	listAttachedVpcNames.Add("action", "list_attached_vpc_names_to_route_domain")
-	listAttachedVpcNames.Add("tgw_name", awsTgw.Name)
-
-	Url.RawQuery = listAttachedVpcNames.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.IsVpcAttachedToTgwWithContext(context.Background(), awsTgw, vpcSolo)
+}
 
-	if err != nil {
-		return false, errors.New("HTTP Get list_attached_vpc_names_to_route_domain failed: " + err.Error())
+func (c *Client) IsVpcAttachedToTgwWithContext(ctx context.Context, awsTgw *AWSTgw, vpcSolo *VPCSolo) (bool, error) {
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
-
 	data := listAttachedVpcNamesResp{
 		Return:  false,
 		Results: make([]string, 0),
 		Reason:  "",
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return false, errors.New("Json Decode list_attached_vpc_names_to_route_domain failed: " + err.Error())
-	}
-	if !data.Return {
-		return false, errors.New("Rest API list_attached_vpc_names_to_route_domain Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_attached_vpc_names_to_route_domain", form, &data); err != nil {
+		return false, err
 	}
 
 	attachedVpcNames := data.Results
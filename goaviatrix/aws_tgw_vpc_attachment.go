@@ -1,10 +1,9 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"net/url"
 )
 
 type AwsTgwVpcAttachment struct {
@@ -24,52 +23,40 @@ type DomainListResp struct {
 }
 
 func (c *Client) CreateAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_vpc_to_tgw") + err.Error())
-	}
-	attachVpcFromTgw := url.Values{}
-	attachVpcFromTgw.Add("CID", c.CID)
-	attachVpcFromTgw.Add("action", "attach_vpc_to_tgw")
-	attachVpcFromTgw.Add("region", awsTgwVpcAttachment.Region)
-	attachVpcFromTgw.Add("vpc_account_name", awsTgwVpcAttachment.VpcAccountName)
-	attachVpcFromTgw.Add("vpc_name", awsTgwVpcAttachment.VpcID)
-	attachVpcFromTgw.Add("tgw_name", awsTgwVpcAttachment.TgwName)
-	attachVpcFromTgw.Add("route_domain_name", awsTgwVpcAttachment.SecurityDomainName)
-	Url.RawQuery = attachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get attach_vpc_to_tgw failed: " + err.Error())
-	}
+	return c.CreateAwsTgwVpcAttachmentWithContext(context.Background(), awsTgwVpcAttachment)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode attach_vpc_to_tgw failed: " + err.Error())
+func (c *Client) CreateAwsTgwVpcAttachmentWithContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
+	form := map[string]string{
+		"region":            awsTgwVpcAttachment.Region,
+		"vpc_account_name":  awsTgwVpcAttachment.VpcAccountName,
+		"vpc_name":          awsTgwVpcAttachment.VpcID,
+		"tgw_name":          awsTgwVpcAttachment.TgwName,
+		"route_domain_name": awsTgwVpcAttachment.SecurityDomainName,
 	}
-	if !data.Return {
-		return errors.New("Rest API attach_vpc_to_tgw Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "attach_vpc_to_tgw", form, nil)
 }
 
 func (c *Client) GetAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
+	return c.GetAwsTgwVpcAttachmentWithContext(context.Background(), awsTgwVpcAttachment)
+}
+
+func (c *Client) GetAwsTgwVpcAttachmentWithContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
 	awsTgw := &AWSTgw{
 		Name: awsTgwVpcAttachment.TgwName,
 	}
-	awsTgw, err := c.ListTgwDetails(awsTgw)
+	awsTgw, err := c.ListTgwDetailsWithContext(ctx, awsTgw)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't find AWS TGW: %s", awsTgwVpcAttachment.TgwName)
 	}
 	awsTgwVpcAttachment.Region = awsTgw.Region
 
-	err = c.GetAwsTgwDomain(awsTgw, awsTgwVpcAttachment.SecurityDomainName)
+	err = c.GetAwsTgwDomainWithContext(ctx, awsTgw, awsTgwVpcAttachment.SecurityDomainName)
 	if err != nil {
 		return nil, errors.New("aws tgw does not have security domain: " + err.Error())
 	}
 
-	aTVA, err := c.GetAwsTgwDomainAttachedVpc(awsTgwVpcAttachment)
+	aTVA, err := c.GetAwsTgwDomainAttachedVpcWithContext(ctx, awsTgwVpcAttachment)
 	if err != nil {
 		if err == ErrNotFound {
 			return nil, err
@@ -85,35 +72,23 @@ func (c *Client) UpdateAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachm
 }
 
 func (c *Client) DeleteAwsTgwVpcAttachment(awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for detach_vpc_from_tgw") + err.Error())
-	}
-	detachVpcFromTgw := url.Values{}
-	detachVpcFromTgw.Add("CID", c.CID)
-	detachVpcFromTgw.Add("action", "detach_vpc_from_tgw")
-	detachVpcFromTgw.Add("tgw_name", awsTgwVpcAttachment.TgwName)
-	detachVpcFromTgw.Add("vpc_name", awsTgwVpcAttachment.VpcID)
-	Url.RawQuery = detachVpcFromTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get detach_vpc_from_tgw failed: " + err.Error())
-	}
+	return c.DeleteAwsTgwVpcAttachmentWithContext(context.Background(), awsTgwVpcAttachment)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpc_from_tgw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API detach_vpc_from_tgw Get failed: " + data.Reason)
+func (c *Client) DeleteAwsTgwVpcAttachmentWithContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) error {
+	form := map[string]string{
+		"tgw_name": awsTgwVpcAttachment.TgwName,
+		"vpc_name": awsTgwVpcAttachment.VpcID,
 	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "detach_vpc_from_tgw", form, nil)
 }
 
 func (c *Client) GetAwsTgwDetail(awsTgw *AWSTgw) (*AWSTgw, error) {
-	awsTgw, err := c.ListTgwDetails(awsTgw)
+	return c.GetAwsTgwDetailWithContext(context.Background(), awsTgw)
+}
+
+func (c *Client) GetAwsTgwDetailWithContext(ctx context.Context, awsTgw *AWSTgw) (*AWSTgw, error) {
+	awsTgw, err := c.ListTgwDetailsWithContext(ctx, awsTgw)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't find AWS TGW: %s", awsTgw.Name)
 	}
@@ -122,29 +97,20 @@ func (c *Client) GetAwsTgwDetail(awsTgw *AWSTgw) (*AWSTgw, error) {
 }
 
 func (c *Client) GetAwsTgwDomain(awsTgw *AWSTgw, sDM string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for list_route_domain_names") + err.Error())
-	}
-	listRouteDomainNames := url.Values{}
-	listRouteDomainNames.Add("CID", c.CID)
-	listRouteDomainNames.Add("action", "list_route_domain_names")
-	listRouteDomainNames.Add("tgw_name", awsTgw.Name)
-	Url.RawQuery = listRouteDomainNames.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get list_route_domain_names failed: " + err.Error())
+	return c.GetAwsTgwDomainWithContext(context.Background(), awsTgw, sDM)
+}
+
+func (c *Client) GetAwsTgwDomainWithContext(ctx context.Context, awsTgw *AWSTgw, sDM string) error {
+	form := map[string]string{
+		"tgw_name": awsTgw.Name,
 	}
 	data := DomainListResp{
 		Return:  false,
 		Results: make([]string, 0),
 		Reason:  "",
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode list_route_domain_names failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API list_route_domain_names Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_route_domain_names", form, &data); err != nil {
+		return err
 	}
 	mDomain := make(map[string]bool)
 	for i := range data.Results {
@@ -158,25 +124,17 @@ func (c *Client) GetAwsTgwDomain(awsTgw *AWSTgw, sDM string) error {
 }
 
 func (c *Client) GetAwsTgwDomainAttachedVpc(awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
-	Url, err := url.Parse(c.baseURL)
-	viewRouteDomainDetails := url.Values{}
-	viewRouteDomainDetails.Add("CID", c.CID)
-	viewRouteDomainDetails.Add("action", "view_route_domain_details")
-	viewRouteDomainDetails.Add("tgw_name", awsTgwVpcAttachment.TgwName)
-	viewRouteDomainDetails.Add("route_domain_name", awsTgwVpcAttachment.SecurityDomainName)
-	Url.RawQuery = viewRouteDomainDetails.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetAwsTgwDomainAttachedVpcWithContext(context.Background(), awsTgwVpcAttachment)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get view_route_domain_details failed: " + err.Error())
+func (c *Client) GetAwsTgwDomainAttachedVpcWithContext(ctx context.Context, awsTgwVpcAttachment *AwsTgwVpcAttachment) (*AwsTgwVpcAttachment, error) {
+	form := map[string]string{
+		"tgw_name":          awsTgwVpcAttachment.TgwName,
+		"route_domain_name": awsTgwVpcAttachment.SecurityDomainName,
 	}
-
 	var data RouteDomainAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return awsTgwVpcAttachment, errors.New("Json Decode view_route_domain_details failed: " + err.Error())
-	}
-	if !data.Return {
-		return awsTgwVpcAttachment, errors.New("Rest API view_route_domain_details Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "view_route_domain_details", form, &data); err != nil {
+		return awsTgwVpcAttachment, err
 	}
 	routeDomainDetail := data.Results
 	attachedVPCs := routeDomainDetail[0].AttachedVPC
@@ -1,11 +1,12 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"log"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // VGWConn simple struct to hold VGW Connection details
@@ -63,46 +64,32 @@ type GetAwsTgwVpnConnVpnIdResp struct {
 }
 
 func (c *Client) CreateAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (string, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return "", errors.New(("url Parsing failed for connect_transit_gw_to_vgw") + err.Error())
-	}
-	attachEdgeVpnToTgw := url.Values{}
-	attachEdgeVpnToTgw.Add("CID", c.CID)
-	attachEdgeVpnToTgw.Add("action", "attach_edge_vpn_to_tgw")
-	attachEdgeVpnToTgw.Add("tgw_name", awsTgwVpnConn.TgwName)
-	attachEdgeVpnToTgw.Add("route_domain_name", awsTgwVpnConn.RouteDomainName)
-	attachEdgeVpnToTgw.Add("connection_name", awsTgwVpnConn.ConnName)
-	attachEdgeVpnToTgw.Add("public_ip", awsTgwVpnConn.PublicIP)
-	attachEdgeVpnToTgw.Add("onprem_asn", awsTgwVpnConn.OnpremASN)
-	attachEdgeVpnToTgw.Add("remote_cidr", awsTgwVpnConn.RemoteCIDR)
+	return c.CreateAwsTgwVpnConnWithContext(context.Background(), awsTgwVpnConn)
+}
+
+func (c *Client) CreateAwsTgwVpnConnWithContext(ctx context.Context, awsTgwVpnConn *AwsTgwVpnConn) (string, error) {
+	form := url.Values{}
+	form.Add("tgw_name", awsTgwVpnConn.TgwName)
+	form.Add("route_domain_name", awsTgwVpnConn.RouteDomainName)
+	form.Add("connection_name", awsTgwVpnConn.ConnName)
+	form.Add("public_ip", awsTgwVpnConn.PublicIP)
+	form.Add("onprem_asn", awsTgwVpnConn.OnpremASN)
+	form.Add("remote_cidr", awsTgwVpnConn.RemoteCIDR)
 	if awsTgwVpnConn.InsideIpCIDRTun1 != "" {
-		attachEdgeVpnToTgw.Add("inside_ip_cidr_tun_1", awsTgwVpnConn.InsideIpCIDRTun1)
+		form.Add("inside_ip_cidr_tun_1", awsTgwVpnConn.InsideIpCIDRTun1)
 	}
 	if awsTgwVpnConn.InsideIpCIDRTun2 != "" {
-		attachEdgeVpnToTgw.Add("inside_ip_cidr_tun_2", awsTgwVpnConn.InsideIpCIDRTun2)
+		form.Add("inside_ip_cidr_tun_2", awsTgwVpnConn.InsideIpCIDRTun2)
 	}
 	if awsTgwVpnConn.PreSharedKeyTun1 != "" {
-		attachEdgeVpnToTgw.Add("pre_shared_key_tun_1", awsTgwVpnConn.PreSharedKeyTun1)
+		form.Add("pre_shared_key_tun_1", awsTgwVpnConn.PreSharedKeyTun1)
 	}
 	if awsTgwVpnConn.PreSharedKeyTun2 != "" {
-		attachEdgeVpnToTgw.Add("pre_shared_key_tun_2", awsTgwVpnConn.PreSharedKeyTun2)
-	}
-
-	Url.RawQuery = attachEdgeVpnToTgw.Encode()
-
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return "", errors.New("HTTP Get attach_edge_vpn_to_tgw failed: " + err.Error())
+		form.Add("pre_shared_key_tun_2", awsTgwVpnConn.PreSharedKeyTun2)
 	}
-
 	var data AwsTgwVpnConnCreateResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode attach_edge_vpn_to_tgw failed: " + err.Error())
-	}
-
-	if !data.Return {
-		return "", errors.New("Rest API attach_edge_vpn_to_tgw Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "attach_edge_vpn_to_tgw", form, &data); err != nil {
+		return "", err
 	}
 
 	if data.Results.VpnID == "" {
@@ -113,30 +100,17 @@ func (c *Client) CreateAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (string, erro
 }
 
 func (c *Client) GetAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (*AwsTgwVpnConn, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_all_tgw_attachments") + err.Error())
-	}
-	listAllTgwAttachments := url.Values{}
-	listAllTgwAttachments.Add("CID", c.CID)
-	listAllTgwAttachments.Add("action", "list_all_tgw_attachments")
-	listAllTgwAttachments.Add("tgw_name", awsTgwVpnConn.TgwName)
-	listAllTgwAttachments.Add("resource_type", "vpn")
-
-	Url.RawQuery = listAllTgwAttachments.Encode()
+	return c.GetAwsTgwVpnConnWithContext(context.Background(), awsTgwVpnConn)
+}
 
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return nil, errors.New("HTTP Get list_all_tgw_attachments failed: " + err.Error())
+func (c *Client) GetAwsTgwVpnConnWithContext(ctx context.Context, awsTgwVpnConn *AwsTgwVpnConn) (*AwsTgwVpnConn, error) {
+	form := map[string]string{
+		"tgw_name":      awsTgwVpnConn.TgwName,
+		"resource_type": "vpn",
 	}
-
 	var data AwsTgwVpnConnResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_all_tgw_attachments failed: " + err.Error())
-	}
-
-	if !data.Return {
-		return nil, errors.New("Rest API list_all_tgw_attachments Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_all_tgw_attachments", form, &data); err != nil {
+		return nil, err
 	}
 
 	allAwsTgwVpnConn := data.Results
@@ -170,22 +144,36 @@ func (c *Client) GetAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) (*AwsTgwVpnConn,
 }
 
 func (c *Client) DeleteAwsTgwVpnConn(awsTgwVpnConn *AwsTgwVpnConn) error {
-	awsTgwVpnConn.CID = c.CID
-	awsTgwVpnConn.Action = "detach_vpn_from_tgw"
-	resp, err := c.Post(c.baseURL, awsTgwVpnConn)
-	if err != nil {
-		return errors.New("HTTP Post detach_vpn_from_tgw failed: " + err.Error())
-	}
+	return c.DeleteAwsTgwVpnConnWithContext(context.Background(), awsTgwVpnConn)
+}
 
-	var data APIResp
+func (c *Client) DeleteAwsTgwVpnConnWithContext(ctx context.Context, awsTgwVpnConn *AwsTgwVpnConn) error {
+	return c.CallWithContext(ctx, "POST", "detach_vpn_from_tgw", awsTgwVpnConn, nil)
+}
 
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_vpn_from_tgw failed: " + err.Error())
-	}
+func (c *Client) WaitForAwsTgwVpnConnDeleted(awsTgwVpnConn *AwsTgwVpnConn, timeout time.Duration) error {
+	return c.WaitForAwsTgwVpnConnDeletedWithContext(context.Background(), awsTgwVpnConn, timeout)
+}
 
-	if !data.Return {
-		return errors.New("Rest API detach_vpn_from_tgw Post failed: " + data.Reason)
+// WaitForAwsTgwVpnConnDeletedWithContext waits until the VPN connection is
+// no longer attached to the TGW, which AWS takes a while to finish.
+func (c *Client) WaitForAwsTgwVpnConnDeletedWithContext(ctx context.Context, awsTgwVpnConn *AwsTgwVpnConn, timeout time.Duration) error {
+	w := &Waiter{
+		Description: "deletion of TGW VPN connection " + awsTgwVpnConn.VpnID,
+		Target:      []string{"deleted"},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			conn := &AwsTgwVpnConn{TgwName: awsTgwVpnConn.TgwName, VpnID: awsTgwVpnConn.VpnID}
+			_, err := c.GetAwsTgwVpnConnWithContext(ctx, conn)
+			if err == ErrNotFound {
+				return "deleted", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return "deleting", nil
+		},
 	}
-
-	return nil
+	_, err := w.Wait(ctx)
+	return err
 }
@@ -0,0 +1,136 @@
+package goaviatrix
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// cacheableActions are the list actions whose responses ResponseCache keeps.
+// They return whole tables that many resources read during one refresh.
+var cacheableActions = map[string]bool{
+	"list_accounts":                      true,
+	"list_account_users":                 true,
+	"list_arm_peer_vnet_pairs":           true,
+	"list_aws_peerings":                  true,
+	"list_custom_vpcs":                   true,
+	"list_fqdn_filter_tags":              true,
+	"list_inter_transit_gateway_peering": true,
+	"list_peer_vpc_pairs":                true,
+	"list_site2cloud_conn":               true,
+	"list_user_profile_names":            true,
+	"list_vgw_connections":               true,
+	"list_vpcs_summary":                  true,
+}
+
+// ResponseCache keeps the raw responses of list actions for up to TTL. Any
+// mutating action clears it, so reads after a write always reach the
+// controller. Concurrent misses for the same key share one request.
+type ResponseCache struct {
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	expires time.Time
+
+	indexOnce sync.Once
+	index     interface{}
+	indexErr  error
+}
+
+// NewResponseCache returns an empty cache whose entries live for ttl
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{TTL: ttl, entries: map[string]*cacheEntry{}}
+}
+
+// Invalidate drops every cached response
+func (rc *ResponseCache) Invalidate() {
+	rc.mu.Lock()
+	rc.entries = map[string]*cacheEntry{}
+	rc.mu.Unlock()
+}
+
+// get returns the entry for key, calling fetch to fill it if it is missing
+// or expired. Failed fetches are not cached.
+func (rc *ResponseCache) get(ctx context.Context, key string, fetch func() ([]byte, error)) (*cacheEntry, error) {
+	rc.mu.Lock()
+	e, ok := rc.entries[key]
+	if ok && time.Now().After(e.expires) {
+		ok = false
+	}
+	if !ok {
+		// Expiry counts from when the request was sent, so an entry never
+		// outlives TTL from the controller's point of view.
+		e = &cacheEntry{done: make(chan struct{}), expires: time.Now().Add(rc.TTL)}
+		rc.entries[key] = e
+		rc.mu.Unlock()
+
+		e.body, e.err = fetch()
+		if e.err != nil {
+			rc.mu.Lock()
+			if rc.entries[key] == e {
+				delete(rc.entries, key)
+			}
+			rc.mu.Unlock()
+		}
+		close(e.done)
+		return e, e.err
+	}
+	rc.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return e, e.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// derived returns a value computed once from the entry's body, such as an
+// index by name, and shared by every reader of the entry.
+func (e *cacheEntry) derived(build func(body []byte) (interface{}, error)) (interface{}, error) {
+	e.indexOnce.Do(func() {
+		e.index, e.indexErr = build(e.body)
+	})
+	return e.index, e.indexErr
+}
+
+// cacheKey identifies a list action and its parameters, ignoring the CID
+func cacheKey(action string, values url.Values) string {
+	values.Del("CID")
+	return action + "?" + values.Encode()
+}
+
+type noCacheKey struct{}
+
+// withoutCache returns a context making the calls under it fetch from the
+// controller rather than the response cache, leaving the cache as it is.
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// useCache reports whether a call of action under ctx goes through the
+// client's response cache.
+func (c *Client) useCache(ctx context.Context, action string) bool {
+	return c.Cache != nil && cacheableActions[action] && ctx.Value(noCacheKey{}) == nil
+}
+
+// cachedCall returns the response body of a cacheable action from the
+// client's cache, fetching it on a miss.
+func (c *Client) cachedCall(ctx context.Context, verb string, action string, params interface{}) (*cacheEntry, error) {
+	values, err := c.actionValues(action, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.Cache.get(ctx, cacheKey(action, values), func() ([]byte, error) {
+		_, body, err := c.invoke(ctx, verb, action, params)
+		return body, err
+	})
+}
@@ -0,0 +1,223 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingController answers every action with a gateway list and counts
+// the requests per action.
+type countingController struct {
+	mu    sync.Mutex
+	calls map[string]int
+	fail  bool
+}
+
+func (cc *countingController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	cc.mu.Lock()
+	if cc.calls == nil {
+		cc.calls = map[string]int{}
+	}
+	cc.calls[r.Form.Get("action")]++
+	fail := cc.fail
+	cc.mu.Unlock()
+	if fail {
+		writeJSON(w, APIResp{Return: false, Reason: "Gateway list is not valid"})
+		return
+	}
+	writeJSON(w, GatewayListResp{Return: true, Results: []Gateway{{GwName: "gw1"}, {GwName: "gw2"}}})
+}
+
+func (cc *countingController) count(action string) int {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.calls[action]
+}
+
+func newCachingTestClient(t *testing.T, cc *countingController, ttl time.Duration) (*Client, func()) {
+	client, server := newTestClient(t, cc.ServeHTTP)
+	client.Cache = NewResponseCache(ttl)
+	return client, server.Close
+}
+
+func TestResponseCacheHit(t *testing.T) {
+	cc := &countingController{}
+	client, done := newCachingTestClient(t, cc, time.Minute)
+	defer done()
+
+	for i := 0; i < 3; i++ {
+		if err := client.Call("GET", "list_vpcs_summary", nil, nil); err != nil {
+			t.Fatalf("Call: %s", err)
+		}
+	}
+	if n := cc.count("list_vpcs_summary"); n != 1 {
+		t.Errorf("list_vpcs_summary sent %d times, want 1", n)
+	}
+
+	// Other parameters are another entry, but a new CID is not.
+	client.Call("GET", "list_vpcs_summary", map[string]string{"account_name": "a"}, nil)
+	client.setCID("other-cid")
+	client.Call("GET", "list_vpcs_summary", nil, nil)
+	if n := cc.count("list_vpcs_summary"); n != 2 {
+		t.Errorf("list_vpcs_summary sent %d times, want 2", n)
+	}
+
+	// Actions outside cacheableActions always reach the controller.
+	client.Call("GET", "get_gateway_info", nil, nil)
+	client.Call("GET", "get_gateway_info", nil, nil)
+	if n := cc.count("get_gateway_info"); n != 2 {
+		t.Errorf("get_gateway_info sent %d times, want 2", n)
+	}
+}
+
+func TestResponseCacheInvalidation(t *testing.T) {
+	cc := &countingController{}
+	client, done := newCachingTestClient(t, cc, time.Minute)
+	defer done()
+
+	client.Call("GET", "list_vpcs_summary", nil, nil)
+	client.Call("GET", "get_gateway_info", nil, nil)
+	client.GetSplitTunnel(&SplitTunnel{VpcID: "vpc-0123"})
+	client.Call("GET", "list_vpcs_summary", nil, nil)
+	if n := cc.count("list_vpcs_summary"); n != 1 {
+		t.Fatalf("list_vpcs_summary sent %d times after reads, want 1", n)
+	}
+
+	client.Call("POST", "delete_container", nil, nil)
+	client.Call("GET", "list_vpcs_summary", nil, nil)
+	if n := cc.count("list_vpcs_summary"); n != 2 {
+		t.Errorf("list_vpcs_summary sent %d times after a write, want 2", n)
+	}
+
+	client.Cache.Invalidate()
+	client.Call("GET", "list_vpcs_summary", nil, nil)
+	if n := cc.count("list_vpcs_summary"); n != 3 {
+		t.Errorf("list_vpcs_summary sent %d times after Invalidate, want 3", n)
+	}
+}
+
+func TestResponseCacheBypass(t *testing.T) {
+	cc := &countingController{}
+	client, done := newCachingTestClient(t, cc, time.Minute)
+	defer done()
+
+	client.GetGateway(&Gateway{GwName: "gw1"})
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetGatewayWithContext(withoutCache(context.Background()), &Gateway{GwName: "gw1"}); err != nil {
+			t.Fatalf("GetGatewayWithContext: %s", err)
+		}
+	}
+	if n := cc.count("list_vpcs_summary"); n != 3 {
+		t.Errorf("list_vpcs_summary sent %d times bypassing the cache, want 3", n)
+	}
+
+	// Bypassing the cache leaves the cached response in place.
+	client.GetGateway(&Gateway{GwName: "gw1"})
+	if n := cc.count("list_vpcs_summary"); n != 3 {
+		t.Errorf("list_vpcs_summary sent %d times after bypassing the cache, want 3", n)
+	}
+}
+
+func TestResponseCacheExpiry(t *testing.T) {
+	cc := &countingController{}
+	client, done := newCachingTestClient(t, cc, 20*time.Millisecond)
+	defer done()
+
+	client.Call("GET", "list_vpcs_summary", nil, nil)
+	time.Sleep(40 * time.Millisecond)
+	client.Call("GET", "list_vpcs_summary", nil, nil)
+	if n := cc.count("list_vpcs_summary"); n != 2 {
+		t.Errorf("list_vpcs_summary sent %d times, want 2", n)
+	}
+}
+
+func TestResponseCacheSkipsFailures(t *testing.T) {
+	cc := &countingController{fail: true}
+	client, done := newCachingTestClient(t, cc, time.Minute)
+	defer done()
+	client.RetryPolicy = nil
+
+	if err := client.Call("GET", "list_vpcs_summary", nil, nil); err == nil {
+		t.Fatalf("Call succeeded, want the controller's rejection")
+	}
+	cc.mu.Lock()
+	cc.fail = false
+	cc.mu.Unlock()
+	if err := client.Call("GET", "list_vpcs_summary", nil, nil); err != nil {
+		t.Fatalf("Call: %s", err)
+	}
+	if n := cc.count("list_vpcs_summary"); n != 2 {
+		t.Errorf("list_vpcs_summary sent %d times, want 2", n)
+	}
+}
+
+func TestResponseCacheSharesConcurrentMisses(t *testing.T) {
+	rc := NewResponseCache(time.Minute)
+	release := make(chan struct{})
+	var fetches int32
+	fetch := func() ([]byte, error) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		return []byte("body"), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e, err := rc.get(context.Background(), "list_accounts?", fetch)
+			if err != nil || string(e.body) != "body" {
+				t.Errorf("get = %v, %v", e, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if fetches != 1 {
+		t.Errorf("fetched %d times, want 1", fetches)
+	}
+}
+
+func TestResponseCacheWaitHonorsContext(t *testing.T) {
+	rc := NewResponseCache(time.Minute)
+	release := make(chan struct{})
+	defer close(release)
+	go rc.get(context.Background(), "k", func() ([]byte, error) {
+		<-release
+		return nil, nil
+	})
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := rc.get(ctx, "k", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestGatewayIndex(t *testing.T) {
+	cc := &countingController{}
+	client, done := newCachingTestClient(t, cc, time.Minute)
+	defer done()
+
+	for _, name := range []string{"gw1", "gw2"} {
+		gw, err := client.GetGateway(&Gateway{GwName: name})
+		if err != nil || gw.GwName != name {
+			t.Errorf("GetGateway(%s) = %v, %v", name, gw, err)
+		}
+	}
+	if _, err := client.GetGateway(&Gateway{GwName: "gw3"}); err != ErrNotFound {
+		t.Errorf("GetGateway(gw3) err = %v, want %v", err, ErrNotFound)
+	}
+	if n := cc.count("list_vpcs_summary"); n != 1 {
+		t.Errorf("list_vpcs_summary sent %d times, want 1", n)
+	}
+}
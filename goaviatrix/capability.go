@@ -0,0 +1,87 @@
+package goaviatrix
+
+import (
+	"fmt"
+)
+
+// Capability is a controller feature that only some controller versions
+// have.
+type Capability string
+
+const (
+	CapabilityAwsTgwVpnConn      Capability = "AWS TGW VPN connections"
+	CapabilityVpnUserAccelerator Capability = "VPN user accelerator"
+	CapabilityMaxVpnConn         Capability = "VPN connection limit"
+)
+
+// capabilities lists the controller versions that have each capability,
+// from the controller release each was first supported with. Features that
+// every supported controller has, such as insane mode (4.3) or AWS TGW
+// orchestration (4.1), need no entry.
+var capabilities = map[Capability]VersionRange{
+	CapabilityAwsTgwVpnConn:      {Min: AviatrixVersion{Major: 4, Minor: 7, Build: 378}},
+	CapabilityVpnUserAccelerator: {Min: AviatrixVersion{Major: 4, Minor: 7, Build: 378}},
+	CapabilityMaxVpnConn:         {Min: AviatrixVersion{Major: 4, Minor: 7, Build: 474}},
+}
+
+// VersionRange is a range of controller versions, including Min and
+// excluding Max. A zero Max leaves the range open ended.
+type VersionRange struct {
+	Min AviatrixVersion
+	Max AviatrixVersion
+}
+
+// Contains reports whether v is within the range.
+func (r VersionRange) Contains(v AviatrixVersion) bool {
+	if v.Compare(r.Min) < 0 {
+		return false
+	}
+	return r.Max == (AviatrixVersion{}) || v.Compare(r.Max) < 0
+}
+
+func (r VersionRange) String() string {
+	if r.Max == (AviatrixVersion{}) {
+		return ">= " + r.Min.String()
+	}
+	return ">= " + r.Min.String() + ", < " + r.Max.String()
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as or newer than
+// other.
+func (v AviatrixVersion) Compare(other AviatrixVersion) int {
+	for _, d := range []int64{v.Major - other.Major, v.Minor - other.Minor, v.Build - other.Build} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// String formats the version as major.minor, with the build if it is set.
+func (v AviatrixVersion) String() string {
+	if v.Build == 0 {
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Build)
+}
+
+// RequireCapability returns an error if the controller's version, as found
+// by ControllerVersionValidation, lacks the capability. Nothing is checked
+// if the version was never validated.
+func (c *Client) RequireCapability(capability Capability) error {
+	if c.ControllerVersion == nil {
+		return nil
+	}
+	r, ok := capabilities[capability]
+	if !ok {
+		return fmt.Errorf("unknown controller capability %q", capability)
+	}
+	if !r.Contains(*c.ControllerVersion) {
+		return fmt.Errorf("%s requires Aviatrix controller version %s, but the controller is running %s",
+			capability, r, c.ControllerVersion)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package goaviatrix
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestVersionCompare(t *testing.T) {
+	cases := []struct {
+		v, other AviatrixVersion
+		want     int
+	}{
+		{AviatrixVersion{4, 7, 520}, AviatrixVersion{4, 7, 520}, 0},
+		{AviatrixVersion{4, 7, 474}, AviatrixVersion{4, 7, 520}, -1},
+		{AviatrixVersion{4, 7, 0}, AviatrixVersion{4, 6, 604}, 1},
+		{AviatrixVersion{5, 0, 0}, AviatrixVersion{4, 7, 520}, 1},
+		{AviatrixVersion{3, 9, 0}, AviatrixVersion{4, 0, 0}, -1},
+	}
+	for _, tc := range cases {
+		if got := tc.v.Compare(tc.other); got != tc.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tc.v, tc.other, got, tc.want)
+		}
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	bounded := VersionRange{Min: AviatrixVersion{Major: 4, Minor: 6}, Max: AviatrixVersion{Major: 5}}
+	open := VersionRange{Min: AviatrixVersion{Major: 4, Minor: 7, Build: 474}}
+	cases := []struct {
+		r    VersionRange
+		v    AviatrixVersion
+		want bool
+	}{
+		{bounded, AviatrixVersion{4, 6, 0}, true},
+		{bounded, AviatrixVersion{4, 7, 520}, true},
+		{bounded, AviatrixVersion{4, 99, 0}, true},
+		{bounded, AviatrixVersion{4, 5, 999}, false},
+		{bounded, AviatrixVersion{5, 0, 0}, false},
+		{bounded, AviatrixVersion{5, 1, 0}, false},
+		{open, AviatrixVersion{4, 7, 474}, true},
+		{open, AviatrixVersion{6, 0, 0}, true},
+		{open, AviatrixVersion{4, 7, 419}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.r.Contains(tc.v); got != tc.want {
+			t.Errorf("(%s).Contains(%s) = %t, want %t", tc.r, tc.v, got, tc.want)
+		}
+	}
+
+	if got, want := bounded.String(), ">= 4.6, < 5.0"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+	if got, want := open.String(), ">= 4.7.474"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
+
+func TestRequireCapability(t *testing.T) {
+	cases := []struct {
+		version    *AviatrixVersion
+		capability Capability
+		wantErr    string
+	}{
+		// Nothing is checked before the version is validated.
+		{nil, CapabilityMaxVpnConn, ""},
+		{&AviatrixVersion{4, 6, 604}, CapabilityVpnUserAccelerator,
+			"VPN user accelerator requires Aviatrix controller version >= 4.7.378, but the controller is running 4.6.604"},
+		{&AviatrixVersion{4, 6, 604}, CapabilityAwsTgwVpnConn, "requires Aviatrix controller version >= 4.7.378"},
+		{&AviatrixVersion{4, 7, 419}, CapabilityAwsTgwVpnConn, ""},
+		{&AviatrixVersion{4, 7, 419}, CapabilityMaxVpnConn, "requires Aviatrix controller version >= 4.7.474"},
+		{&AviatrixVersion{4, 7, 520}, CapabilityMaxVpnConn, ""},
+		{&AviatrixVersion{4, 7, 520}, Capability("teleport"), `unknown controller capability "teleport"`},
+	}
+	for _, tc := range cases {
+		client := &Client{ControllerVersion: tc.version}
+		err := client.RequireCapability(tc.capability)
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("RequireCapability(%s) at %v = %s, want no error", tc.capability, tc.version, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("RequireCapability(%s) at %v = %v, want %q", tc.capability, tc.version, err, tc.wantErr)
+		}
+	}
+}
+
+func TestControllerVersionValidation(t *testing.T) {
+	supported := VersionRange{Min: AviatrixVersion{Major: 4, Minor: 6}, Max: AviatrixVersion{Major: 5}}
+	cases := []struct {
+		current string
+		wantErr bool
+	}{
+		{"UserConnect-4.7.520", false},
+		{"UserConnect-4.6.604", false},
+		{"UserConnect-4.3.1275", true},
+		{"UserConnect-5.0.2675", true},
+	}
+	for _, tc := range cases {
+		client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, VersionInfoResp{Return: true, Results: VersionInfo{CurrentVersion: tc.current}})
+		})
+		err := client.ControllerVersionValidation(supported)
+		server.Close()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, want an error: %t", tc.current, err, tc.wantErr)
+		}
+		if err == nil && client.ControllerVersion == nil {
+			t.Errorf("%s: ControllerVersion not set", tc.current)
+		}
+		if err != nil && client.ControllerVersion != nil {
+			t.Errorf("%s: ControllerVersion set to %s for an unsupported controller", tc.current, client.ControllerVersion)
+		}
+	}
+}
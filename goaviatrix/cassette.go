@@ -0,0 +1,268 @@
+package goaviatrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RecordMode selects whether a Cassette records controller traffic, replays
+// it, or is not used at all.
+type RecordMode string
+
+const (
+	RecordModeOff    RecordMode = "off"
+	RecordModeRecord RecordMode = "record"
+	RecordModeReplay RecordMode = "replay"
+)
+
+// ParseRecordMode parses the value of AVIATRIX_RECORD_MODE. The empty string
+// means RecordModeOff.
+func ParseRecordMode(s string) (RecordMode, error) {
+	switch mode := RecordMode(strings.ToLower(s)); mode {
+	case "", RecordModeOff:
+		return RecordModeOff, nil
+	case RecordModeRecord, RecordModeReplay:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid record mode %q: must be record, replay or off", s)
+}
+
+// Interaction is one recorded request to the controller and its response.
+// Params and Body are redacted the same way as TRACE logs, and the CID is
+// left out of Params altogether.
+type Interaction struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Action string          `json:"action"`
+	Params url.Values      `json:"params"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+// Cassette holds controller interactions recorded to, or replayed from, a
+// JSON file. Requests are matched by action name and parameters, ignoring
+// the CID; identical requests are replayed in the order they were recorded,
+// the last one repeating once they run out.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+	// RedactKeys lists keys masked in addition to passwords, secrets and
+	// the CID, as for Client.RedactKeys.
+	RedactKeys []string `json:"-"`
+
+	mu   sync.Mutex
+	path string
+	next map[string]int
+}
+
+// NewCassette starts an empty cassette that is saved to path after every
+// recorded interaction.
+func NewCassette(path string) *Cassette {
+	return &Cassette{Interactions: []*Interaction{}, path: path}
+}
+
+// LoadCassette reads a cassette recorded to path for replay.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %s", err)
+	}
+	c := &Cassette{path: path}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %s", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to the file it was created or loaded from.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save()
+}
+
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// Transport returns a RoundTripper that, in record mode, sends requests with
+// next and records them, and in replay mode answers them from the cassette
+// without any network access. In off mode it returns next itself.
+func (c *Cassette) Transport(mode RecordMode, next http.RoundTripper) http.RoundTripper {
+	switch mode {
+	case RecordModeRecord:
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		return &cassetteTransport{cassette: c, next: next}
+	case RecordModeReplay:
+		return &cassetteTransport{cassette: c}
+	}
+	return next
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	params, err := requestParams(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.next == nil {
+		return t.cassette.replay(req, params)
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err := t.cassette.record(req, params, resp.StatusCode, body); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %s", params.Get("action"), err)
+	}
+	return resp, nil
+}
+
+// requestParams returns the form values of req from its query string or
+// its body, restoring the body for the next transport.
+func requestParams(req *http.Request) (url.Values, error) {
+	if req.Body == nil {
+		return req.URL.Query(), nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return url.ParseQuery(string(body))
+}
+
+// normalize returns params without the CID and with sensitive values
+// masked, which is both what is stored and what requests are matched on.
+func (c *Cassette) normalize(params url.Values) url.Values {
+	normalized := url.Values{}
+	for k, v := range params {
+		switch {
+		case k == "CID":
+		case isSensitiveKey(k, c.RedactKeys):
+			normalized[k] = []string{redactedValue}
+		default:
+			normalized[k] = v
+		}
+	}
+	return normalized
+}
+
+func interactionKey(action string, params url.Values) string {
+	return action + "?" + params.Encode()
+}
+
+func (c *Cassette) record(req *http.Request, params url.Values, status int, body []byte) error {
+	in := &Interaction{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Action: params.Get("action"),
+		Params: c.normalize(params),
+		Status: status,
+	}
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if dec.Decode(&doc) == nil && !dec.More() {
+		redacted, err := json.Marshal(c.redactJSON(doc))
+		if err != nil {
+			return err
+		}
+		in.Body = redacted
+	} else {
+		in.Text = string(body)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, in)
+	return c.save()
+}
+
+// redactJSON masks the values of sensitive keys anywhere in a decoded JSON
+// document, such as the CID returned by login.
+func (c *Cassette) redactJSON(doc interface{}) interface{} {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if isSensitiveKey(k, c.RedactKeys) {
+				v[k] = redactedValue
+			} else {
+				v[k] = c.redactJSON(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = c.redactJSON(v[i])
+		}
+	}
+	return doc
+}
+
+func (c *Cassette) replay(req *http.Request, params url.Values) (*http.Response, error) {
+	action := params.Get("action")
+	key := interactionKey(action, c.normalize(params))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.next == nil {
+		c.next = map[string]int{}
+	}
+	var matches []*Interaction
+	for _, in := range c.Interactions {
+		if interactionKey(in.Action, in.Params) == key {
+			matches = append(matches, in)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no interaction recorded in %s for %s %s", c.path, req.Method, key)
+	}
+	i := c.next[key]
+	if i < len(matches)-1 {
+		c.next[key] = i + 1
+	} else {
+		i = len(matches) - 1
+	}
+	in := matches[i]
+
+	body := []byte(in.Text)
+	contentType := "text/plain; charset=utf-8"
+	if in.Body != nil {
+		body = in.Body
+		contentType = "application/json"
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{contentType}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
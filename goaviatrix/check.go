@@ -1,23 +1,28 @@
 package goaviatrix
 
 import (
-	"strings"
+	"context"
 
 	"github.com/pkg/errors"
 )
 
-func (c *Client) ControllerVersionValidation(supportedVersion string) error {
-	suppVersion := strings.Split(supportedVersion, ".")
+// ControllerVersionValidation checks that the controller runs a version in
+// the supported range and remembers the version for RequireCapability.
+func (c *Client) ControllerVersionValidation(supported VersionRange) error {
+	return c.ControllerVersionValidationWithContext(context.Background(), supported)
+}
 
-	currentVersion, _, err := c.GetCurrentVersion()
+func (c *Client) ControllerVersionValidationWithContext(ctx context.Context, supported VersionRange) error {
+	_, currentVersion, err := c.GetCurrentVersionWithContext(ctx)
 	if err != nil {
 		return err
 	}
-	currVersion := strings.Split(currentVersion, ".")
-	if suppVersion[0] != currVersion[0] || suppVersion[1] != currVersion[1] {
-		return errors.New("current Terraform branch supports controller version: UserConnect-" + supportedVersion +
+	if !supported.Contains(*currentVersion) {
+		return errors.New("current Terraform branch supports controller versions " + supported.String() +
+			", but the controller is running " + currentVersion.String() +
 			". Please upgrade/downgrade controller or change Terraform branch.")
 	}
+	c.ControllerVersion = currentVersion
 
 	return nil
 }
@@ -2,19 +2,19 @@ package goaviatrix
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ajg/form"
-	"github.com/google/go-querystring/query"
 )
 
 // LoginResp represents the response object from the `login` action
@@ -37,6 +37,8 @@ type APIRequest struct {
 	Action string `form:"action,omitempty" json:"action" url:"action"`
 }
 
+const cidExpiredReason = "CID is invalid or expired."
+
 // Client for accessing the Aviatrix Controller
 type Client struct {
 	HTTPClient   *http.Client
@@ -44,7 +46,38 @@ type Client struct {
 	Password     string
 	CID          string
 	ControllerIP string
-	baseURL      string
+	RetryPolicy  *RetryPolicy
+	Observer     Observer
+	// ReadLimiter and WriteLimiter throttle list_/get_ actions and all
+	// other actions respectively; nil means unlimited.
+	ReadLimiter  *Limiter
+	WriteLimiter *Limiter
+	// Cache, if set, keeps list action responses between calls.
+	Cache *ResponseCache
+	// RedactKeys lists form keys masked in TRACE logs in addition to
+	// passwords, secrets and the CID.
+	RedactKeys []string
+	// ControllerVersion is the controller version found by
+	// ControllerVersionValidation, used by RequireCapability.
+	ControllerVersion *AviatrixVersion
+	// SessionCache, if set, supplies the CID at start and keeps the CID of
+	// every login for later runs.
+	SessionCache *SessionCache
+	// ReadOnly refuses every action that could change the controller.
+	// DryRun logs such actions with their redacted parameters instead of
+	// sending them, and fails them with ErrDryRun.
+	ReadOnly bool
+	DryRun   bool
+	// DefaultTags are merged into the tags of every taggable resource the
+	// provider manages. Tags set on a resource take precedence.
+	DefaultTags map[string]string
+	baseURL     string
+	backendURL  string
+	// cidMu guards CID once the client is shared between resources, so
+	// read it with GetCID from then on. loginMu lets only one caller at a
+	// time log in again.
+	cidMu   sync.RWMutex
+	loginMu sync.Mutex
 }
 
 // Login to the Aviatrix controller with the username/password provided in
@@ -54,25 +87,62 @@ type Client struct {
 // Returns:
 //    error - if any
 func (c *Client) Login() error {
+	return c.LoginWithContext(context.Background())
+}
+
+// LoginWithContext is Login with a context that can cancel the request.
+func (c *Client) LoginWithContext(ctx context.Context) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+	return c.login(ctx)
+}
+
+// relogin logs in again after the controller rejected staleCID as expired.
+// Callers that find the CID already replaced by a concurrent re-login reuse
+// the new CID instead of logging in once more.
+func (c *Client) relogin(ctx context.Context, staleCID string) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+	if c.GetCID() != staleCID {
+		return nil
+	}
+	return c.login(ctx)
+}
+
+// login logs in and stores the new CID. The caller must hold loginMu.
+func (c *Client) login(ctx context.Context) error {
+	if c.Username == "" || c.Password == "" {
+		return fmt.Errorf("Aviatrix: Client: no username and password to log in to controller %s", c.ControllerIP)
+	}
 	account := make(map[string]interface{})
 	account["action"] = "login"
 	account["username"] = c.Username
 	account["password"] = c.Password
 
 	log.Printf("[INFO] Parsed Aviatrix login: %#v", account["username"])
-	resp, err := c.Post(c.baseURL, account)
+	req := c.beforeRequest("POST", "login")
+	resp, err := c.RequestWithContext(ctx, "POST", c.baseURL, account)
 	if err != nil {
+		c.afterResponse(req, nil, nil, err)
 		return err
 	}
+	defer resp.Body.Close()
 	var data LoginResp
 	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		c.afterResponse(req, resp, nil, err)
 		return err
 	}
+	c.afterResponse(req, resp, &APIResp{Return: data.Return, Reason: data.Reason}, nil)
 	if !data.Return {
-		return errors.New(data.Reason)
+		return newAPIError("login", resp.StatusCode, data.Reason)
+	}
+	log.Printf("[TRACE] Logged in to Aviatrix controller %s", c.ControllerIP)
+	c.setCID(data.CID)
+	if c.SessionCache != nil {
+		if err := c.SessionCache.Store(c.ControllerIP, c.Username, data.CID); err != nil {
+			log.Printf("[WARN] Failed to cache Aviatrix controller session: %s", err)
+		}
 	}
-	log.Printf("[TRACE] CID is '%s'.", data.CID)
-	c.CID = data.CID
 	return nil
 }
 
@@ -88,14 +158,41 @@ func (c *Client) Login() error {
 // See Also:
 //   init()
 func NewClient(username string, password string, controllerIP string, HTTPClient *http.Client) (*Client, error) {
-	client := &Client{Username: username, Password: password, HTTPClient: HTTPClient, ControllerIP: controllerIP}
+	client := &Client{Username: username, Password: password, HTTPClient: HTTPClient, ControllerIP: controllerIP,
+		RetryPolicy: DefaultRetryPolicy()}
+	return client.init(controllerIP)
+}
+
+// NewClientWithCID creates a Client that uses a session already logged in
+// to, instead of logging in itself. username and password may be empty; if
+// given they are used to log in again once the CID expires.
+func NewClientWithCID(username string, password string, cid string, controllerIP string, HTTPClient *http.Client) (*Client, error) {
+	client := &Client{Username: username, Password: password, CID: cid, HTTPClient: HTTPClient,
+		ControllerIP: controllerIP, RetryPolicy: DefaultRetryPolicy()}
+	return client.init(controllerIP)
+}
+
+// NewClientWithSessionCache creates a Client like NewClient, but starts with
+// the CID cached for the user on the controller, if any, instead of logging
+// in. Once that CID expires the client logs in again and caches the new one.
+func NewClientWithSessionCache(username string, password string, controllerIP string, cache *SessionCache, HTTPClient *http.Client) (*Client, error) {
+	client := &Client{Username: username, Password: password, HTTPClient: HTTPClient, ControllerIP: controllerIP,
+		RetryPolicy: DefaultRetryPolicy(), SessionCache: cache}
+	if cache != nil {
+		client.CID = cache.Load(controllerIP, username)
+		if client.CID != "" {
+			log.Printf("[TRACE] Using cached session for Aviatrix controller %s", controllerIP)
+		}
+	}
 	return client.init(controllerIP)
 }
 
 // init initializes the new client with the given controller IP/host.  Logs
-// in to the controller and sets up the http client.
+// in to the controller, unless the client already has a CID, and sets up
+// the http client.
 // Arguments:
-//    controllerIP - the controller host/IP
+//    controllerIP - the controller host/IP, optionally with a port, or a
+//                   full URL such as https://host:8443/aviatrix
 // Returns:
 //   Client - the updated client object
 //   error - if any
@@ -104,23 +201,54 @@ func (c *Client) init(controllerIP string) (*Client, error) {
 		return nil, fmt.Errorf("Aviatrix: Client: Controller IP is not set")
 	}
 
-	c.baseURL = "https://" + controllerIP + "/v1/api"
+	var err error
+	if c.baseURL, c.backendURL, err = controllerEndpoints(controllerIP); err != nil {
+		return nil, err
+	}
 
 	if c.HTTPClient == nil {
+		tlsConfig, err := (&TLSOptions{}).TLSConfig()
+		if err != nil {
+			return nil, err
+		}
 		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
+			TLSClientConfig: tlsConfig,
+			Proxy:           http.ProxyFromEnvironment,
 		}
 		c.HTTPClient = &http.Client{Transport: tr}
 	}
-	if err := c.Login(); err != nil {
-		return nil, err
+	if c.CID == "" {
+		if err := c.Login(); err != nil {
+			return nil, err
+		}
 	}
 
 	return c, nil
 }
 
+// controllerEndpoints returns the URLs of the API and of the private
+// backend for a controller given as host[:port] or as an http(s) URL with an
+// optional base path.
+func controllerEndpoints(controller string) (api string, backend string, err error) {
+	if !strings.Contains(controller, "://") {
+		controller = "https://" + controller
+	}
+	u, err := url.Parse(controller)
+	if err != nil {
+		return "", "", fmt.Errorf("Aviatrix: Client: invalid controller URL %q: %s", controller, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", "", fmt.Errorf("Aviatrix: Client: invalid controller URL %q: "+
+			"must be http(s)://host[:port][/path]", controller)
+	}
+	base := strings.TrimRight(u.Path, "/")
+	u.Path = base + "/v1/api"
+	api = u.String()
+	u.Path = base + "/v1/backend1"
+	backend = u.String()
+	return api, backend, nil
+}
+
 func (c *Client) Get(path string, i interface{}) (*http.Response, error) {
 	return c.Request("GET", path, i)
 }
@@ -149,90 +277,198 @@ func (c *Client) Delete(path string, i interface{}) (*http.Response, error) {
 //   []byte - the body string as a byte array
 //   error - if any
 func (c *Client) Do(verb string, req interface{}) (*http.Response, []byte, error) {
-	var err error
-	var resp *http.Response
-	var url string
+	return c.DoWithContext(context.Background(), verb, req)
+}
+
+// DoWithContext is Do with a context that cancels the request and any
+// retries.
+func (c *Client) DoWithContext(ctx context.Context, verb string, req interface{}) (*http.Response, []byte, error) {
+	return c.invoke(ctx, verb, actionName(req), req)
+}
+
+// Call invokes a controller action and decodes the response into out.
+// Arguments:
+//   verb   - GET or POST
+//   action - the controller action name
+//   params - nil, url.Values, map[string]string or a form-tagged struct;
+//            the CID and action are filled in by Call
+//   out    - pointer the full JSON response is decoded into, or nil
+// Returns:
+//   error - an *APIError if the controller rejected the action
+func (c *Client) Call(verb string, action string, params interface{}, out interface{}) error {
+	return c.CallWithContext(context.Background(), verb, action, params, out)
+}
+
+// CallWithContext is Call with a context that cancels the request and any
+// retries.
+func (c *Client) CallWithContext(ctx context.Context, verb string, action string, params interface{}, out interface{}) error {
 	var body []byte
-	respdata := new(APIResp)
-
-	// do request
-	var loop int
-	for {
-		url = c.baseURL
-		loop = loop + 1
-		if verb == "GET" {
-			// prepare query string
-			v, _ := query.Values(req)
-			url = url + "?" + v.Encode()
-			resp, err = c.Request(verb, url, nil)
-		} else {
-			resp, err = c.Request(verb, url, req)
+	if c.useCache(ctx, action) {
+		e, err := c.cachedCall(ctx, verb, action, params)
+		if err != nil {
+			return err
 		}
+		body = e.body
+	} else {
+		var err error
+		if _, body, err = c.invoke(ctx, verb, action, params); err != nil {
+			return err
+		}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("Json Decode %s failed: %s", action, err)
+	}
+	return nil
+}
 
-		// check response for error
+// invoke sends an action to the controller, retrying it according to the
+// client's RetryPolicy. Mutating actions clear the response cache, and are
+// refused in ReadOnly and DryRun mode.
+func (c *Client) invoke(ctx context.Context, verb string, action string, params interface{}) (*http.Response, []byte, error) {
+	if err := c.checkMutation(verb, action, params); err != nil {
+		return nil, nil, err
+	}
+	var resp *http.Response
+	var body []byte
+	err := c.retry(ctx, action, func() error {
+		var err error
+		resp, body, err = c.send(ctx, verb, action, params)
+		return err
+	})
+	if c.Cache != nil && !c.isReadCall(action, params) {
+		c.Cache.Invalidate()
+	}
+	return resp, body, err
+}
+
+// send sends one action to the controller with the current CID. If the
+// controller reports the CID as expired it logs in again, unless a concurrent
+// call already has, and resends the action once with the new CID.
+func (c *Client) send(ctx context.Context, verb string, action string, params interface{}) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		values, err := c.actionValues(action, params)
 		if err != nil {
-			if loop > 2 {
-				return resp, nil, err
-			} else {
-				continue // try again
-			}
+			return nil, nil, err
 		}
-
-		log.Printf("[TRACE] %s %s: %d", verb, url, resp.StatusCode)
-		// decode the json response and look for errors to retry
-		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			body, _ = ioutil.ReadAll(resp.Body)
-			if err = json.Unmarshal(body, respdata); err != nil {
-				return resp, body, err
-			}
-			// Check if the CID has expired; if so re-login
-			if respdata.Reason == "CID is invalid or expired." && loop < 2 {
+		resp, body, data, err := c.roundTrip(ctx, verb, action, values)
+		if err != nil {
+			return resp, body, err
+		}
+		if !data.Return {
+			if data.Reason == cidExpiredReason && attempt == 0 {
 				log.Printf("[TRACE] re-login (expired CID)")
-				time.Sleep(500 * time.Millisecond)
-				if err = c.Login(); err != nil {
+				if err = sleepContext(ctx, 500*time.Millisecond); err != nil {
 					return resp, body, err
 				}
-				// update the CID value in the object passed
-				s := reflect.ValueOf(req).Elem()
-				f := s.FieldByName("CID")
-				if f.IsValid() && f.CanSet() {
-					f.SetString(c.CID)
+				if err = c.relogin(ctx, values.Get("CID")); err != nil {
+					return resp, body, err
 				}
-				// loop around again using new CID
-			} else if !respdata.Return {
-				return resp, body, errors.New(respdata.Reason)
-			} else {
-				return resp, body, nil
+				continue
 			}
-		} else {
-			return resp, body, errors.New("Status code")
+			return resp, body, newAPIError(action, resp.StatusCode, data.Reason)
 		}
+		return resp, body, nil
 	}
+}
 
-	return resp, body, err
+// roundTrip makes one HTTP request for action and decodes the controller's
+// return and reason, reporting it to the client's Observer.
+func (c *Client) roundTrip(ctx context.Context, verb string, action string, values url.Values) (resp *http.Response, body []byte, data *APIResp, err error) {
+	release, err := c.limiterFor(action, values).acquire(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer release()
+
+	req := c.beforeRequest(verb, action)
+	defer func() { c.afterResponse(req, resp, data, err) }()
+
+	if verb == "GET" || verb == "DELETE" {
+		resp, err = c.RequestWithContext(ctx, verb, c.baseURL+"?"+values.Encode(), nil)
+	} else {
+		resp, err = c.RequestWithContext(ctx, verb, c.baseURL, values)
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("HTTP %s %s failed: %w", verb, action, err)
+	}
+	body, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return resp, nil, nil, fmt.Errorf("HTTP %s %s failed: %w", verb, action, err)
+	}
+	log.Printf("[TRACE] %s %s: %d", verb, action, resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		return resp, body, nil, newAPIError(action, resp.StatusCode, "")
+	}
+
+	data = &APIResp{}
+	if err = json.Unmarshal(body, data); err != nil {
+		return resp, body, nil, fmt.Errorf("Json Decode %s failed: %s", action, err)
+	}
+	return resp, body, data, nil
+}
+
+// actionValues builds the form values for an action from the given params,
+// setting the action name and the client's current CID.
+func (c *Client) actionValues(action string, params interface{}) (url.Values, error) {
+	values := url.Values{}
+	switch p := params.(type) {
+	case nil:
+	case url.Values:
+		for k, v := range p {
+			values[k] = append([]string(nil), v...)
+		}
+	case map[string]string:
+		for k, v := range p {
+			values.Set(k, v)
+		}
+	default:
+		v, err := form.EncodeToValues(params)
+		if err != nil {
+			return nil, fmt.Errorf("Form Encode %s failed: %s", action, err)
+		}
+		values = v
+	}
+	values.Set("CID", c.GetCID())
+	if action != "" {
+		values.Set("action", action)
+	}
+	return values, nil
 }
 
 // Request makes an HTTP request with the given interface being encoded as
 // form data.
 func (c *Client) Request(verb string, path string, i interface{}) (*http.Response, error) {
-	log.Printf("[TRACE] %s %s", verb, path)
+	return c.RequestWithContext(context.Background(), verb, path, i)
+}
+
+// RequestWithContext is Request with a context that cancels the request.
+func (c *Client) RequestWithContext(ctx context.Context, verb string, path string, i interface{}) (*http.Response, error) {
+	log.Printf("[TRACE] %s %s", verb, c.redactURL(path))
 	var req *http.Request
 	var err error
 	if i != nil {
-		buf := new(bytes.Buffer)
-		if err = form.NewEncoder(buf).Encode(i); err != nil {
-			return nil, err
+		var body string
+		if v, ok := i.(url.Values); ok {
+			body = v.Encode()
+		} else {
+			buf := new(bytes.Buffer)
+			if err = form.NewEncoder(buf).Encode(i); err != nil {
+				return nil, err
+			}
+			body = buf.String()
 		}
-		body := buf.String()
-		log.Printf("[TRACE] %s %s Body: %s", verb, path, body)
+		log.Printf("[TRACE] %s %s Body: %s", verb, c.redactURL(path), c.redactQuery(body))
 		reader := strings.NewReader(body)
-		req, err = http.NewRequest(verb, path, reader)
+		req, err = http.NewRequestWithContext(ctx, verb, path, reader)
 		if err == nil {
 			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		}
 	} else {
-		req, err = http.NewRequest(verb, path, nil)
+		req, err = http.NewRequestWithContext(ctx, verb, path, nil)
 	}
 
 	if err != nil {
@@ -240,3 +476,35 @@ func (c *Client) Request(verb string, path string, i interface{}) (*http.Respons
 	}
 	return c.HTTPClient.Do(req)
 }
+
+// actionName returns the value of the Action field of a request struct, if
+// it has one.
+func actionName(req interface{}) string {
+	v := reflect.Indirect(reflect.ValueOf(req))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName("Action")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+// GetCID returns the session ID of the client's current login
+func (c *Client) GetCID() string {
+	c.cidMu.RLock()
+	defer c.cidMu.RUnlock()
+	return c.CID
+}
+
+func (c *Client) setCID(cid string) {
+	c.cidMu.Lock()
+	c.CID = cid
+	c.cidMu.Unlock()
+}
+
+// GetControllerIP returns the controller host the client talks to
+func (c *Client) GetControllerIP() string {
+	return c.ControllerIP
+}
@@ -0,0 +1,119 @@
+package goaviatrix
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestClient returns a Client logged in as "test-cid" to a controller
+// served by handler, and the server to close after the test.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	client, err := NewClientWithCID("admin", "password", "test-cid", server.URL, server.Client())
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewClientWithCID: %s", err)
+	}
+	return client, server
+}
+
+// writeJSON answers a controller action with v.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestClientReloginSingleFlight(t *testing.T) {
+	var logins int32
+	client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("action") == "login":
+			n := atomic.AddInt32(&logins, 1)
+			writeJSON(w, LoginResp{Return: true, CID: "cid-" + string(rune('0'+n))})
+		case r.Form.Get("CID") == "test-cid":
+			writeJSON(w, APIResp{Return: false, Reason: cidExpiredReason})
+		default:
+			writeJSON(w, APIResp{Return: true})
+		}
+	})
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Call("GET", "list_accounts", nil, nil); err != nil {
+				t.Errorf("Call: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if logins != 1 {
+		t.Errorf("logged in %d times, want 1", logins)
+	}
+	if cid := client.GetCID(); cid != "cid-1" {
+		t.Errorf("CID = %q, want %q", cid, "cid-1")
+	}
+}
+
+func TestControllerEndpoints(t *testing.T) {
+	cases := []struct {
+		in, api, backend string
+	}{
+		{"10.0.0.1", "https://10.0.0.1/v1/api", "https://10.0.0.1/v1/backend1"},
+		{"ctrl.example.com:8443", "https://ctrl.example.com:8443/v1/api", "https://ctrl.example.com:8443/v1/backend1"},
+		{"https://ctrl.example.com", "https://ctrl.example.com/v1/api", "https://ctrl.example.com/v1/backend1"},
+		{"http://127.0.0.1:8080/", "http://127.0.0.1:8080/v1/api", "http://127.0.0.1:8080/v1/backend1"},
+		{"https://proxy.example.com/aviatrix/", "https://proxy.example.com/aviatrix/v1/api", "https://proxy.example.com/aviatrix/v1/backend1"},
+		{"[fd00::1]:443", "https://[fd00::1]:443/v1/api", "https://[fd00::1]:443/v1/backend1"},
+	}
+	for _, tc := range cases {
+		api, backend, err := controllerEndpoints(tc.in)
+		if err != nil {
+			t.Errorf("controllerEndpoints(%q): %s", tc.in, err)
+			continue
+		}
+		if api != tc.api || backend != tc.backend {
+			t.Errorf("controllerEndpoints(%q) = %q, %q, want %q, %q", tc.in, api, backend, tc.api, tc.backend)
+		}
+	}
+
+	for _, in := range []string{
+		"ftp://ctrl.example.com",
+		"https://",
+		"https://ctrl.example.com/?x=1",
+		"https://ctrl.example.com/#top",
+		"https://ctrl example.com",
+	} {
+		if api, _, err := controllerEndpoints(in); err == nil {
+			t.Errorf("controllerEndpoints(%q) = %q, want an error", in, api)
+		}
+	}
+}
+
+func TestClientUsesControllerBasePath(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		writeJSON(w, APIResp{Return: true})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithCID("", "", "test-cid", server.URL+"/aviatrix", server.Client())
+	if err != nil {
+		t.Fatalf("NewClientWithCID: %s", err)
+	}
+	if err := client.Call("GET", "list_accounts", nil, nil); err != nil {
+		t.Fatalf("Call: %s", err)
+	}
+	if path != "/aviatrix/v1/api" {
+		t.Errorf("request sent to %s, want /aviatrix/v1/api", path)
+	}
+}
@@ -0,0 +1,36 @@
+package goaviatrix
+
+import (
+	"strconv"
+)
+
+// Cloud types as the controller numbers them
+const (
+	AWS      = 1
+	GCP      = 4
+	ARM      = 8
+	OCI      = 16
+	AZUREGOV = 32
+	AWSGOV   = 256
+)
+
+// CloudTypes lists every cloud type the controller knows
+var CloudTypes = []int{AWS, GCP, ARM, OCI, AZUREGOV, AWSGOV}
+
+var cloudTypeNames = map[int]string{
+	AWS:      "AWS",
+	GCP:      "GCP",
+	ARM:      "ARM",
+	OCI:      "OCI",
+	AZUREGOV: "AZURE GOV",
+	AWSGOV:   "AWS GOV",
+}
+
+// CloudTypeName returns the name of a cloud type, or its number if it is
+// unknown.
+func CloudTypeName(cloudType int) string {
+	if name, ok := cloudTypeNames[cloudType]; ok {
+		return name
+	}
+	return strconv.Itoa(cloudType)
+}
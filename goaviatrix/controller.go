@@ -1,11 +1,10 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
+	"context"
 	"log"
-	"net/url"
+	"strings"
+	"time"
 )
 
 // Controller Http Access enabled get result struct
@@ -28,134 +27,116 @@ type SecurityGroupInfo struct {
 }
 
 func (c *Client) EnableHttpAccess() error {
-	url := "?CID=%s&action=config_http_access&operation=enable"
-	path := c.baseURL + fmt.Sprintf(url, c.CID)
-	resp, err := c.Get(path, nil)
-	if err != nil {
-		return err
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
+	return c.EnableHttpAccessWithContext(context.Background())
+}
+
+func (c *Client) EnableHttpAccessWithContext(ctx context.Context) error {
+	form := map[string]string{
+		"operation": "enable",
 	}
-	if !data.Return {
-		log.Printf("[ERROR] Error invoking controller %s", data.Reason)
-		return errors.New(data.Reason)
+	err := c.CallWithContext(ctx, "GET", "config_http_access", form, nil)
+	if err != nil {
+		log.Printf("[ERROR] Error invoking controller %s", err)
 	}
-	return nil
+	return err
 }
 
 func (c *Client) DisableHttpAccess() error {
-	url := "?CID=%s&action=config_http_access&operation=disable"
-	path := c.baseURL + fmt.Sprintf(url, c.CID)
-	resp, err := c.Get(path, nil)
-	if err != nil {
-		return err
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
+	return c.DisableHttpAccessWithContext(context.Background())
+}
+
+func (c *Client) DisableHttpAccessWithContext(ctx context.Context) error {
+	form := map[string]string{
+		"operation": "disable",
 	}
-	if !data.Return {
-		log.Printf("[ERROR] Error invoking controller %s", data.Reason)
-		return errors.New(data.Reason)
+	err := c.CallWithContext(ctx, "GET", "config_http_access", form, nil)
+	if err != nil {
+		log.Printf("[ERROR] Error invoking controller %s", err)
 	}
-	return nil
+	return err
 }
 
 func (c *Client) GetHttpAccessEnabled() (string, error) {
-	url := "?CID=%s&action=config_http_access&operation=get"
-	path := c.baseURL + fmt.Sprintf(url, c.CID)
-	resp, err := c.Get(path, nil)
-	if err != nil {
-		return "", err
+	return c.GetHttpAccessEnabledWithContext(context.Background())
+}
+
+func (c *Client) GetHttpAccessEnabledWithContext(ctx context.Context) (string, error) {
+	form := map[string]string{
+		"operation": "get",
 	}
 	var data ControllerHttpAccessResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	if err := c.CallWithContext(ctx, "GET", "config_http_access", form, &data); err != nil {
+		log.Printf("[ERROR] Error invoking controller %s", err)
 		return "", err
 	}
-	if !data.Return {
-		log.Printf("[ERROR] Error invoking controller %s", data.Reason)
-		return "", errors.New(data.Reason)
-	}
 	result := data.Result
 	return result, nil
 }
 
-func (c *Client) EnableExceptionRule() error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for enable_fqdn_exception_rule " + err.Error())
-	}
-	enableFqdnExceptionRule := url.Values{}
-	enableFqdnExceptionRule.Add("CID", c.CID)
-	enableFqdnExceptionRule.Add("action", "enable_fqdn_exception_rule")
-	Url.RawQuery = enableFqdnExceptionRule.Encode()
-	resp, err := c.Get(Url.String(), nil)
+func (c *Client) WaitForHttpAccess(enabled bool, timeout time.Duration) error {
+	return c.WaitForHttpAccessWithContext(context.Background(), enabled, timeout)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_fqdn_exception_rule failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode detach_fqdn_filter_tag_from_gw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API detach_fqdn_filter_tag_from_gw Get failed: " + data.Reason)
-	}
+// WaitForHttpAccessWithContext waits until the controller reports HTTP
+// access as enabled or disabled. The controller restarts its web server to
+// apply the change, so a request it fails to answer is retried.
+func (c *Client) WaitForHttpAccessWithContext(ctx context.Context, enabled bool, timeout time.Duration) error {
+	target := "False"
+	if enabled {
+		target = "True"
+	}
+	w := &Waiter{
+		Description: "controller http access",
+		Target:      []string{target},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			result, err := c.GetHttpAccessEnabledWithContext(ctx)
+			if err != nil && !isRejection(err) && ctx.Err() == nil {
+				log.Printf("[DEBUG] Controller is not answering while http access changes: %s", err)
+				return "unavailable", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			// The flag is reported as a Python style tuple, e.g. "(True)".
+			if strings.Contains(result, "True") {
+				return "True", nil
+			}
+			return "False", nil
+		},
+	}
+	_, err := w.Wait(ctx)
+	return err
+}
 
-	return nil
+func (c *Client) EnableExceptionRule() error {
+	return c.EnableExceptionRuleWithContext(context.Background())
 }
 
-func (c *Client) DisableExceptionRule() error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for disable_fqdn_exception_rule " + err.Error())
-	}
-	disableFqdnExceptionRule := url.Values{}
-	disableFqdnExceptionRule.Add("CID", c.CID)
-	disableFqdnExceptionRule.Add("action", "disable_fqdn_exception_rule")
-	Url.RawQuery = disableFqdnExceptionRule.Encode()
-	resp, err := c.Get(Url.String(), nil)
+func (c *Client) EnableExceptionRuleWithContext(ctx context.Context) error {
+	return c.CallWithContext(ctx, "GET", "enable_fqdn_exception_rule", nil, nil)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_fqdn_exception_rule failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_fqdn_exception_rule failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_fqdn_exception_rule Get failed: " + data.Reason)
-	}
+func (c *Client) DisableExceptionRule() error {
+	return c.DisableExceptionRuleWithContext(context.Background())
+}
 
-	return nil
+func (c *Client) DisableExceptionRuleWithContext(ctx context.Context) error {
+	return c.CallWithContext(ctx, "GET", "disable_fqdn_exception_rule", nil, nil)
 }
 
 func (c *Client) GetExceptionRuleStatus() (bool, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return false, errors.New("url Parsing failed for get_fqdn_exception_rule_status " + err.Error())
-	}
-	getFqdnExceptionRuleStatus := url.Values{}
-	getFqdnExceptionRuleStatus.Add("CID", c.CID)
-	getFqdnExceptionRuleStatus.Add("action", "get_fqdn_exception_rule_status")
-	Url.RawQuery = getFqdnExceptionRuleStatus.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetExceptionRuleStatusWithContext(context.Background())
+}
 
-	if err != nil {
-		return false, errors.New("HTTP Get get_fqdn_exception_rule_status failed: " + err.Error())
-	}
+func (c *Client) GetExceptionRuleStatusWithContext(ctx context.Context) (bool, error) {
 	data := GetFqdnExceptionRuleResp{
 		Return:  false,
 		Results: "",
 		Reason:  "",
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return false, errors.New("Json Decode get_fqdn_exception_rule_status failed: " + err.Error())
-	}
-	if !data.Return {
-		return false, errors.New("Rest API get_fqdn_exception_rule_status Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "get_fqdn_exception_rule_status", nil, &data); err != nil {
+		return false, err
 	}
 
 	if data.Results == "disabled" {
@@ -165,76 +146,32 @@ func (c *Client) GetExceptionRuleStatus() (bool, error) {
 }
 
 func (c *Client) EnableSecurityGroupManagement(account string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for enable_controller_security_group_management " + err.Error())
-	}
-	enableSecurityGroupManagement := url.Values{}
-	enableSecurityGroupManagement.Add("CID", c.CID)
-	enableSecurityGroupManagement.Add("action", "enable_controller_security_group_management")
-	enableSecurityGroupManagement.Add("access_account_name", account)
-	Url.RawQuery = enableSecurityGroupManagement.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableSecurityGroupManagementWithContext(context.Background(), account)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_controller_security_group_management failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_controller_security_group_management failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_controller_security_group_management Get failed: " + data.Reason)
+func (c *Client) EnableSecurityGroupManagementWithContext(ctx context.Context, account string) error {
+	form := map[string]string{
+		"access_account_name": account,
 	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "enable_controller_security_group_management", form, nil)
 }
 
 func (c *Client) DisableSecurityGroupManagement() error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for disable_controller_security_group_management " + err.Error())
-	}
-	disableSecurityGroupManagement := url.Values{}
-	disableSecurityGroupManagement.Add("CID", c.CID)
-	disableSecurityGroupManagement.Add("action", "disable_controller_security_group_management")
-	Url.RawQuery = disableSecurityGroupManagement.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get disable_controller_security_group_management failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_controller_security_group_management failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_controller_security_group_management Get failed: " + data.Reason)
-	}
+	return c.DisableSecurityGroupManagementWithContext(context.Background())
+}
 
-	return nil
+func (c *Client) DisableSecurityGroupManagementWithContext(ctx context.Context) error {
+	return c.CallWithContext(ctx, "GET", "disable_controller_security_group_management", nil, nil)
 }
 
 func (c *Client) GetSecurityGroupManagementStatus() (*SecurityGroupInfo, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New("url Parsing failed for get_controller_security_group_management_status " + err.Error())
-	}
-	getSecurityGroupManagementStatus := url.Values{}
-	getSecurityGroupManagementStatus.Add("CID", c.CID)
-	getSecurityGroupManagementStatus.Add("action", "get_controller_security_group_management_status")
-	Url.RawQuery = getSecurityGroupManagementStatus.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetSecurityGroupManagementStatusWithContext(context.Background())
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get get_controller_security_group_management_status failed: " + err.Error())
-	}
+func (c *Client) GetSecurityGroupManagementStatusWithContext(ctx context.Context) (*SecurityGroupInfo, error) {
 	var data GetSecurityGroupManagementResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode get_controller_security_group_management_status failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API get_controller_security_group_management_status Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "get_controller_security_group_management_status", nil, &data); err != nil {
+		return nil, err
 	}
 
 	return &data.Results, nil
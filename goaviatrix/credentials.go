@@ -0,0 +1,142 @@
+package goaviatrix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultProfile is the profile read from the shared credentials file when
+// none is named.
+const DefaultProfile = "default"
+
+// Credentials are the settings used to log in to a controller, or the CID
+// of a session that is already logged in. ControllerIP may also hold a
+// controller URL.
+type Credentials struct {
+	ControllerIP string `json:"controller_ip"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	CID          string `json:"cid"`
+	// CredentialProcess is a command printing Credentials as JSON. It is
+	// only read from profiles.
+	CredentialProcess string `json:"-"`
+}
+
+// Merge fills the fields of c that are empty from other.
+func (c *Credentials) Merge(other *Credentials) {
+	if other == nil {
+		return
+	}
+	for _, f := range []struct{ dst, src *string }{
+		{&c.ControllerIP, &other.ControllerIP},
+		{&c.Username, &other.Username},
+		{&c.Password, &other.Password},
+		{&c.CID, &other.CID},
+		{&c.CredentialProcess, &other.CredentialProcess},
+	} {
+		if *f.dst == "" {
+			*f.dst = *f.src
+		}
+	}
+}
+
+// DefaultCredentialsFile returns ~/.aviatrix/credentials.
+func DefaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".aviatrix", "credentials"), nil
+}
+
+// LoadProfile reads a named profile from a shared credentials file. The
+// file has one [profile] section per profile with key = value lines for
+// controller_ip (or controller_url), username, password, cid and
+// credential_process. Lines starting with # or ; are comments.
+func LoadProfile(path string, profile string) (*Credentials, error) {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %s", err)
+	}
+	defer f.Close()
+
+	var creds *Credentials
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == profile {
+				creds = &Credentials{}
+			}
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, n)
+		}
+		if section != profile {
+			continue
+		}
+		key, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		switch key {
+		case "controller_ip", "controller_url":
+			creds.ControllerIP = value
+		case "username":
+			creds.Username = value
+		case "password":
+			creds.Password = value
+		case "cid":
+			creds.CID = value
+		case "credential_process":
+			creds.CredentialProcess = value
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown key %q", path, n, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %s", err)
+	}
+	if creds == nil {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+	return creds, nil
+}
+
+// RunCredentialProcess runs command through the shell and decodes the
+// Credentials it prints on stdout as JSON, for example
+// {"username": "admin", "password": "..."} or {"cid": "..."}.
+func RunCredentialProcess(command string) (*Credentials, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// The command's output is not included since it may hold secrets.
+		return nil, fmt.Errorf("credential_process failed: %s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	creds := &Credentials{}
+	if err := json.Unmarshal(stdout.Bytes(), creds); err != nil {
+		return nil, fmt.Errorf("credential_process printed invalid JSON: %s", err)
+	}
+	return creds, nil
+}
@@ -0,0 +1,112 @@
+package goaviatrix
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testCredentialsFile = `# shared Aviatrix credentials
+[default]
+controller_ip = 10.0.0.1
+username = admin
+password = pa=ss
+
+; a profile logged in already
+[prod]
+controller_url = https://ctrl.example.com:8443/aviatrix
+cid = abc123
+
+[vault]
+credential_process = vault-aviatrix --profile vault
+`
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := writeCredentialsFile(t, testCredentialsFile)
+	cases := []struct {
+		profile string
+		want    Credentials
+	}{
+		{"", Credentials{ControllerIP: "10.0.0.1", Username: "admin", Password: "pa=ss"}},
+		{"default", Credentials{ControllerIP: "10.0.0.1", Username: "admin", Password: "pa=ss"}},
+		{"prod", Credentials{ControllerIP: "https://ctrl.example.com:8443/aviatrix", CID: "abc123"}},
+		{"vault", Credentials{CredentialProcess: "vault-aviatrix --profile vault"}},
+	}
+	for _, tc := range cases {
+		got, err := LoadProfile(path, tc.profile)
+		if err != nil {
+			t.Errorf("LoadProfile(%q): %s", tc.profile, err)
+			continue
+		}
+		if *got != tc.want {
+			t.Errorf("LoadProfile(%q) = %+v, want %+v", tc.profile, *got, tc.want)
+		}
+	}
+}
+
+func TestLoadProfileErrors(t *testing.T) {
+	cases := []struct {
+		name, content, profile, want string
+	}{
+		{"missing profile", testCredentialsFile, "staging", `profile "staging" not found`},
+		{"unknown key", "[default]\nregion = us-east-1\n", "", `:2: unknown key "region"`},
+		{"no equals sign", "[default]\nusername admin\n", "", ":2: expected key = value"},
+		// Malformed lines are reported even in other profiles.
+		{"bad line elsewhere", "[default]\nusername = a\n[other]\noops\n", "", ":4: expected key = value"},
+	}
+	for _, tc := range cases {
+		path := writeCredentialsFile(t, tc.content)
+		_, err := LoadProfile(path, tc.profile)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+
+	if _, err := LoadProfile(filepath.Join(t.TempDir(), "missing"), ""); err == nil {
+		t.Errorf("LoadProfile of a missing file succeeded")
+	}
+}
+
+func TestCredentialsMerge(t *testing.T) {
+	creds := &Credentials{Username: "explicit"}
+	creds.Merge(&Credentials{ControllerIP: "10.0.0.1", Username: "profile", Password: "secret"})
+	creds.Merge(nil)
+	want := Credentials{ControllerIP: "10.0.0.1", Username: "explicit", Password: "secret"}
+	if *creds != want {
+		t.Errorf("merged %+v, want %+v", *creds, want)
+	}
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+	creds, err := RunCredentialProcess(`echo '{"controller_ip": "10.0.0.1", "username": "admin", "password": "secret"}'`)
+	if err != nil {
+		t.Fatalf("RunCredentialProcess: %s", err)
+	}
+	if want := (Credentials{ControllerIP: "10.0.0.1", Username: "admin", Password: "secret"}); *creds != want {
+		t.Errorf("credentials = %+v, want %+v", *creds, want)
+	}
+
+	_, err = RunCredentialProcess(`echo "password: secret"; echo "no vault token" >&2; exit 3`)
+	if err == nil || !strings.Contains(err.Error(), "no vault token") || strings.Contains(err.Error(), "secret") {
+		t.Errorf("failing process err = %v, want its stderr and not its stdout", err)
+	}
+
+	_, err = RunCredentialProcess(`echo "password: secret"`)
+	if err == nil || !strings.Contains(err.Error(), "invalid JSON") || strings.Contains(err.Error(), "secret") {
+		t.Errorf("non-JSON output err = %v, want invalid JSON without the output", err)
+	}
+}
@@ -0,0 +1,178 @@
+package goaviatrix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorKind classifies why the controller rejected an action
+type ErrorKind int
+
+const (
+	ErrorUnknown ErrorKind = iota
+	ErrorNotFound
+	ErrorConflict
+	ErrorTransient
+	ErrorAuth
+	ErrorValidation
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorNotFound:
+		return "NotFound"
+	case ErrorConflict:
+		return "Conflict"
+	case ErrorTransient:
+		return "Transient"
+	case ErrorAuth:
+		return "Auth"
+	case ErrorValidation:
+		return "Validation"
+	}
+	return "Unknown"
+}
+
+// APIError is returned by Client methods when the controller answers an
+// action with a non-200 status or with "return": false
+type APIError struct {
+	Action     string
+	StatusCode int
+	Reason     string
+	Kind       ErrorKind
+}
+
+func (e *APIError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("Rest API %s failed: status code %d", e.Action, e.StatusCode)
+	}
+	return fmt.Sprintf("Rest API %s failed: %s", e.Action, e.Reason)
+}
+
+// Is lets errors.Is(err, ErrNotFound) match controller "does not exist"
+// style rejections as well as the sentinel itself.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.Kind == ErrorNotFound
+}
+
+// newAPIError builds an APIError for the given action, classifying it from
+// the HTTP status and the controller reason.
+func newAPIError(action string, statusCode int, reason string) *APIError {
+	return &APIError{
+		Action:     action,
+		StatusCode: statusCode,
+		Reason:     reason,
+		Kind:       classifyError(statusCode, reason),
+	}
+}
+
+// reasonKinds maps fragments of controller reasons to an error kind. Order
+// matters: "CID is invalid" must be seen as Auth before the generic
+// "invalid" is seen as Validation.
+var reasonKinds = []struct {
+	fragment string
+	kind     ErrorKind
+}{
+	{"cid is invalid or expired", ErrorAuth},
+	{"invalid username or password", ErrorAuth},
+	{"login failed", ErrorAuth},
+	{"permission denied", ErrorAuth},
+	{"not authorized", ErrorAuth},
+	{"in progress", ErrorTransient},
+	{"busy", ErrorTransient},
+	{"try again", ErrorTransient},
+	{"timed out", ErrorTransient},
+	{"temporarily", ErrorTransient},
+	{"does not exist", ErrorNotFound},
+	{"doesn't exist", ErrorNotFound},
+	{"not found", ErrorNotFound},
+	{"not exist", ErrorNotFound},
+	{"already exist", ErrorConflict},
+	{"already attached", ErrorConflict},
+	{"already enabled", ErrorConflict},
+	{"already in use", ErrorConflict},
+	{"is in use", ErrorConflict},
+	{"duplicate", ErrorConflict},
+	{"invalid", ErrorValidation},
+	{"not valid", ErrorValidation},
+	{"must be", ErrorValidation},
+	{"missing", ErrorValidation},
+	{"required", ErrorValidation},
+	{"not supported", ErrorValidation},
+}
+
+func classifyError(statusCode int, reason string) ErrorKind {
+	lower := strings.ToLower(reason)
+	for _, rk := range reasonKinds {
+		if strings.Contains(lower, rk.fragment) {
+			return rk.kind
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrorAuth
+	case statusCode == http.StatusNotFound:
+		return ErrorNotFound
+	case statusCode == http.StatusConflict:
+		return ErrorConflict
+	case statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity:
+		return ErrorValidation
+	case statusCode == http.StatusTooManyRequests || statusCode >= 500:
+		return ErrorTransient
+	}
+	return ErrorUnknown
+}
+
+// errorKind returns the kind of an APIError anywhere in err's chain
+func errorKind(err error) ErrorKind {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind
+	}
+	return ErrorUnknown
+}
+
+// IsNotFound reports whether err is ErrNotFound or a controller rejection
+// saying the object does not exist.
+func IsNotFound(err error) bool {
+	return err == ErrNotFound || errorKind(err) == ErrorNotFound
+}
+
+// IsConflict reports whether the controller rejected an action because the
+// object already exists or is in use.
+func IsConflict(err error) bool {
+	return errorKind(err) == ErrorConflict
+}
+
+// IsTransient reports whether the controller was busy and the action may
+// succeed if tried again later.
+func IsTransient(err error) bool {
+	return errorKind(err) == ErrorTransient
+}
+
+// IsAuth reports whether the controller rejected the credentials or CID.
+func IsAuth(err error) bool {
+	return errorKind(err) == ErrorAuth
+}
+
+// IsValidation reports whether the controller rejected the parameters.
+func IsValidation(err error) bool {
+	return errorKind(err) == ErrorValidation
+}
+
+// reasonContains reports whether err is a controller rejection whose reason
+// contains substr.
+func reasonContains(err error, substr string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && strings.Contains(apiErr.Reason, substr)
+}
+
+// isRejection reports whether err came from the controller answering the
+// action, as opposed to a transport or decoding failure.
+func isRejection(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr)
+}
@@ -0,0 +1,92 @@
+package goaviatrix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		statusCode int
+		reason     string
+		want       ErrorKind
+	}{
+		{http.StatusOK, "CID is invalid or expired.", ErrorAuth},
+		{http.StatusOK, "Invalid username or password", ErrorAuth},
+		{http.StatusOK, "Permission denied for account tfa-test", ErrorAuth},
+		{http.StatusOK, "Active upgrade in progress.", ErrorTransient},
+		{http.StatusOK, "Controller is busy, please try again later", ErrorTransient},
+		{http.StatusOK, "Gateway tfg-test does not exist", ErrorNotFound},
+		{http.StatusOK, "VPC vpc-0123 doesn't exist", ErrorNotFound},
+		{http.StatusOK, "Endpoint not found", ErrorNotFound},
+		{http.StatusOK, "Account tfa-test already exists", ErrorConflict},
+		{http.StatusOK, "Spoke is already attached to transit", ErrorConflict},
+		{http.StatusOK, "Subnet is in use by gateway tfg-test", ErrorConflict},
+		{http.StatusOK, "Invalid CIDR 10.0.0.0/33", ErrorValidation},
+		{http.StatusOK, "gw_size is required", ErrorValidation},
+		{http.StatusOK, "Insane mode is not supported in this region", ErrorValidation},
+		{http.StatusOK, "Something went wrong", ErrorUnknown},
+		{http.StatusOK, "", ErrorUnknown},
+
+		// Without a known reason the status decides.
+		{http.StatusUnauthorized, "", ErrorAuth},
+		{http.StatusForbidden, "", ErrorAuth},
+		{http.StatusNotFound, "", ErrorNotFound},
+		{http.StatusConflict, "", ErrorConflict},
+		{http.StatusBadRequest, "", ErrorValidation},
+		{http.StatusUnprocessableEntity, "", ErrorValidation},
+		{http.StatusTooManyRequests, "", ErrorTransient},
+		{http.StatusBadGateway, "", ErrorTransient},
+		{http.StatusMovedPermanently, "", ErrorUnknown},
+
+		// A known reason wins over the status.
+		{http.StatusInternalServerError, "Gateway tfg-test does not exist", ErrorNotFound},
+		{http.StatusNotFound, "Controller is busy", ErrorTransient},
+	}
+	for _, tc := range cases {
+		if got := classifyError(tc.statusCode, tc.reason); got != tc.want {
+			t.Errorf("classifyError(%d, %q) = %s, want %s", tc.statusCode, tc.reason, got, tc.want)
+		}
+	}
+}
+
+// TestReasonKindsOrder checks that no fragment is shadowed by an earlier
+// fragment of another kind.
+func TestReasonKindsOrder(t *testing.T) {
+	for _, rk := range reasonKinds {
+		if got := classifyError(http.StatusOK, strings.ToUpper(rk.fragment)); got != rk.kind {
+			t.Errorf("reason %q is classified %s, want %s", rk.fragment, got, rk.kind)
+		}
+	}
+}
+
+func TestErrorKindPredicates(t *testing.T) {
+	notFound := newAPIError("get_gateway_info", http.StatusOK, "Gateway tfg-test does not exist")
+	wrapped := fmt.Errorf("failed to read gateway: %w", notFound)
+	conflict := newAPIError("create_gateway", http.StatusOK, "Gateway tfg-test already exists")
+
+	if !IsNotFound(notFound) || !IsNotFound(wrapped) || !IsNotFound(ErrNotFound) {
+		t.Errorf("IsNotFound missed a not found error")
+	}
+	if !errors.Is(wrapped, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false, want true", wrapped)
+	}
+	if IsNotFound(conflict) || errors.Is(conflict, ErrNotFound) {
+		t.Errorf("a conflict is reported as not found")
+	}
+	if !IsConflict(conflict) || IsConflict(notFound) {
+		t.Errorf("IsConflict(%v) or IsConflict(%v) is wrong", conflict, notFound)
+	}
+	if IsTransient(errors.New("Controller is busy")) {
+		t.Errorf("an error that is not an APIError is classified")
+	}
+	if !IsAuth(newAPIError("login", http.StatusForbidden, "")) {
+		t.Errorf("IsAuth missed a 403")
+	}
+	if !IsValidation(newAPIError("create_gateway", http.StatusOK, "Invalid gw_size")) {
+		t.Errorf("IsValidation missed an invalid parameter")
+	}
+}
@@ -0,0 +1,238 @@
+package fake
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// uploadDir is where upload_file stores files on the controller
+const uploadDir = "/var/www/php/tmp/"
+
+type account struct {
+	goaviatrix.Account
+}
+
+type accountUser struct {
+	goaviatrix.AccountUser
+}
+
+func init() {
+	register(map[string]action{
+		"setup_account_profile":  setupAccountProfile,
+		"list_accounts":          listAccounts,
+		"edit_account_profile":   editAccountProfile,
+		"delete_account_profile": deleteAccountProfile,
+		"upload_file":            uploadFile,
+		"add_account_user":       addAccountUser,
+		"list_account_users":     listAccountUsers,
+		"edit_account_user":      editAccountUser,
+		"delete_account_user":    deleteAccountUser,
+	})
+}
+
+// requireAccount checks that a cloud account named name exists
+func (c *Controller) requireAccount(name string) error {
+	if _, ok := c.accounts[name]; !ok {
+		return fmt.Errorf("account %s does not exist", name)
+	}
+	return nil
+}
+
+func setupAccountProfile(c *Controller, p params) (interface{}, error) {
+	if err := p.require("account_name", "cloud_type"); err != nil {
+		return nil, err
+	}
+	name := p.get("account_name")
+	if _, ok := c.accounts[name]; ok {
+		return nil, fmt.Errorf("account %s already exists", name)
+	}
+	a := &account{}
+	if err := p.decode(&a.Account); err != nil {
+		return nil, fmt.Errorf("invalid account parameters: %s", err)
+	}
+	switch a.CloudType {
+	case 1:
+		if a.AwsAccountNumber == "" {
+			return nil, fmt.Errorf("missing required parameter: aws_account_number")
+		}
+	case 4:
+		if a.GcloudProjectCredentialsFilepathController != "" {
+			if _, ok := c.files[a.GcloudProjectCredentialsFilepathController]; !ok {
+				return nil, fmt.Errorf("credentials file %s does not exist", a.GcloudProjectCredentialsFilepathController)
+			}
+		}
+	case 8:
+		if a.ArmSubscriptionId == "" {
+			return nil, fmt.Errorf("missing required parameter: arm_subscription_id")
+		}
+	}
+	a.forgetSecrets()
+	c.accounts[name] = a
+	return fmt.Sprintf("An email with instructions has been sent to %s", c.Username), nil
+}
+
+// forgetSecrets clears the fields the controller never reports back
+func (a *account) forgetSecrets() {
+	a.CID = ""
+	a.Action = ""
+	a.AwsSecretKey = ""
+	a.AwsgovSecretKey = ""
+	a.AwschinaSecretKey = ""
+	a.ArmApplicationClientSecret = ""
+	a.ArmChinaApplicationClientSecret = ""
+	a.GcloudProjectCredentialsContents = ""
+	a.GcloudProjectCredentialsFilename = ""
+	a.GcloudProjectCredentialsFilepathLocal = ""
+	a.GcloudProjectCredentialsFilepathController = ""
+}
+
+func listAccounts(c *Controller, p params) (interface{}, error) {
+	list := make([]goaviatrix.Account, 0, len(c.accounts))
+	for _, name := range sortedKeys(c.accounts) {
+		list = append(list, c.accounts[name].Account)
+	}
+	return goaviatrix.AccountResult{AccountList: list}, nil
+}
+
+func editAccountProfile(c *Controller, p params) (interface{}, error) {
+	name := p.get("account_name")
+	a, ok := c.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("account %s does not exist", name)
+	}
+	updated := &account{}
+	if err := p.decode(&updated.Account); err != nil {
+		return nil, fmt.Errorf("invalid account parameters: %s", err)
+	}
+	if updated.CloudType != 0 && updated.CloudType != a.CloudType {
+		return nil, fmt.Errorf("cloud_type of account %s can not be changed", name)
+	}
+	updated.CloudType = a.CloudType
+	updated.forgetSecrets()
+	c.accounts[name] = updated
+	return fmt.Sprintf("Account %s has been updated", name), nil
+}
+
+func deleteAccountProfile(c *Controller, p params) (interface{}, error) {
+	name := p.get("account_name")
+	if _, ok := c.accounts[name]; !ok {
+		return nil, fmt.Errorf("account %s does not exist", name)
+	}
+	var users []string
+	for _, gw := range c.gateways {
+		if gw.AccountName == name {
+			users = append(users, "gateway "+gw.GwName)
+		}
+	}
+	for _, v := range c.vpcs {
+		if v.AccountName == name {
+			users = append(users, "vpc "+v.Name)
+		}
+	}
+	for _, t := range c.tgws {
+		if t.accountName == name {
+			users = append(users, "tgw "+t.name)
+		}
+	}
+	if len(users) != 0 {
+		return nil, inUse("account", name, users)
+	}
+	delete(c.accounts, name)
+	if c.system.securityGroupAccount == name {
+		c.system.securityGroupState = "Disabled"
+		c.system.securityGroupAccount = ""
+	}
+	return fmt.Sprintf("Account %s has been deleted", name), nil
+}
+
+func uploadFile(c *Controller, p params) (interface{}, error) {
+	if err := p.require("filename"); err != nil {
+		return nil, err
+	}
+	path := uploadDir + p.get("filename")
+	c.files[path] = p.get("contents")
+	return path, nil
+}
+
+func addAccountUser(c *Controller, p params) (interface{}, error) {
+	if err := p.require("username", "account_name", "email", "password"); err != nil {
+		return nil, err
+	}
+	name := p.get("username")
+	if _, ok := c.accountUsers[name]; ok {
+		return nil, fmt.Errorf("user %s already exists", name)
+	}
+	if err := c.requireUserAccount(p.get("account_name")); err != nil {
+		return nil, err
+	}
+	c.accountUsers[name] = &accountUser{goaviatrix.AccountUser{
+		UserName:    name,
+		AccountName: p.get("account_name"),
+		Email:       p.get("email"),
+	}}
+	return fmt.Sprintf("User %s has been added", name), nil
+}
+
+// requireUserAccount checks the account a controller user is assigned to.
+// "admin" is the built-in account every controller has.
+func (c *Controller) requireUserAccount(name string) error {
+	if name == "admin" {
+		return nil
+	}
+	return c.requireAccount(name)
+}
+
+func listAccountUsers(c *Controller, p params) (interface{}, error) {
+	list := make([]goaviatrix.AccountUser, 0, len(c.accountUsers))
+	for _, name := range sortedKeys(c.accountUsers) {
+		list = append(list, c.accountUsers[name].AccountUser)
+	}
+	return list, nil
+}
+
+func editAccountUser(c *Controller, p params) (interface{}, error) {
+	name := p.get("username")
+	u, ok := c.accountUsers[name]
+	if !ok {
+		return nil, fmt.Errorf("user %s does not exist", name)
+	}
+	switch what := p.get("what"); what {
+	case "email":
+		if err := p.require("email"); err != nil {
+			return nil, err
+		}
+		u.Email = p.get("email")
+	case "account_name":
+		if err := c.requireUserAccount(p.get("account_name")); err != nil {
+			return nil, err
+		}
+		u.AccountName = p.get("account_name")
+	case "password":
+		if err := p.require("old_password", "new_password"); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid value for what: %q", what)
+	}
+	return fmt.Sprintf("User %s has been updated", name), nil
+}
+
+func deleteAccountUser(c *Controller, p params) (interface{}, error) {
+	name := p.get("username")
+	if _, ok := c.accountUsers[name]; !ok {
+		return nil, fmt.Errorf("user %s does not exist", name)
+	}
+	delete(c.accountUsers, name)
+	return fmt.Sprintf("User %s has been deleted", name), nil
+}
+
+// cloudType parses the cloud_type parameter
+func cloudType(p params) (int, error) {
+	ct, err := strconv.Atoi(p.get("cloud_type"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid cloud_type: %q", p.get("cloud_type"))
+	}
+	return ct, nil
+}
@@ -0,0 +1,333 @@
+// Package fake runs an in-memory Aviatrix controller over HTTPS for tests
+// that must not reach a real controller.
+//
+// The Controller implements the /v1/api actions used by goaviatrix with
+// enough fidelity for the provider's resources to create, read, update,
+// import and delete their objects. It keeps the controller's basic
+// invariants: names are unique, referenced accounts and gateways must exist
+// and objects that are still in use, such as a transit gateway with attached
+// spokes, cannot be deleted.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ajg/form"
+)
+
+const cidExpiredReason = "CID is invalid or expired."
+
+// action handles one controller action. It runs with the controller locked
+// and returns the value reported as "results", or an error whose text is
+// reported as the "reason" of a failed action.
+type action func(c *Controller, p params) (interface{}, error)
+
+// actions maps action names to their handlers. Each file registers the
+// actions of its domain.
+var actions = map[string]action{}
+
+func register(m map[string]action) {
+	for name, a := range m {
+		actions[name] = a
+	}
+}
+
+// Controller is a fake Aviatrix controller. Create one with NewController.
+type Controller struct {
+	// Username and Password are the credentials login accepts.
+	Username string
+	Password string
+	// CurrentVersion and LatestVersion are reported by list_version_info.
+	CurrentVersion string
+	LatestVersion  string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	sessions map[string]bool
+	calls    map[string]int
+
+	files        map[string]string
+	accounts     map[string]*account
+	accountUsers map[string]*accountUser
+	vpcs         map[string]*vpc
+	gateways     map[string]*gateway
+	tags         map[string]map[string]string
+	system       systemConfig
+
+	transitPeerings []transitPeering
+	vgwConns        map[string]*vgwConn
+	site2clouds     map[string]*site2cloud
+	fqdnTags        map[string]*fqdnTag
+	policies        map[string]*policy
+	firewallTags    map[string]*firewallTag
+	vpnUsers        map[string]*vpnUser
+	profiles        map[string]*profile
+	xlrEndpoints    []string
+	tgws            map[string]*tgw
+	awsPeerings     []awsPeering
+	armPeerings     []armPeering
+	transPeers      []transPeer
+	tunnels         []tunnel
+}
+
+// NewController starts a fake controller accepting the login admin/password.
+// Callers must Close it when done.
+func NewController() *Controller {
+	c := &Controller{
+		Username:       "admin",
+		Password:       "password",
+		CurrentVersion: "UserConnect-4.7.520",
+		LatestVersion:  "UserConnect-4.7.520",
+
+		sessions:     map[string]bool{},
+		calls:        map[string]int{},
+		files:        map[string]string{},
+		accounts:     map[string]*account{},
+		accountUsers: map[string]*accountUser{},
+		vpcs:         map[string]*vpc{},
+		gateways:     map[string]*gateway{},
+		tags:         map[string]map[string]string{},
+		system:       defaultSystemConfig(),
+		vgwConns:     map[string]*vgwConn{},
+		site2clouds:  map[string]*site2cloud{},
+		fqdnTags:     map[string]*fqdnTag{},
+		policies:     map[string]*policy{},
+		firewallTags: map[string]*firewallTag{},
+		vpnUsers:     map[string]*vpnUser{},
+		profiles:     map[string]*profile{},
+		tgws:         map[string]*tgw{},
+	}
+	c.server = httptest.NewTLSServer(c)
+	return c
+}
+
+// Host returns the host:port to use as the controller IP. The server uses a
+// self-signed certificate, so clients must not verify it.
+func (c *Controller) Host() string {
+	return strings.TrimPrefix(c.server.URL, "https://")
+}
+
+// URL returns the base URL of the controller
+func (c *Controller) URL() string {
+	return c.server.URL
+}
+
+// Close shuts the controller down
+func (c *Controller) Close() {
+	c.server.Close()
+}
+
+// ExpireSessions invalidates every CID handed out so far, as a controller
+// restart would.
+func (c *Controller) ExpireSessions() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions = map[string]bool{}
+}
+
+// Calls returns how many times action has been received
+func (c *Controller) Calls(action string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[action]
+}
+
+// ServeHTTP implements http.Handler
+func (c *Controller) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/v1/api":
+	case "/v1/backend1":
+		c.serveBackend(w, r)
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := r.Form.Get("action")
+	c.calls[name]++
+	if name == "login" {
+		c.login(w, params{r.Form})
+		return
+	}
+	if !c.sessions[r.Form.Get("CID")] {
+		writeJSON(w, map[string]interface{}{"return": false, "reason": cidExpiredReason})
+		return
+	}
+
+	a, ok := actions[name]
+	if !ok {
+		writeJSON(w, map[string]interface{}{
+			"return": false,
+			"reason": fmt.Sprintf("action %q is not supported by the fake controller", name),
+		})
+		return
+	}
+	results, err := a(c, params{r.Form})
+	if err != nil {
+		writeJSON(w, map[string]interface{}{"return": false, "reason": err.Error()})
+		return
+	}
+	writeJSON(w, map[string]interface{}{"return": true, "results": results})
+}
+
+func (c *Controller) login(w http.ResponseWriter, p params) {
+	if p.get("username") != c.Username || p.get("password") != c.Password {
+		writeJSON(w, map[string]interface{}{"return": false, "reason": "Invalid username or password."})
+		return
+	}
+	cid := fmt.Sprintf("cid%012d", c.next())
+	c.sessions[cid] = true
+	writeJSON(w, map[string]interface{}{
+		"return":  true,
+		"results": fmt.Sprintf("User login:%s in account:%s has been authorized successfully", c.Username, c.Username),
+		"CID":     cid,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// next returns a new sequence number for generated IDs and addresses
+func (c *Controller) next() int {
+	c.seq++
+	return c.seq
+}
+
+// id returns a new cloud style ID with the given prefix, such as "vpc-"
+func (c *Controller) id(prefix string) string {
+	return fmt.Sprintf("%s%08x", prefix, c.next())
+}
+
+// ip returns a new public IP address
+func (c *Controller) ip() string {
+	n := c.next()
+	return fmt.Sprintf("54.%d.%d.%d", n>>16&0xff, n>>8&0xff, n&0xff)
+}
+
+// params are the form values of a request
+type params struct {
+	url.Values
+}
+
+func (p params) get(key string) string {
+	return p.Values.Get(key)
+}
+
+// require checks that every key has a non-empty value
+func (p params) require(keys ...string) error {
+	for _, k := range keys {
+		if p.get(k) == "" {
+			return fmt.Errorf("missing required parameter: %s", k)
+		}
+	}
+	return nil
+}
+
+// decode fills dst from the values using its form tags
+func (p params) decode(dst interface{}) error {
+	d := form.NewDecoder(nil)
+	d.IgnoreUnknownKeys(true)
+	return d.DecodeValues(dst, p.Values)
+}
+
+// indexed returns the values of keys such as prefix[0], prefix[1], ... in
+// index order.
+func (p params) indexed(prefix string) []string {
+	re := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + `\[(\d+)\]$`)
+	return p.collect(re)
+}
+
+// indexedField returns the values of keys such as prefix[0][field] in
+// index order.
+func (p params) indexedField(prefix string, field string) []string {
+	re := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + `\[(\d+)\]\[` + regexp.QuoteMeta(field) + `\]$`)
+	return p.collect(re)
+}
+
+func (p params) collect(re *regexp.Regexp) []string {
+	type item struct {
+		index int
+		value string
+	}
+	var items []item
+	for k, v := range p.Values {
+		m := re.FindStringSubmatch(k)
+		if m == nil {
+			continue
+		}
+		var i int
+		fmt.Sscan(m[1], &i)
+		items = append(items, item{i, v[0]})
+	}
+	sort.Slice(items, func(a, b int) bool { return items[a].index < items[b].index })
+	values := make([]string, 0, len(items))
+	for _, it := range items {
+		values = append(values, it.value)
+	}
+	return values
+}
+
+// yesNo renders b the way the controller reports flags
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// splitList splits a comma separated list, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(list []string, s string) []string {
+	var kept []string
+	for _, item := range list {
+		if item != s {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// inUse builds the error for deleting an object that others still depend on
+func inUse(kind string, name string, users []string) error {
+	sort.Strings(users)
+	return fmt.Errorf("%s %s is in use by %s", kind, name, strings.Join(users, ", "))
+}
@@ -0,0 +1,173 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// policy is the stateful firewall of a single gateway.
+type policy struct {
+	basePolicy     string
+	baseLogEnabled string
+	rules          []*goaviatrix.Policy
+}
+
+type firewallTag struct {
+	name    string
+	members []goaviatrix.CIDRMember
+}
+
+func init() {
+	register(map[string]action{
+		"vpc_access_policy":     vpcAccessPolicy,
+		"set_vpc_base_policy":   setVpcBasePolicy,
+		"update_access_policy":  updateAccessPolicy,
+		"add_policy_tag":        addPolicyTag,
+		"list_policy_members":   listPolicyMembers,
+		"update_policy_members": updatePolicyMembers,
+		"del_policy_tag":        delPolicyTag,
+	})
+}
+
+// policyOf returns the firewall of the named gateway, creating the
+// controller's allow-all default on first use.
+func (c *Controller) policyOf(name string) (*policy, error) {
+	if _, err := c.findGateway(name); err != nil {
+		return nil, err
+	}
+	pol, ok := c.policies[name]
+	if !ok {
+		pol = &policy{basePolicy: "allow-all", baseLogEnabled: "off"}
+		c.policies[name] = pol
+	}
+	return pol, nil
+}
+
+func vpcAccessPolicy(c *Controller, p params) (interface{}, error) {
+	name := p.get("vpc_name")
+	pol, err := c.policyOf(name)
+	if err != nil {
+		return nil, err
+	}
+	return goaviatrix.Firewall{
+		GwName:         name,
+		BasePolicy:     pol.basePolicy,
+		BaseLogEnabled: pol.baseLogEnabled,
+		PolicyList:     pol.rules,
+	}, nil
+}
+
+func setVpcBasePolicy(c *Controller, p params) (interface{}, error) {
+	name := p.get("vpc_name")
+	pol, err := c.policyOf(name)
+	if err != nil {
+		return nil, err
+	}
+	base := p.get("base_policy")
+	if base != "allow-all" && base != "deny-all" {
+		return nil, fmt.Errorf("invalid base_policy: %q", base)
+	}
+	logEnabled := orDefault(p.get("base_policy_log_enable"), "off")
+	if logEnabled != "on" && logEnabled != "off" {
+		return nil, fmt.Errorf("invalid base_policy_log_enable: %q", logEnabled)
+	}
+	pol.basePolicy = base
+	pol.baseLogEnabled = logEnabled
+	return fmt.Sprintf("Base policy of gateway %s has been updated", name), nil
+}
+
+func updateAccessPolicy(c *Controller, p params) (interface{}, error) {
+	name := p.get("vpc_name")
+	pol, err := c.policyOf(name)
+	if err != nil {
+		return nil, err
+	}
+	var rules []*goaviatrix.Policy
+	if raw := p.get("new_policy"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+			return nil, fmt.Errorf("invalid new_policy: %s", err)
+		}
+	}
+	for _, rule := range rules {
+		if rule == nil || rule.SrcIP == "" || rule.DstIP == "" {
+			return nil, fmt.Errorf("every policy must have a source and a destination")
+		}
+		if rule.Action != "allow" && rule.Action != "deny" {
+			return nil, fmt.Errorf("invalid deny_allow: %q", rule.Action)
+		}
+		if rule.LogEnabled == "" {
+			rule.LogEnabled = "off"
+		}
+	}
+	pol.rules = rules
+	return fmt.Sprintf("Access policies of gateway %s have been updated", name), nil
+}
+
+func (c *Controller) findFirewallTag(name string) (*firewallTag, error) {
+	tag, ok := c.firewallTags[name]
+	if !ok {
+		return nil, fmt.Errorf("firewall tag %s does not exist", name)
+	}
+	return tag, nil
+}
+
+func addPolicyTag(c *Controller, p params) (interface{}, error) {
+	if err := p.require("tag_name"); err != nil {
+		return nil, err
+	}
+	name := p.get("tag_name")
+	if _, ok := c.firewallTags[name]; ok {
+		return nil, fmt.Errorf("firewall tag %s already exists", name)
+	}
+	c.firewallTags[name] = &firewallTag{name: name}
+	return fmt.Sprintf("Firewall tag %s has been added", name), nil
+}
+
+func listPolicyMembers(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFirewallTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	return goaviatrix.FirewallTag{Name: tag.name, CIDRList: tag.members}, nil
+}
+
+func updatePolicyMembers(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFirewallTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	names := p.indexedField("new_policies", "name")
+	cidrs := p.indexedField("new_policies", "cidr")
+	if len(names) != len(cidrs) {
+		return nil, fmt.Errorf("every member must have a name and a cidr")
+	}
+	members := make([]goaviatrix.CIDRMember, 0, len(names))
+	for i := range names {
+		members = append(members, goaviatrix.CIDRMember{CIDRTag: names[i], CIDR: cidrs[i]})
+	}
+	tag.members = members
+	return fmt.Sprintf("Members of firewall tag %s have been updated", tag.name), nil
+}
+
+func delPolicyTag(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFirewallTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	var users []string
+	for _, gw := range sortedKeys(c.policies) {
+		for _, rule := range c.policies[gw].rules {
+			if rule.SrcIP == tag.name || rule.DstIP == tag.name {
+				users = append(users, "firewall of gateway "+gw)
+				break
+			}
+		}
+	}
+	if len(users) != 0 {
+		return nil, inUse("firewall tag", tag.name, users)
+	}
+	delete(c.firewallTags, tag.name)
+	return fmt.Sprintf("Firewall tag %s has been deleted", tag.name), nil
+}
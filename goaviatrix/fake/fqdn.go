@@ -0,0 +1,211 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+type fqdnTag struct {
+	name     string
+	state    string
+	color    string
+	domains  []goaviatrix.Filters
+	gateways []string
+	// sourceIPs are the source IP filters per attached gateway
+	sourceIPs map[string][]string
+}
+
+func init() {
+	register(map[string]action{
+		"add_fqdn_filter_tag":                      addFqdnFilterTag,
+		"del_fqdn_filter_tag":                      delFqdnFilterTag,
+		"list_fqdn_filter_tags":                    listFqdnFilterTags,
+		"set_fqdn_filter_tag_state":                setFqdnFilterTagState,
+		"set_fqdn_filter_tag_color":                setFqdnFilterTagColor,
+		"set_fqdn_filter_tag_domain_names":         setFqdnFilterTagDomainNames,
+		"list_fqdn_filter_tag_domain_names":        listFqdnFilterTagDomainNames,
+		"attach_fqdn_filter_tag_to_gw":             attachFqdnFilterTagToGw,
+		"detach_fqdn_filter_tag_from_gw":           detachFqdnFilterTagFromGw,
+		"list_fqdn_filter_tag_attached_gws":        listFqdnFilterTagAttachedGws,
+		"update_fqdn_filter_tag_source_ip_filters": updateFqdnFilterTagSourceIPFilters,
+		"list_fqdn_filter_tag_source_ip_filters":   listFqdnFilterTagSourceIPFilters,
+	})
+}
+
+func (c *Controller) findFqdnTag(name string) (*fqdnTag, error) {
+	tag, ok := c.fqdnTags[name]
+	if !ok {
+		return nil, fmt.Errorf("FQDN filter tag %s does not exist", name)
+	}
+	return tag, nil
+}
+
+func addFqdnFilterTag(c *Controller, p params) (interface{}, error) {
+	if err := p.require("tag_name"); err != nil {
+		return nil, err
+	}
+	name := p.get("tag_name")
+	if _, ok := c.fqdnTags[name]; ok {
+		return nil, fmt.Errorf("FQDN filter tag %s already exists", name)
+	}
+	c.fqdnTags[name] = &fqdnTag{
+		name:      name,
+		state:     "disabled",
+		color:     "white",
+		domains:   []goaviatrix.Filters{},
+		sourceIPs: map[string][]string{},
+	}
+	return fmt.Sprintf("FQDN filter tag %s has been added", name), nil
+}
+
+func delFqdnFilterTag(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	if len(tag.gateways) != 0 {
+		var users []string
+		for _, gw := range tag.gateways {
+			users = append(users, "gateway "+gw)
+		}
+		return nil, inUse("FQDN filter tag", tag.name, users)
+	}
+	delete(c.fqdnTags, tag.name)
+	return fmt.Sprintf("FQDN filter tag %s has been deleted", tag.name), nil
+}
+
+func listFqdnFilterTags(c *Controller, p params) (interface{}, error) {
+	tags := map[string]map[string]string{}
+	for name, tag := range c.fqdnTags {
+		tags[name] = map[string]string{"wbmode": tag.color, "state": tag.state}
+	}
+	return tags, nil
+}
+
+func setFqdnFilterTagState(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	status := p.get("status")
+	if status != "enabled" && status != "disabled" {
+		return nil, fmt.Errorf("invalid status: %q", status)
+	}
+	tag.state = status
+	return fmt.Sprintf("FQDN filter tag %s has been %s", tag.name, status), nil
+}
+
+func setFqdnFilterTagColor(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	color := p.get("color")
+	if color != "white" && color != "black" {
+		return nil, fmt.Errorf("invalid color: %q", color)
+	}
+	tag.color = color
+	return fmt.Sprintf("FQDN filter tag %s mode has been set to %s", tag.name, color), nil
+}
+
+func setFqdnFilterTagDomainNames(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	names := p.indexedField("domain_names", "fqdn")
+	protos := p.indexedField("domain_names", "proto")
+	ports := p.indexedField("domain_names", "port")
+	if len(protos) != len(names) || len(ports) != len(names) {
+		return nil, fmt.Errorf("every domain name must have a fqdn, proto and port")
+	}
+	domains := make([]goaviatrix.Filters, 0, len(names))
+	for i := range names {
+		domains = append(domains, goaviatrix.Filters{FQDN: names[i], Protocol: protos[i], Port: ports[i]})
+	}
+	tag.domains = domains
+	return fmt.Sprintf("Domain names of FQDN filter tag %s have been updated", tag.name), nil
+}
+
+func listFqdnFilterTagDomainNames(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	return tag.domains, nil
+}
+
+func attachFqdnFilterTagToGw(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	gw, err := c.findGateway(p.get("gw_name"))
+	if err != nil {
+		return nil, err
+	}
+	if gw.EnableNat != "yes" {
+		return nil, fmt.Errorf("SNAT must be enabled on gateway %s to attach FQDN filter tags", gw.GwName)
+	}
+	if contains(tag.gateways, gw.GwName) {
+		return nil, fmt.Errorf("FQDN filter tag %s is already attached to gateway %s", tag.name, gw.GwName)
+	}
+	tag.gateways = append(tag.gateways, gw.GwName)
+	return fmt.Sprintf("FQDN filter tag %s has been attached to gateway %s", tag.name, gw.GwName), nil
+}
+
+func detachFqdnFilterTagFromGw(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("gw_name")
+	if !contains(tag.gateways, name) {
+		return nil, fmt.Errorf("FQDN filter tag %s is not attached to gateway %s", tag.name, name)
+	}
+	tag.gateways = remove(tag.gateways, name)
+	delete(tag.sourceIPs, name)
+	return fmt.Sprintf("FQDN filter tag %s has been detached from gateway %s", tag.name, name), nil
+}
+
+func listFqdnFilterTagAttachedGws(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{}, tag.gateways...), nil
+}
+
+func updateFqdnFilterTagSourceIPFilters(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("gateway_name")
+	if !contains(tag.gateways, name) {
+		return nil, fmt.Errorf("FQDN filter tag %s is not attached to gateway %s", tag.name, name)
+	}
+	tag.sourceIPs[name] = p.indexed("source_ips")
+	return fmt.Sprintf("Source IP filters of FQDN filter tag %s have been updated", tag.name), nil
+}
+
+func listFqdnFilterTagSourceIPFilters(c *Controller, p params) (interface{}, error) {
+	tag, err := c.findFqdnTag(p.get("tag_name"))
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("gateway_name")
+	if !contains(tag.gateways, name) {
+		return nil, fmt.Errorf("FQDN filter tag %s is not attached to gateway %s", tag.name, name)
+	}
+	configured := []string{}
+	for _, ip := range tag.sourceIPs[name] {
+		configured = append(configured, ip+"~~"+name)
+	}
+	subnets := []string{}
+	if gw := c.gateways[name]; gw != nil {
+		subnets = append(subnets, gw.VpcNet)
+	}
+	return goaviatrix.GwSourceIP{ConfiguredIPs: configured, VpcSubnets: subnets}, nil
+}
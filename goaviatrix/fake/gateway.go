@@ -0,0 +1,664 @@
+package fake
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// Kinds of gateways
+const (
+	kindGateway = "gateway"
+	kindTransit = "transit"
+	kindSpoke   = "spoke"
+)
+
+type gateway struct {
+	// Gateway holds the fields list_vpcs_summary reports. Only fields a real
+	// controller reports are set.
+	goaviatrix.Gateway
+
+	kind string
+	// primary is the name of the primary gateway of an HA gateway
+	primary     string
+	dmz         bool
+	splitTunnel goaviatrix.SplitTunnelUnit
+}
+
+// summary is a list_vpcs_summary entry. TransitVpc is only decoded by
+// clients reading the list as goaviatrix.VPCInfo.
+type summary struct {
+	goaviatrix.Gateway
+	TransitVpc string `json:"transit_vpc,omitempty"`
+}
+
+func init() {
+	register(map[string]action{
+		"connect_container":              connectContainer,
+		"list_vpcs_summary":              listVpcsSummary,
+		"list_vpc_by_name":               listVpcByName,
+		"edit_gw_config":                 editGwConfig,
+		"delete_container":               deleteContainer,
+		"enable_snat":                    setSNat(true),
+		"disable_snat":                   setSNat(false),
+		"enable_nat":                     setSNat(true),
+		"enable_single_az_ha":            setSingleAZHa(true),
+		"disable_single_az_ha":           setSingleAZHa(false),
+		"create_peering_ha_gateway":      createPeeringHaGateway,
+		"set_vpn_client_cidr":            setVpnClientCidr,
+		"set_vpn_max_connection":         setVpnMaxConnection,
+		"set_vpn_gateway_authentication": setVpnGatewayAuthentication,
+		"modify_split_tunnel":            modifySplitTunnel,
+		"list_resource_tags":             listResourceTags,
+		"add_resource_tags":              addResourceTags,
+		"delete_resource_tags":           deleteResourceTags,
+	})
+}
+
+// vpcIDOf strips the decoration list_vpcs_summary adds to VPC IDs, such as
+// "vpc-1234~~name" for AWS or "vpc-name~-~project" for GCP.
+func vpcIDOf(id string) string {
+	for _, sep := range []string{"~~", "~-~"} {
+		if i := strings.Index(id, sep); i > 0 {
+			return id[:i]
+		}
+	}
+	return id
+}
+
+// findGateway returns the gateway named name
+func (c *Controller) findGateway(name string) (*gateway, error) {
+	gw, ok := c.gateways[name]
+	if !ok {
+		return nil, fmt.Errorf("gateway %s does not exist", name)
+	}
+	return gw, nil
+}
+
+// newGateway checks the parameters shared by every kind of gateway and
+// returns a gateway with its placement filled in. region is the region for
+// AWS and ARM gateways and the zone for GCP gateways.
+func (c *Controller) newGateway(kind string, name string, accountName string, ct int, vpcID string, size string, subnet string, region string) (*gateway, error) {
+	if name == "" {
+		return nil, fmt.Errorf("missing required parameter: gw_name")
+	}
+	if _, ok := c.gateways[name]; ok {
+		return nil, fmt.Errorf("gateway %s already exists", name)
+	}
+	if err := c.requireAccount(accountName); err != nil {
+		return nil, err
+	}
+	if c.accounts[accountName].CloudType != ct {
+		return nil, fmt.Errorf("account %s is not valid for cloud_type %d", accountName, ct)
+	}
+	for key, value := range map[string]string{"vpc_id": vpcID, "gw_size": size, "subnet": subnet, "region": region} {
+		if value == "" {
+			return nil, fmt.Errorf("missing required parameter: %s", key)
+		}
+	}
+
+	gw := &gateway{kind: kind}
+	gw.GwName = name
+	gw.AccountName = accountName
+	gw.CloudType = ct
+	gw.GwSize = size
+	gw.VpcNet = subnet
+	switch ct {
+	case 1:
+		gw.VpcID = vpcID + "~~" + name
+		gw.VpcRegion = region
+		gw.GatewayZone = region + "a"
+	case 4:
+		gw.VpcID = vpcID + "~-~" + c.accounts[accountName].GcloudProjectName
+		gw.GatewayZone = region
+		if i := strings.LastIndex(region, "-"); i > 0 {
+			gw.VpcRegion = region[:i]
+		}
+	case 8:
+		gw.VpcID = vpcID
+		gw.VpcRegion = region
+	default:
+		return nil, fmt.Errorf("invalid cloud_type: %d", ct)
+	}
+	gw.EnableNat = "no"
+	gw.VpnStatus = "disabled"
+	gw.SingleAZ = "no"
+	gw.PublicIP = c.ip()
+	n := c.next()
+	gw.PrivateIP = fmt.Sprintf("10.%d.%d.%d", n>>16&0xff, n>>8&0xff, n&0xff)
+	gw.CloudnGatewayInstID = c.id("i-")
+	gw.GwSecurityGroupID = c.id("sg-")
+	gw.PublicDnsServer = "8.8.8.8"
+	gw.InstState = "running"
+	gw.VpcState = "up"
+	gw.ConnectedTransit = "no"
+	gw.InsaneMode = "no"
+	gw.SpokeVpc = "no"
+	return gw, nil
+}
+
+// addHaGateway creates the HA gateway of primary in subnet or, for GCP, in
+// zone.
+func (c *Controller) addHaGateway(primary *gateway, subnet string, zone string, eip string) error {
+	name := primary.GwName + "-hagw"
+	if _, ok := c.gateways[name]; ok {
+		return fmt.Errorf("HA GW already exists for %s", primary.GwName)
+	}
+	if subnet == "" && zone == "" {
+		return fmt.Errorf("missing required parameter: public_subnet")
+	}
+	ha := &gateway{kind: primary.kind, primary: primary.GwName}
+	ha.Gateway = primary.Gateway
+	ha.GwName = name
+	ha.IsHagw = "yes"
+	ha.PublicIP = c.ip()
+	if eip != "" {
+		ha.PublicIP = eip
+	}
+	ha.CloudnGatewayInstID = c.id("i-")
+	ha.VpnStatus = "disabled"
+	ha.ElbState = ""
+	ha.ElbName = ""
+	ha.SpokeVpc = "no"
+	ha.TransitGwName = ""
+	if subnet != "" {
+		ha.VpcNet = subnet
+		if i := strings.Index(subnet, "~~"); i > 0 {
+			ha.VpcNet = subnet[:i]
+			ha.GatewayZone = subnet[i+2:]
+		}
+	}
+	if zone != "" {
+		ha.GatewayZone = zone
+	}
+	c.gateways[name] = ha
+	c.tagResource("gw", name, nil)
+	return nil
+}
+
+func connectContainer(c *Controller, p params) (interface{}, error) {
+	ct, err := cloudType(p)
+	if err != nil {
+		return nil, err
+	}
+	region := p.get("vpc_reg")
+	if ct == 4 {
+		region = p.get("zone")
+	}
+	gw, err := c.newGateway(kindGateway, p.get("gw_name"), p.get("account_name"), ct, p.get("vpc_id"),
+		p.get("vpc_size"), p.get("vpc_net"), region)
+	if err != nil {
+		return nil, err
+	}
+
+	gw.EnableNat = yesNo(p.get("enable_nat") == "yes")
+	gw.AllocateNewEipRead = ct == 1 && p.get("allocate_new_eip") != "off"
+	if ct == 1 && p.get("allocate_new_eip") == "off" {
+		if p.get("eip") == "" {
+			return nil, fmt.Errorf("eip is required if allocate_new_eip is off")
+		}
+		gw.PublicIP = p.get("eip")
+	}
+	if p.get("single_az_ha") == "enabled" {
+		gw.SingleAZ = "yes"
+	}
+
+	if p.get("vpn_access") == "yes" {
+		if err := p.require("cidr"); err != nil {
+			return nil, err
+		}
+		gw.VpnStatus = "enabled"
+		gw.VpnCidr = p.get("cidr")
+		gw.MaxConn = p.get("max_conn")
+		gw.SplitTunnel = yesNo(p.get("split_tunnel") == "yes")
+		gw.splitTunnel.SplitTunnel = gw.SplitTunnel
+		if p.get("enable_elb") == "yes" {
+			gw.ElbState = "enabled"
+			gw.EnableElb = "yes"
+			gw.ElbName = p.get("elb_name")
+			if gw.ElbName == "" {
+				gw.ElbName = "elb-" + vpcIDOf(gw.VpcID)
+			}
+			gw.ElbDNSName = gw.ElbName + ".elb.amazonaws.com"
+		} else {
+			gw.ElbState = "disabled"
+		}
+		gw.SamlEnabled = yesNo(p.get("saml_enabled") == "yes")
+		gw.EnableLdapRead = p.get("enable_ldap") == "yes"
+		auth := goaviatrix.VpnGatewayAuth{
+			OtpMode:            p.get("otp_mode"),
+			OktaURL:            p.get("okta_url"),
+			OktaToken:          p.get("okta_token"),
+			OktaUsernameSuffix: p.get("okta_username_suffix"),
+			DuoIntegrationKey:  p.get("duo_integration_key"),
+			DuoSecretKey:       p.get("duo_secret_key"),
+			DuoAPIHostname:     p.get("duo_api_hostname"),
+			DuoPushMode:        p.get("duo_push_mode"),
+			EnableLdap:         p.get("enable_ldap"),
+			LdapServer:         p.get("ldap_server"),
+			LdapBindDn:         p.get("ldap_bind_dn"),
+			LdapBaseDn:         p.get("ldap_base_dn"),
+			LdapUserAttr:       p.get("ldap_username_attribute"),
+			LdapPassword:       p.get("ldap_password"),
+		}
+		if err := gw.setAuth(auth); err != nil {
+			return nil, err
+		}
+	} else if p.get("enable_elb") == "yes" {
+		return nil, fmt.Errorf("ELB can not be enabled without VPN access")
+	}
+
+	c.gateways[gw.GwName] = gw
+	c.tagResource("gw", gw.GwName, tagMap(p.get("tags")))
+	return fmt.Sprintf("Gateway %s has been created", gw.GwName), nil
+}
+
+// setAuth applies the VPN authentication settings of a gateway
+func (gw *gateway) setAuth(auth goaviatrix.VpnGatewayAuth) error {
+	gw.AuthMethod = ""
+	gw.OktaURL, gw.OktaUsernameSuffix = "", ""
+	gw.DuoIntegrationKey, gw.DuoAPIHostname, gw.DuoPushMode = "", "", ""
+	gw.LdapServer, gw.LdapBindDn, gw.LdapBaseDn, gw.LdapUserAttr = "", "", "", ""
+
+	ldap := auth.EnableLdap == "yes"
+	if ldap {
+		if auth.LdapServer == "" || auth.LdapBindDn == "" || auth.LdapBaseDn == "" || auth.LdapUserAttr == "" {
+			return fmt.Errorf("missing required LDAP parameters")
+		}
+		gw.LdapServer = auth.LdapServer
+		gw.LdapBindDn = auth.LdapBindDn
+		gw.LdapBaseDn = auth.LdapBaseDn
+		gw.LdapUserAttr = auth.LdapUserAttr
+		gw.AuthMethod = "LDAP"
+	}
+	switch auth.OtpMode {
+	case "":
+	case "2":
+		if auth.DuoIntegrationKey == "" || auth.DuoAPIHostname == "" {
+			return fmt.Errorf("missing required DUO parameters")
+		}
+		gw.DuoIntegrationKey = auth.DuoIntegrationKey
+		gw.DuoAPIHostname = auth.DuoAPIHostname
+		gw.DuoPushMode = auth.DuoPushMode
+		gw.AuthMethod = "duo_auth"
+		if ldap {
+			gw.AuthMethod = "duo_auth+LDAP"
+		}
+	case "3":
+		if ldap {
+			return fmt.Errorf("LDAP is not supported with okta authentication")
+		}
+		if auth.OktaURL == "" {
+			return fmt.Errorf("missing required parameter: okta_url")
+		}
+		gw.OktaURL = auth.OktaURL
+		gw.OktaUsernameSuffix = auth.OktaUsernameSuffix
+		gw.AuthMethod = "okta_auth"
+	default:
+		return fmt.Errorf("invalid otp_mode: %q", auth.OtpMode)
+	}
+	gw.EnableLdapRead = ldap
+	return nil
+}
+
+func listVpcsSummary(c *Controller, p params) (interface{}, error) {
+	list := make([]summary, 0, len(c.gateways))
+	for _, name := range sortedKeys(c.gateways) {
+		gw := c.gateways[name]
+		s := summary{Gateway: gw.Gateway}
+		// EnableElb is not reported, ElbState is
+		s.EnableElb = ""
+		if gw.kind == kindTransit {
+			s.TransitVpc = "yes"
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func listVpcByName(c *Controller, p params) (interface{}, error) {
+	gw, err := c.findGateway(p.get("vpc_name"))
+	if err != nil {
+		return nil, err
+	}
+	return goaviatrix.GatewayDetail{
+		AccountName: gw.AccountName,
+		GwName:      gw.GwName,
+		DMZEnabled:  gw.dmz,
+	}, nil
+}
+
+func editGwConfig(c *Controller, p params) (interface{}, error) {
+	if err := p.require("gw_name", "gw_size"); err != nil {
+		return nil, err
+	}
+	gw, err := c.findGateway(p.get("gw_name"))
+	if err != nil {
+		return nil, err
+	}
+	gw.GwSize = p.get("gw_size")
+	return fmt.Sprintf("Gateway %s has been resized to %s", gw.GwName, gw.GwSize), nil
+}
+
+func deleteContainer(c *Controller, p params) (interface{}, error) {
+	name := p.get("gw_name")
+	gw, err := c.findGateway(name)
+	if err != nil {
+		return nil, err
+	}
+	if users := c.gatewayUsers(gw); len(users) != 0 {
+		return nil, inUse("gateway", name, users)
+	}
+	delete(c.gateways, name)
+	delete(c.tags, "gw/"+name)
+	delete(c.policies, name)
+	return fmt.Sprintf("Gateway %s has been deleted", name), nil
+}
+
+// gatewayUsers lists the objects that keep gw from being deleted
+func (c *Controller) gatewayUsers(gw *gateway) []string {
+	var users []string
+	name := gw.GwName
+	if _, ok := c.gateways[name+"-hagw"]; ok && gw.primary == "" {
+		users = append(users, "HA gateway "+name+"-hagw")
+	}
+	if gw.kind == kindSpoke && gw.SpokeVpc == "yes" {
+		users = append(users, "transit attachment to "+gw.TransitGwName)
+	}
+	for _, other := range c.gateways {
+		if other.kind == kindSpoke && other.SpokeVpc == "yes" && other.TransitGwName == name {
+			users = append(users, "spoke "+other.GwName)
+		}
+	}
+	for _, s := range c.site2clouds {
+		if s.GwName == name || s.backupGwName == name {
+			users = append(users, "site2cloud "+s.TunnelName)
+		}
+	}
+	for _, v := range c.vgwConns {
+		if v.GwName == name {
+			users = append(users, "VGW connection "+v.ConnName)
+		}
+	}
+	for _, tp := range c.transitPeerings {
+		if tp.TransitGatewayName1 == name || tp.TransitGatewayName2 == name {
+			users = append(users, "transit peering "+tp.TransitGatewayName1+"<->"+tp.TransitGatewayName2)
+		}
+	}
+	for _, t := range c.tunnels {
+		if t.VpcName1 == name || t.VpcName2 == name {
+			users = append(users, "tunnel "+t.VpcName1+"<->"+t.VpcName2)
+		}
+	}
+	for _, tp := range c.transPeers {
+		if tp.Source == name || tp.Nexthop == name {
+			users = append(users, "transitive peering "+tp.Source+"->"+tp.Nexthop)
+		}
+	}
+	for _, tag := range c.fqdnTags {
+		if contains(tag.gateways, name) {
+			users = append(users, "FQDN filter tag "+tag.name)
+		}
+	}
+	for _, t := range c.tgws {
+		if t.attachedGateway(gw) != "" {
+			users = append(users, "AWS TGW "+t.name)
+		}
+	}
+	// Users and accelerators attached to an ELB only hold on to the last
+	// gateway behind it.
+	lastBehindElb := gw.ElbState == "enabled" && len(c.vpnGateways(gw.VpcID, gw.ElbName)) == 1
+	for _, u := range c.vpnUsers {
+		if u.GwName == name || (lastBehindElb && u.GwName == gw.ElbName) {
+			users = append(users, "VPN user "+u.UserName)
+		}
+	}
+	if lastBehindElb && contains(c.xlrEndpoints, gw.ElbName) {
+		users = append(users, "VPN user accelerator "+gw.ElbName)
+	}
+	return users
+}
+
+func setSNat(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		name := p.get("gateway_name")
+		if name == "" {
+			name = p.get("gw_name")
+		}
+		gw, err := c.findGateway(name)
+		if err != nil {
+			return nil, err
+		}
+		if enabled && gw.EnableNat == "yes" {
+			return nil, fmt.Errorf("SNAT is already enabled on gateway %s", name)
+		}
+		gw.EnableNat = yesNo(enabled)
+		return fmt.Sprintf("SNAT has been updated on gateway %s", name), nil
+	}
+}
+
+func setSingleAZHa(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		gw, err := c.findGateway(p.get("gw_name"))
+		if err != nil {
+			return nil, err
+		}
+		gw.SingleAZ = yesNo(enabled)
+		return fmt.Sprintf("Single AZ HA has been updated on gateway %s", gw.GwName), nil
+	}
+}
+
+func createPeeringHaGateway(c *Controller, p params) (interface{}, error) {
+	gw, err := c.findGateway(p.get("gw_name"))
+	if err != nil {
+		return nil, err
+	}
+	if gw.kind != kindGateway {
+		return nil, fmt.Errorf("gateway %s is not a peering gateway", gw.GwName)
+	}
+	if err := c.addHaGateway(gw, p.get("public_subnet"), p.get("new_zone"), p.get("eip")); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Peering HA gateway %s-hagw has been created", gw.GwName), nil
+}
+
+// vpnGateways returns the VPN gateways in vpcID served through lbName,
+// which is either a gateway name or the name of their ELB.
+func (c *Controller) vpnGateways(vpcID string, lbName string) []*gateway {
+	var gws []*gateway
+	for _, name := range sortedKeys(c.gateways) {
+		gw := c.gateways[name]
+		if gw.VpnStatus != "enabled" || vpcIDOf(gw.VpcID) != vpcIDOf(vpcID) {
+			continue
+		}
+		if lbName == "" || gw.GwName == lbName || (gw.ElbState == "enabled" && gw.ElbName == lbName) {
+			gws = append(gws, gw)
+		}
+	}
+	return gws
+}
+
+func (c *Controller) requireVpnGateways(p params) ([]*gateway, error) {
+	gws := c.vpnGateways(p.get("vpc_id"), p.get("lb_or_gateway_name"))
+	if len(gws) == 0 {
+		return nil, fmt.Errorf("VPN gateway %s does not exist in VPC %s", p.get("lb_or_gateway_name"), p.get("vpc_id"))
+	}
+	return gws, nil
+}
+
+func setVpnClientCidr(c *Controller, p params) (interface{}, error) {
+	if err := p.require("cidr"); err != nil {
+		return nil, err
+	}
+	gws, err := c.requireVpnGateways(p)
+	if err != nil {
+		return nil, err
+	}
+	for _, gw := range gws {
+		gw.VpnCidr = p.get("cidr")
+	}
+	return "VPN client CIDR has been updated", nil
+}
+
+func setVpnMaxConnection(c *Controller, p params) (interface{}, error) {
+	if err := p.require("max_connections"); err != nil {
+		return nil, err
+	}
+	gws, err := c.requireVpnGateways(p)
+	if err != nil {
+		return nil, err
+	}
+	for _, gw := range gws {
+		gw.MaxConn = p.get("max_connections")
+	}
+	return "VPN max connections have been updated", nil
+}
+
+func setVpnGatewayAuthentication(c *Controller, p params) (interface{}, error) {
+	gws, err := c.requireVpnGateways(p)
+	if err != nil {
+		return nil, err
+	}
+	var auth goaviatrix.VpnGatewayAuth
+	if err := p.decode(&auth); err != nil {
+		return nil, fmt.Errorf("invalid authentication parameters: %s", err)
+	}
+	switch auth.AuthType {
+	case "duo_auth":
+		auth.OtpMode = "2"
+	case "okta_auth":
+		auth.OtpMode = "3"
+	case "duo_auth+LDAP":
+		auth.OtpMode = "2"
+		auth.EnableLdap = "yes"
+	case "LDAP":
+		auth.EnableLdap = "yes"
+	case "none", "":
+		auth.OtpMode = ""
+		auth.EnableLdap = "no"
+	}
+	for _, gw := range gws {
+		if err := gw.setAuth(auth); err != nil {
+			return nil, err
+		}
+		if auth.SamlEnabled != "" {
+			gw.SamlEnabled = auth.SamlEnabled
+		}
+	}
+	return "VPN authentication has been updated", nil
+}
+
+func modifySplitTunnel(c *Controller, p params) (interface{}, error) {
+	gws := c.vpnGateways(p.get("vpc_id"), p.get("lb_name"))
+	if len(gws) == 0 {
+		return nil, fmt.Errorf("VPN gateway %s does not exist in VPC %s", p.get("lb_name"), p.get("vpc_id"))
+	}
+	switch p.get("command") {
+	case "get":
+		return gws[0].splitTunnel, nil
+	case "modify":
+		unit := goaviatrix.SplitTunnelUnit{
+			SplitTunnel:     p.get("split_tunnel"),
+			AdditionalCidrs: p.get("additional_cidrs"),
+			NameServers:     p.get("nameservers"),
+			SearchDomains:   p.get("search_domains"),
+		}
+		if unit.SplitTunnel != "yes" && unit.SplitTunnel != "no" {
+			return nil, fmt.Errorf("invalid split_tunnel: %q", unit.SplitTunnel)
+		}
+		for _, gw := range gws {
+			gw.splitTunnel = unit
+			gw.SplitTunnel = unit.SplitTunnel
+		}
+		return "Split tunnel has been modified", nil
+	}
+	return nil, fmt.Errorf("invalid command: %q", p.get("command"))
+}
+
+// tagResource sets the tags of a resource, including the tag the controller
+// adds to everything it creates.
+func (c *Controller) tagResource(resourceType string, name string, tags map[string]string) {
+	all := map[string]string{"Aviatrix-Created-Resource": "Do-Not-Delete-Aviatrix-Created-Resource"}
+	for k, v := range tags {
+		all[k] = v
+	}
+	c.tags[resourceType+"/"+name] = all
+}
+
+// tagMap parses a tag list of the form "k1:v1,k2:v2"
+func tagMap(list string) map[string]string {
+	tags := map[string]string{}
+	for _, item := range splitList(list) {
+		kv := strings.SplitN(item, ":", 2)
+		if len(kv) == 2 {
+			tags[kv[0]] = kv[1]
+		} else {
+			tags[kv[0]] = ""
+		}
+	}
+	return tags
+}
+
+func (c *Controller) resourceTags(p params) (map[string]string, error) {
+	if err := p.require("cloud_type", "resource_type", "resource_name"); err != nil {
+		return nil, err
+	}
+	if p.get("cloud_type") != "1" {
+		return nil, fmt.Errorf("tags are not supported for cloud_type %s", p.get("cloud_type"))
+	}
+	tags, ok := c.tags[p.get("resource_type")+"/"+p.get("resource_name")]
+	if !ok {
+		return nil, fmt.Errorf("%s %s does not exist", p.get("resource_type"), p.get("resource_name"))
+	}
+	return tags, nil
+}
+
+func listResourceTags(c *Controller, p params) (interface{}, error) {
+	tags, err := c.resourceTags(p)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]map[string]string{"tags": tags}, nil
+}
+
+func addResourceTags(c *Controller, p params) (interface{}, error) {
+	tags, err := c.resourceTags(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.require("new_tag_list"); err != nil {
+		return nil, err
+	}
+	for k, v := range tagMap(p.get("new_tag_list")) {
+		tags[k] = v
+	}
+	return "Tags have been added", nil
+}
+
+func deleteResourceTags(c *Controller, p params) (interface{}, error) {
+	tags, err := c.resourceTags(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.require("del_tag_list"); err != nil {
+		return nil, err
+	}
+	for k := range tagMap(p.get("del_tag_list")) {
+		delete(tags, k)
+	}
+	return "Tags have been deleted", nil
+}
+
+// sortedKeys returns the keys of m, which must be a map with string keys,
+// in order.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,249 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+type transPeer = goaviatrix.TransPeer
+
+type tunnel = goaviatrix.Tunnel
+
+// peer is one side of an AWS or ARM peering as the controller lists it.
+type peer struct {
+	VpcID       string   `json:"vpc_id"`
+	AccountName string   `json:"account_name"`
+	Region      string   `json:"region"`
+	VpcCidr     []string `json:"vpc_cidr"`
+}
+
+type awsPeering struct {
+	Requester peer   `json:"requester"`
+	Accepter  peer   `json:"accepter"`
+	ID        string `json:"peering_id"`
+}
+
+type armPeering struct {
+	Requester peer `json:"requester"`
+	Accepter  peer `json:"accepter"`
+}
+
+func init() {
+	register(map[string]action{
+		"create_aws_peering":       createAwsPeering,
+		"list_aws_peerings":        listAwsPeerings,
+		"delete_aws_peering":       deleteAwsPeering,
+		"arm_peer_vnet_pair":       armPeerVnetPair,
+		"list_arm_peer_vnet_pairs": listArmPeerVnetPairs,
+		"arm_unpeer_vnet_pair":     armUnpeerVnetPair,
+		"add_extended_vpc_peer":    addExtendedVpcPeer,
+		"list_extended_vpc_peer":   listExtendedVpcPeer,
+		"delete_extended_vpc_peer": deleteExtendedVpcPeer,
+		"peer_vpc_pair":            peerVpcPair,
+		"list_peer_vpc_pairs":      listPeerVpcPairs,
+		"unpeer_vpc_pair":          unpeerVpcPair,
+	})
+}
+
+// peerOf reads one side of a peering from the parameters with the given
+// prefixes, checking that its account exists.
+func (c *Controller) peerOf(p params, account string, vpcID string, region string) (peer, error) {
+	if err := p.require(account, vpcID, region); err != nil {
+		return peer{}, err
+	}
+	if err := c.requireAccount(p.get(account)); err != nil {
+		return peer{}, err
+	}
+	side := peer{VpcID: p.get(vpcID), AccountName: p.get(account), Region: p.get(region), VpcCidr: []string{}}
+	for _, v := range c.vpcs {
+		if len(v.VpcID) != 0 && v.VpcID[0] == side.VpcID {
+			side.VpcCidr = []string{v.Cidr}
+		}
+	}
+	return side, nil
+}
+
+func createAwsPeering(c *Controller, p params) (interface{}, error) {
+	req, err := c.peerOf(p, "peer1_account_name", "peer1_vpc_id", "peer1_region")
+	if err != nil {
+		return nil, err
+	}
+	acc, err := c.peerOf(p, "peer2_account_name", "peer2_vpc_id", "peer2_region")
+	if err != nil {
+		return nil, err
+	}
+	if req.VpcID == acc.VpcID {
+		return nil, fmt.Errorf("VPC %s can not be peered with itself", req.VpcID)
+	}
+	for _, pr := range c.awsPeerings {
+		if samePair(pr.Requester.VpcID, pr.Accepter.VpcID, req.VpcID, acc.VpcID) {
+			return nil, fmt.Errorf("VPCs %s and %s are already peered", req.VpcID, acc.VpcID)
+		}
+	}
+	id := c.id("pcx-")
+	c.awsPeerings = append(c.awsPeerings, awsPeering{Requester: req, Accepter: acc, ID: id})
+	return map[string]string{
+		"text": fmt.Sprintf("AWS peering %s between %s and %s has been created", id, req.VpcID, acc.VpcID),
+	}, nil
+}
+
+func listAwsPeerings(c *Controller, p params) (interface{}, error) {
+	return map[string][]awsPeering{"pair_list": append([]awsPeering{}, c.awsPeerings...)}, nil
+}
+
+func deleteAwsPeering(c *Controller, p params) (interface{}, error) {
+	vpc1, vpc2 := p.get("peer1_vpc_id"), p.get("peer2_vpc_id")
+	for i, pr := range c.awsPeerings {
+		if pr.Requester.VpcID == vpc1 && pr.Accepter.VpcID == vpc2 {
+			c.awsPeerings = append(c.awsPeerings[:i], c.awsPeerings[i+1:]...)
+			return fmt.Sprintf("AWS peering %s has been deleted", pr.ID), nil
+		}
+	}
+	return nil, fmt.Errorf("AWS peering between %s and %s does not exist", vpc1, vpc2)
+}
+
+func armPeerVnetPair(c *Controller, p params) (interface{}, error) {
+	req, err := c.peerOf(p, "req_account_name", "req_vpc_id", "req_region")
+	if err != nil {
+		return nil, err
+	}
+	acc, err := c.peerOf(p, "acc_account_name", "acc_vpc_id", "acc_region")
+	if err != nil {
+		return nil, err
+	}
+	if req.VpcID == acc.VpcID {
+		return nil, fmt.Errorf("VNet %s can not be peered with itself", req.VpcID)
+	}
+	for _, pr := range c.armPeerings {
+		if samePair(pr.Requester.VpcID, pr.Accepter.VpcID, req.VpcID, acc.VpcID) {
+			return nil, fmt.Errorf("VNets %s and %s are already peered", req.VpcID, acc.VpcID)
+		}
+	}
+	c.armPeerings = append(c.armPeerings, armPeering{Requester: req, Accepter: acc})
+	return fmt.Sprintf("ARM peering between %s and %s has been created", req.VpcID, acc.VpcID), nil
+}
+
+func listArmPeerVnetPairs(c *Controller, p params) (interface{}, error) {
+	return append([]armPeering{}, c.armPeerings...), nil
+}
+
+func armUnpeerVnetPair(c *Controller, p params) (interface{}, error) {
+	vnet1, vnet2 := p.get("vpc_name1"), p.get("vpc_name2")
+	for i, pr := range c.armPeerings {
+		if pr.Requester.VpcID == vnet1 && pr.Accepter.VpcID == vnet2 {
+			c.armPeerings = append(c.armPeerings[:i], c.armPeerings[i+1:]...)
+			return fmt.Sprintf("ARM peering between %s and %s has been deleted", vnet1, vnet2), nil
+		}
+	}
+	return nil, fmt.Errorf("ARM peering between %s and %s does not exist", vnet1, vnet2)
+}
+
+// samePair reports whether a-b and x-y name the same pair in either order.
+func samePair(a string, b string, x string, y string) bool {
+	return (a == x && b == y) || (a == y && b == x)
+}
+
+func addExtendedVpcPeer(c *Controller, p params) (interface{}, error) {
+	if err := p.require("source", "nexthop", "reachable_cidr"); err != nil {
+		return nil, err
+	}
+	tp := transPeer{Source: p.get("source"), Nexthop: p.get("nexthop"), ReachableCidr: p.get("reachable_cidr")}
+	for _, name := range []string{tp.Source, tp.Nexthop} {
+		if _, err := c.findGateway(name); err != nil {
+			return nil, err
+		}
+	}
+	if !c.tunnelExists(tp.Source, tp.Nexthop) {
+		return nil, fmt.Errorf("gateways %s and %s are not peered", tp.Source, tp.Nexthop)
+	}
+	for _, other := range c.transPeers {
+		if other.Source == tp.Source && other.Nexthop == tp.Nexthop && other.ReachableCidr == tp.ReachableCidr {
+			return nil, fmt.Errorf("transitive peering %s->%s for %s already exists", tp.Source, tp.Nexthop, tp.ReachableCidr)
+		}
+	}
+	c.transPeers = append(c.transPeers, tp)
+	return fmt.Sprintf("Transitive peering %s->%s has been created", tp.Source, tp.Nexthop), nil
+}
+
+func listExtendedVpcPeer(c *Controller, p params) (interface{}, error) {
+	return append([]transPeer{}, c.transPeers...), nil
+}
+
+func deleteExtendedVpcPeer(c *Controller, p params) (interface{}, error) {
+	source, nexthop, cidr := p.get("source"), p.get("nexthop"), p.get("reachable_cidr")
+	for i, tp := range c.transPeers {
+		if tp.Source == source && tp.Nexthop == nexthop && (cidr == "" || tp.ReachableCidr == cidr) {
+			c.transPeers = append(c.transPeers[:i], c.transPeers[i+1:]...)
+			return fmt.Sprintf("Transitive peering %s->%s has been deleted", source, nexthop), nil
+		}
+	}
+	return nil, fmt.Errorf("transitive peering %s->%s does not exist", source, nexthop)
+}
+
+func (c *Controller) tunnelExists(gw1 string, gw2 string) bool {
+	for _, t := range c.tunnels {
+		if samePair(t.VpcName1, t.VpcName2, gw1, gw2) {
+			return true
+		}
+	}
+	return false
+}
+
+func peerVpcPair(c *Controller, p params) (interface{}, error) {
+	if err := p.require("vpc_name1", "vpc_name2"); err != nil {
+		return nil, err
+	}
+	name1, name2 := p.get("vpc_name1"), p.get("vpc_name2")
+	if name1 == name2 {
+		return nil, fmt.Errorf("gateway %s can not be peered with itself", name1)
+	}
+	for _, name := range []string{name1, name2} {
+		if _, err := c.findGateway(name); err != nil {
+			return nil, err
+		}
+	}
+	if c.tunnelExists(name1, name2) {
+		return nil, fmt.Errorf("gateways %s and %s are already peered", name1, name2)
+	}
+	t := tunnel{
+		VpcName1:        name1,
+		VpcName2:        name2,
+		PeeringState:    "active",
+		PeeringHaStatus: "disabled",
+		PeeringLink:     name1 + "<->" + name2,
+		EnableHA:        "no",
+	}
+	if p.get("ha_enabled") == "yes" {
+		for _, name := range []string{name1, name2} {
+			if _, ok := c.gateways[name+"-hagw"]; !ok {
+				return nil, fmt.Errorf("gateway %s has no HA gateway", name)
+			}
+		}
+		t.PeeringHaStatus = "active"
+		t.EnableHA = "yes"
+	}
+	c.tunnels = append(c.tunnels, t)
+	return fmt.Sprintf("Gateways %s and %s have been peered", name1, name2), nil
+}
+
+func listPeerVpcPairs(c *Controller, p params) (interface{}, error) {
+	return goaviatrix.TunnelResult{PairList: append([]tunnel{}, c.tunnels...)}, nil
+}
+
+func unpeerVpcPair(c *Controller, p params) (interface{}, error) {
+	name1, name2 := p.get("vpc_name1"), p.get("vpc_name2")
+	for i, t := range c.tunnels {
+		if samePair(t.VpcName1, t.VpcName2, name1, name2) {
+			for _, tp := range c.transPeers {
+				if samePair(tp.Source, tp.Nexthop, name1, name2) {
+					return nil, inUse("tunnel", name1+"<->"+name2,
+						[]string{"transitive peering " + tp.Source + "->" + tp.Nexthop})
+				}
+			}
+			c.tunnels = append(c.tunnels[:i], c.tunnels[i+1:]...)
+			return fmt.Sprintf("Gateways %s and %s have been unpeered", name1, name2), nil
+		}
+	}
+	return nil, fmt.Errorf("gateways %s and %s are not peered", name1, name2)
+}
@@ -0,0 +1,231 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+type site2cloud struct {
+	goaviatrix.Site2Cloud
+	backupGwName string
+	dpd          bool
+}
+
+func init() {
+	register(map[string]action{
+		"add_site2cloud":               addSite2Cloud,
+		"list_site2cloud_conn":         listSite2CloudConn,
+		"get_site2cloud_conn_detail":   getSite2CloudConnDetail,
+		"edit_site2cloud_conn":         editSite2CloudConn,
+		"delete_site2cloud_connection": deleteSite2CloudConnection,
+		"enable_dpd_config":            setDeadPeerDetection(true),
+		"disable_dpd_config":           setDeadPeerDetection(false),
+	})
+}
+
+func addSite2Cloud(c *Controller, p params) (interface{}, error) {
+	if err := p.require("vpc_id", "connection_name", "connection_type", "tunnel_type", "primary_cloud_gateway_name",
+		"remote_gateway_ip", "remote_subnet_cidr"); err != nil {
+		return nil, err
+	}
+	name := p.get("connection_name")
+	if c.connectionExists(name) {
+		return nil, fmt.Errorf("connection %s already exists", name)
+	}
+	gw, err := c.findGateway(p.get("primary_cloud_gateway_name"))
+	if err != nil {
+		return nil, err
+	}
+	if vpcIDOf(gw.VpcID) != vpcIDOf(p.get("vpc_id")) {
+		return nil, fmt.Errorf("gateway %s is not in VPC %s", gw.GwName, p.get("vpc_id"))
+	}
+	connType := p.get("connection_type")
+	if connType != "unmapped" && connType != "mapped" {
+		return nil, fmt.Errorf("invalid connection_type: %q", connType)
+	}
+	if connType == "mapped" {
+		if err := p.require("virtual_remote_subnet_cidr", "virtual_local_subnet_cidr"); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &site2cloud{}
+	s.VpcID = p.get("vpc_id")
+	s.TunnelName = name
+	s.ConnType = connType
+	s.TunnelType = p.get("tunnel_type")
+	s.RemoteGwType = p.get("remote_gateway_type")
+	s.GwName = gw.GwName
+	s.RemoteGwIP = p.get("remote_gateway_ip")
+	s.RemoteSubnet = p.get("remote_subnet_cidr")
+	s.LocalSubnet = p.get("local_subnet_cidr")
+	if s.LocalSubnet == "" {
+		s.LocalSubnet = gw.VpcNet
+	}
+	s.RemoteSubnetVirtual = p.get("virtual_remote_subnet_cidr")
+	s.LocalSubnetVirtual = p.get("virtual_local_subnet_cidr")
+	s.HAEnabled = "disabled"
+	// Dead peer detection starts out enabled on new connections.
+	s.dpd = true
+	if p.get("ha_enabled") == "yes" {
+		backup, err := c.findGateway(p.get("backup_gateway_name"))
+		if err != nil {
+			return nil, err
+		}
+		if err := p.require("backup_remote_gateway_ip"); err != nil {
+			return nil, err
+		}
+		s.HAEnabled = "enabled"
+		s.backupGwName = backup.GwName
+		s.RemoteGwIP2 = p.get("backup_remote_gateway_ip")
+	}
+	s.Phase1Auth = orDefault(p.get("phase1_auth"), goaviatrix.Phase1AuthDefault)
+	s.Phase1DhGroups = orDefault(p.get("phase1_dh_group"), goaviatrix.Phase1DhGroupDefault)
+	s.Phase1Encryption = orDefault(p.get("phase1_encryption"), goaviatrix.Phase1EncryptionDefault)
+	s.Phase2Auth = orDefault(p.get("phase2_auth"), goaviatrix.Phase2AuthDefault)
+	s.Phase2DhGroups = orDefault(p.get("phase2_dh_group"), goaviatrix.Phase2DhGroupDefault)
+	s.Phase2Encryption = orDefault(p.get("phase2_encryption"), goaviatrix.Phase2EncryptionDefault)
+	s.SslServerPool = orDefault(p.get("ssl_server_pool"), goaviatrix.SslServerPoolDefault)
+	if p.get("private_route_encryption") == "true" {
+		s.RouteTableList = p.indexed("route_table_list")
+	}
+	c.site2clouds[name] = s
+	return fmt.Sprintf("Site2Cloud connection %s has been created", name), nil
+}
+
+func orDefault(value string, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+func listSite2CloudConn(c *Controller, p params) (interface{}, error) {
+	list := make([]goaviatrix.Site2Cloud, 0, len(c.site2clouds))
+	for _, name := range sortedKeys(c.site2clouds) {
+		s := c.site2clouds[name]
+		list = append(list, goaviatrix.Site2Cloud{
+			VpcID:               s.VpcID,
+			TunnelName:          s.TunnelName,
+			ConnType:            s.ConnType,
+			TunnelType:          s.TunnelType,
+			GwName:              s.GwName,
+			RemoteGwIP:          s.RemoteGwIP,
+			RemoteSubnet:        s.RemoteSubnet,
+			LocalSubnet:         s.LocalSubnet,
+			HAEnabled:           s.HAEnabled,
+			RemoteSubnetVirtual: s.RemoteSubnetVirtual,
+			LocalSubnetVirtual:  s.LocalSubnetVirtual,
+		})
+	}
+	return goaviatrix.Site2CloudConnList{Connections: list}, nil
+}
+
+func getSite2CloudConnDetail(c *Controller, p params) (interface{}, error) {
+	name := p.get("conn_name")
+	if conn, ok := c.vgwConns[name]; ok {
+		return conn.detail(), nil
+	}
+	s, ok := c.site2clouds[name]
+	if !ok || vpcIDOf(s.VpcID) != vpcIDOf(p.get("vpc_id")) {
+		return nil, fmt.Errorf("connection %s does not exist", name)
+	}
+
+	tunnels := []goaviatrix.TunnelInfo{{
+		Status: "up",
+		Name:   s.TunnelName,
+		GwName: s.GwName,
+		PeerIP: s.RemoteGwIP,
+	}}
+	if s.backupGwName != "" {
+		tunnels = append(tunnels, goaviatrix.TunnelInfo{
+			Status: "up",
+			Name:   s.TunnelName,
+			GwName: s.backupGwName,
+			PeerIP: s.RemoteGwIP2,
+		})
+	}
+	dpd := "disable"
+	if s.dpd {
+		dpd = "enable"
+	}
+	detail := goaviatrix.EditSite2CloudConnDetail{
+		VpcID:        []string{s.VpcID},
+		TunnelName:   []string{s.TunnelName},
+		ConnType:     s.ConnType,
+		TunnelType:   []string{s.TunnelType},
+		GwName:       []string{s.GwName},
+		Tunnels:      tunnels,
+		RemoteSubnet: s.RemoteSubnet,
+		LocalSubnet:  s.LocalSubnet,
+		HAEnabled:    s.HAEnabled,
+		PeerType:     s.RemoteGwType,
+		Algorithm: goaviatrix.AlgorithmInfo{
+			Phase1Auth:      []string{s.Phase1Auth},
+			Phase1DhGroups:  []string{s.Phase1DhGroups},
+			Phase1Encrption: []string{s.Phase1Encryption},
+			Phase2Auth:      []string{s.Phase2Auth},
+			Phase2DhGroups:  []string{s.Phase2DhGroups},
+			Phase2Encrption: []string{s.Phase2Encryption},
+		},
+		RouteTableList:          s.RouteTableList,
+		SslServerPool:           []string{s.SslServerPool},
+		DeadPeerDetectionConfig: dpd,
+	}
+	if s.ConnType == "mapped" {
+		detail.RemoteSubnetVirtual = s.RemoteSubnetVirtual
+		detail.LocalSubnetVirtual = s.LocalSubnetVirtual
+	} else {
+		detail.RemoteCidr = s.RemoteSubnet
+		detail.LocalCidr = s.LocalSubnet
+	}
+	return goaviatrix.Site2CloudConnDetailList{Connections: detail}, nil
+}
+
+func (c *Controller) findSite2Cloud(name string, vpcID string) (*site2cloud, error) {
+	s, ok := c.site2clouds[name]
+	if !ok || vpcIDOf(s.VpcID) != vpcIDOf(vpcID) {
+		return nil, fmt.Errorf("connection %s does not exist", name)
+	}
+	return s, nil
+}
+
+func editSite2CloudConn(c *Controller, p params) (interface{}, error) {
+	s, err := c.findSite2Cloud(p.get("conn_name"), p.get("vpc_id"))
+	if err != nil {
+		return nil, err
+	}
+	if err := p.require("cloud_subnet_cidr"); err != nil {
+		return nil, err
+	}
+	switch p.get("network_type") {
+	case "1":
+		s.LocalSubnet = p.get("cloud_subnet_cidr")
+	case "2":
+		s.RemoteSubnet = p.get("cloud_subnet_cidr")
+	default:
+		return nil, fmt.Errorf("invalid network_type: %q", p.get("network_type"))
+	}
+	return fmt.Sprintf("Site2Cloud connection %s has been updated", s.TunnelName), nil
+}
+
+func deleteSite2CloudConnection(c *Controller, p params) (interface{}, error) {
+	s, err := c.findSite2Cloud(p.get("connection_name"), p.get("vpc_id"))
+	if err != nil {
+		return nil, err
+	}
+	delete(c.site2clouds, s.TunnelName)
+	return fmt.Sprintf("Site2Cloud connection %s has been deleted", s.TunnelName), nil
+}
+
+func setDeadPeerDetection(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		s, err := c.findSite2Cloud(p.get("connection_name"), p.get("vpc_id"))
+		if err != nil {
+			return nil, err
+		}
+		s.dpd = enabled
+		return fmt.Sprintf("Dead peer detection has been updated on %s", s.TunnelName), nil
+	}
+}
@@ -0,0 +1,139 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// systemConfig is the controller wide configuration
+type systemConfig struct {
+	httpAccess           bool
+	exceptionRule        bool
+	securityGroupState   string
+	securityGroupAccount string
+}
+
+func defaultSystemConfig() systemConfig {
+	return systemConfig{
+		exceptionRule:      true,
+		securityGroupState: "Disabled",
+	}
+}
+
+func init() {
+	register(map[string]action{
+		"list_version_info":                               listVersionInfo,
+		"upgrade":                                         upgrade,
+		"config_http_access":                              configHTTPAccess,
+		"enable_fqdn_exception_rule":                      setExceptionRule(true),
+		"disable_fqdn_exception_rule":                     setExceptionRule(false),
+		"get_fqdn_exception_rule_status":                  getExceptionRuleStatus,
+		"enable_controller_security_group_management":     enableSecurityGroupManagement,
+		"disable_controller_security_group_management":    disableSecurityGroupManagement,
+		"get_controller_security_group_management_status": getSecurityGroupManagementStatus,
+	})
+}
+
+func listVersionInfo(c *Controller, p params) (interface{}, error) {
+	return goaviatrix.VersionInfo{CurrentVersion: c.CurrentVersion, LatestVersion: c.LatestVersion}, nil
+}
+
+// upgrade moves the controller to the requested release, or to the latest
+// one when no version is given. Downgrades are rejected.
+func upgrade(c *Controller, p params) (interface{}, error) {
+	target := c.LatestVersion
+	if v := p.get("version"); v != "" {
+		target = "UserConnect-" + v
+	}
+	_, want, err := goaviatrix.ParseVersion(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q", p.get("version"))
+	}
+	_, have, err := goaviatrix.ParseVersion(c.CurrentVersion)
+	if err != nil {
+		return nil, err
+	}
+	if want.Major < have.Major || (want.Major == have.Major && want.Minor < have.Minor) {
+		return nil, fmt.Errorf("can not downgrade from %s to %s", c.CurrentVersion, target)
+	}
+	c.CurrentVersion = target
+	return fmt.Sprintf("Controller has been upgraded to %s", target), nil
+}
+
+// serveBackend handles the private backend used before release 3.2 to
+// upgrade the controller. It answers in plain text.
+func (c *Controller) serveBackend(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := r.Form.Get("action")
+	c.calls[name]++
+	if name != "userconnect_release" || !c.sessions[r.Form.Get("CID")] {
+		http.Error(w, "invalid request", http.StatusForbidden)
+		return
+	}
+	c.CurrentVersion = c.LatestVersion
+	fmt.Fprintf(w, "Controller has been upgraded to %s", c.CurrentVersion)
+}
+
+func configHTTPAccess(c *Controller, p params) (interface{}, error) {
+	switch p.get("operation") {
+	case "get":
+		// The controller reports the flag as a Python style tuple.
+		if c.system.httpAccess {
+			return "(True)", nil
+		}
+		return "(False)", nil
+	case "enable":
+		c.system.httpAccess = true
+	case "disable":
+		c.system.httpAccess = false
+	default:
+		return nil, fmt.Errorf("invalid operation: %q", p.get("operation"))
+	}
+	return "HTTP access has been updated", nil
+}
+
+func setExceptionRule(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		c.system.exceptionRule = enabled
+		return "FQDN exception rule has been updated", nil
+	}
+}
+
+func getExceptionRuleStatus(c *Controller, p params) (interface{}, error) {
+	if c.system.exceptionRule {
+		return "enabled", nil
+	}
+	return "disabled", nil
+}
+
+func enableSecurityGroupManagement(c *Controller, p params) (interface{}, error) {
+	name := p.get("access_account_name")
+	acc, ok := c.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("account %s does not exist", name)
+	}
+	if acc.CloudType != 1 {
+		return nil, fmt.Errorf("account %s is not an AWS account", name)
+	}
+	c.system.securityGroupState = "Enabled"
+	c.system.securityGroupAccount = name
+	return "Controller security group management has been enabled", nil
+}
+
+func disableSecurityGroupManagement(c *Controller, p params) (interface{}, error) {
+	c.system.securityGroupState = "Disabled"
+	c.system.securityGroupAccount = ""
+	return "Controller security group management has been disabled", nil
+}
+
+func getSecurityGroupManagementStatus(c *Controller, p params) (interface{}, error) {
+	return goaviatrix.SecurityGroupInfo{
+		State:       c.system.securityGroupState,
+		AccountName: c.system.securityGroupAccount,
+		Response:    fmt.Sprintf("Controller security group management is %s", c.system.securityGroupState),
+	}, nil
+}
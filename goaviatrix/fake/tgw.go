@@ -0,0 +1,425 @@
+package fake
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+const (
+	defaultDomain       = "Default_Domain"
+	sharedServiceDomain = "Shared_Service_Domain"
+	edgeDomain          = "Aviatrix_Edge_Domain"
+)
+
+var defaultDomains = []string{defaultDomain, sharedServiceDomain, edgeDomain}
+
+type tgw struct {
+	name        string
+	accountName string
+	region      string
+	asn         int
+	domains     []string
+	// connections holds both directions of every domain connection.
+	connections map[string][]string
+	attachments []tgwAttachment
+	vpnConns    []*goaviatrix.AwsTgwVpnConnEdit
+}
+
+// tgwAttachment is a VPC attached to a security domain. Transit gateways
+// are attached to the edge domain through their VPC, recorded in gwName.
+type tgwAttachment struct {
+	domain      string
+	vpcID       string
+	accountName string
+	region      string
+	gwName      string
+}
+
+func init() {
+	register(map[string]action{
+		"add_aws_tgw":                             addAwsTgw,
+		"list_tgw_details":                        listTgwDetails,
+		"delete_aws_tgw":                          deleteAwsTgw,
+		"list_route_domain_names":                 listRouteDomainNames,
+		"view_route_domain_details":               viewRouteDomainDetails,
+		"add_route_domain":                        addRouteDomain,
+		"delete_route_domain":                     deleteRouteDomain,
+		"add_connection_between_route_domains":    setDomainConnection(true),
+		"delete_connection_between_route_domains": setDomainConnection(false),
+		"attach_vpc_to_tgw":                       attachVpcToTgw,
+		"detach_vpc_from_tgw":                     detachVpcFromTgw,
+		"list_attached_vpc_names_to_route_domain": listAttachedVpcNamesToRouteDomain,
+		"attach_edge_vpn_to_tgw":                  attachEdgeVpnToTgw,
+		"list_all_tgw_attachments":                listAllTgwAttachments,
+		"detach_vpn_from_tgw":                     detachVpnFromTgw,
+	})
+}
+
+// attachedGateway returns the domain gw is attached to, or "" if it is not.
+func (t *tgw) attachedGateway(gw *gateway) string {
+	for _, a := range t.attachments {
+		if a.gwName == gw.GwName {
+			return a.domain
+		}
+	}
+	return ""
+}
+
+func (t *tgw) hasDomain(name string) bool {
+	return contains(t.domains, name)
+}
+
+func (t *tgw) connect(a string, b string, enabled bool) {
+	if enabled {
+		if !contains(t.connections[a], b) {
+			t.connections[a] = append(t.connections[a], b)
+			t.connections[b] = append(t.connections[b], a)
+		}
+		return
+	}
+	t.connections[a] = remove(t.connections[a], b)
+	t.connections[b] = remove(t.connections[b], a)
+}
+
+func (c *Controller) findTgw(name string) (*tgw, error) {
+	t, ok := c.tgws[name]
+	if !ok {
+		return nil, fmt.Errorf("AWS TGW %s does not exist", name)
+	}
+	return t, nil
+}
+
+func (c *Controller) findDomain(p params) (*tgw, string, error) {
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, "", err
+	}
+	name := p.get("route_domain_name")
+	if !t.hasDomain(name) {
+		return nil, "", fmt.Errorf("security domain %s does not exist in AWS TGW %s", name, t.name)
+	}
+	return t, name, nil
+}
+
+func addAwsTgw(c *Controller, p params) (interface{}, error) {
+	if err := p.require("tgw_name", "account_name", "region", "aws_side_asn"); err != nil {
+		return nil, err
+	}
+	name := p.get("tgw_name")
+	if _, ok := c.tgws[name]; ok {
+		return nil, fmt.Errorf("AWS TGW %s already exists", name)
+	}
+	acc, ok := c.accounts[p.get("account_name")]
+	if !ok {
+		return nil, fmt.Errorf("account %s does not exist", p.get("account_name"))
+	}
+	if acc.CloudType != 1 {
+		return nil, fmt.Errorf("account %s is not an AWS account", acc.AccountName)
+	}
+	asn, err := strconv.Atoi(p.get("aws_side_asn"))
+	if err != nil || asn < 64512 || asn > 4294967294 {
+		return nil, fmt.Errorf("invalid aws_side_asn: %q", p.get("aws_side_asn"))
+	}
+	t := &tgw{
+		name:        name,
+		accountName: acc.AccountName,
+		region:      p.get("region"),
+		asn:         asn,
+		domains:     append([]string{}, defaultDomains...),
+		connections: map[string][]string{},
+	}
+	// The default domains come up fully connected.
+	for i, a := range defaultDomains {
+		for _, b := range defaultDomains[i+1:] {
+			t.connect(a, b, true)
+		}
+	}
+	c.tgws[name] = t
+	return fmt.Sprintf("AWS TGW %s has been created", name), nil
+}
+
+func listTgwDetails(c *Controller, p params) (interface{}, error) {
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, err
+	}
+	return goaviatrix.TGWInfoList{
+		TgwInfo: goaviatrix.TgwInfoDetail{
+			AccountName:     t.accountName,
+			Region:          t.region,
+			AwsSideAsNumber: t.asn,
+		},
+		TgwID: "tgw-" + t.name,
+		Name:  t.name,
+	}, nil
+}
+
+func deleteAwsTgw(c *Controller, p params) (interface{}, error) {
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, err
+	}
+	var users []string
+	for _, a := range t.attachments {
+		users = append(users, "VPC "+a.vpcID)
+	}
+	for _, conn := range t.vpnConns {
+		users = append(users, "VPN connection "+conn.ConnName)
+	}
+	if len(users) != 0 {
+		return nil, inUse("AWS TGW", t.name, users)
+	}
+	delete(c.tgws, t.name)
+	return fmt.Sprintf("AWS TGW %s has been deleted", t.name), nil
+}
+
+func listRouteDomainNames(c *Controller, p params) (interface{}, error) {
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, err
+	}
+	// The edge domain is reported separately by the controller.
+	return remove(t.domains, edgeDomain), nil
+}
+
+func viewRouteDomainDetails(c *Controller, p params) (interface{}, error) {
+	t, name, err := c.findDomain(p)
+	if err != nil {
+		return nil, err
+	}
+	attached := []goaviatrix.AttachedVPCDetail{}
+	for _, a := range t.attachments {
+		if a.domain == name {
+			attached = append(attached, goaviatrix.AttachedVPCDetail{
+				TgwName:     t.name,
+				Region:      a.region,
+				VPCName:     a.vpcID,
+				RouteDomain: name,
+				VPCId:       a.vpcID,
+				AccountName: a.accountName,
+			})
+		}
+	}
+	return []goaviatrix.RouteDomainDetail{{
+		Name:                 name,
+		ConnectedRouteDomain: append([]string{}, t.connections[name]...),
+		AttachedVPC:          attached,
+	}}, nil
+}
+
+func addRouteDomain(c *Controller, p params) (interface{}, error) {
+	if err := p.require("tgw_name", "route_domain_name"); err != nil {
+		return nil, err
+	}
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("route_domain_name")
+	if t.hasDomain(name) {
+		return nil, fmt.Errorf("security domain %s already exists in AWS TGW %s", name, t.name)
+	}
+	t.domains = append(t.domains, name)
+	return fmt.Sprintf("Security domain %s has been created", name), nil
+}
+
+func deleteRouteDomain(c *Controller, p params) (interface{}, error) {
+	t, name, err := c.findDomain(p)
+	if err != nil {
+		return nil, err
+	}
+	if contains(defaultDomains, name) {
+		return nil, fmt.Errorf("default security domain %s can not be deleted", name)
+	}
+	var users []string
+	for _, a := range t.attachments {
+		if a.domain == name {
+			users = append(users, "VPC "+a.vpcID)
+		}
+	}
+	for _, conn := range t.vpnConns {
+		if conn.RouteDomainName == name {
+			users = append(users, "VPN connection "+conn.ConnName)
+		}
+	}
+	if len(users) != 0 {
+		return nil, inUse("security domain", name, users)
+	}
+	for _, other := range append([]string{}, t.connections[name]...) {
+		t.connect(name, other, false)
+	}
+	delete(t.connections, name)
+	t.domains = remove(t.domains, name)
+	return fmt.Sprintf("Security domain %s has been deleted", name), nil
+}
+
+func setDomainConnection(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		t, err := c.findTgw(p.get("tgw_name"))
+		if err != nil {
+			return nil, err
+		}
+		src := p.get("source_route_domain_name")
+		dst := p.get("destination_route_domain_name")
+		for _, name := range []string{src, dst} {
+			if !t.hasDomain(name) {
+				return nil, fmt.Errorf("security domain %s does not exist in AWS TGW %s", name, t.name)
+			}
+		}
+		if src == dst {
+			return nil, fmt.Errorf("security domain %s can not be connected to itself", src)
+		}
+		if enabled && contains(t.connections[src], dst) {
+			return nil, fmt.Errorf("security domains %s and %s are already connected", src, dst)
+		}
+		if !enabled && !contains(t.connections[src], dst) {
+			return nil, fmt.Errorf("security domains %s and %s are not connected", src, dst)
+		}
+		t.connect(src, dst, enabled)
+		return fmt.Sprintf("Connection between %s and %s has been updated", src, dst), nil
+	}
+}
+
+func attachVpcToTgw(c *Controller, p params) (interface{}, error) {
+	if err := p.require("tgw_name", "vpc_name", "vpc_account_name", "route_domain_name"); err != nil {
+		return nil, err
+	}
+	t, name, err := c.findDomain(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.requireAccount(p.get("vpc_account_name")); err != nil {
+		return nil, err
+	}
+	vpcID := vpcIDOf(p.get("vpc_name"))
+	for _, other := range c.tgws {
+		for _, a := range other.attachments {
+			if a.vpcID == vpcID {
+				return nil, fmt.Errorf("VPC %s is already attached to AWS TGW %s", vpcID, other.name)
+			}
+		}
+	}
+	a := tgwAttachment{
+		domain:      name,
+		vpcID:       vpcID,
+		accountName: p.get("vpc_account_name"),
+		region:      orDefault(p.get("region"), t.region),
+	}
+	if name == edgeDomain {
+		gw, err := c.findKind(p.get("gateway_name"), kindTransit)
+		if err != nil {
+			return nil, err
+		}
+		if vpcIDOf(gw.VpcID) != vpcID {
+			return nil, fmt.Errorf("transit gateway %s is not in VPC %s", gw.GwName, vpcID)
+		}
+		if !gw.EnableHybridConnection {
+			return nil, fmt.Errorf("transit gateway %s has not enabled tgw interface", gw.GwName)
+		}
+		a.gwName = gw.GwName
+	} else if p.get("gateway_name") != "" {
+		return nil, fmt.Errorf("only the %s accepts Aviatrix transit gateways", edgeDomain)
+	}
+	t.attachments = append(t.attachments, a)
+	return fmt.Sprintf("VPC %s has been attached to AWS TGW %s", vpcID, t.name), nil
+}
+
+func detachVpcFromTgw(c *Controller, p params) (interface{}, error) {
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, err
+	}
+	vpcID := vpcIDOf(p.get("vpc_name"))
+	for i, a := range t.attachments {
+		if a.vpcID == vpcID {
+			t.attachments = append(t.attachments[:i], t.attachments[i+1:]...)
+			return fmt.Sprintf("VPC %s has been detached from AWS TGW %s", vpcID, t.name), nil
+		}
+	}
+	return nil, fmt.Errorf("VPC %s is not attached to AWS TGW %s", vpcID, t.name)
+}
+
+func listAttachedVpcNamesToRouteDomain(c *Controller, p params) (interface{}, error) {
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, a := range t.attachments {
+		if p.get("route_domain_name") == "" || a.domain == p.get("route_domain_name") {
+			names = append(names, a.vpcID+"~~"+orDefault(a.gwName, a.vpcID))
+		}
+	}
+	return names, nil
+}
+
+func attachEdgeVpnToTgw(c *Controller, p params) (interface{}, error) {
+	if err := p.require("tgw_name", "route_domain_name", "connection_name", "public_ip"); err != nil {
+		return nil, err
+	}
+	// Static connections name their remote CIDRs, BGP ones the remote ASN.
+	if p.get("onprem_asn") == "" && p.get("remote_cidr") == "" {
+		return nil, fmt.Errorf("either onprem_asn or remote_cidr is required")
+	}
+	t, domain, err := c.findDomain(p)
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("connection_name")
+	for _, conn := range t.vpnConns {
+		if conn.ConnName == name {
+			return nil, fmt.Errorf("VPN connection %s already exists in AWS TGW %s", name, t.name)
+		}
+	}
+	conn := &goaviatrix.AwsTgwVpnConnEdit{
+		TgwName:          t.name,
+		RouteDomainName:  domain,
+		ConnName:         name,
+		PublicIP:         p.get("public_ip"),
+		OnpremASN:        p.get("onprem_asn"),
+		RemoteCIDR:       splitList(p.get("remote_cidr")),
+		VpnID:            c.id("vpn-"),
+		InsideIpCIDRTun1: p.get("inside_ip_cidr_tun_1"),
+		InsideIpCIDRTun2: p.get("inside_ip_cidr_tun_2"),
+		PreSharedKeyTun1: p.get("pre_shared_key_tun_1"),
+		PreSharedKeyTun2: p.get("pre_shared_key_tun_2"),
+	}
+	t.vpnConns = append(t.vpnConns, conn)
+	return map[string]string{
+		"text":   fmt.Sprintf("VPN connection %s has been attached to AWS TGW %s", name, t.name),
+		"vpn_id": conn.VpnID,
+	}, nil
+}
+
+func listAllTgwAttachments(c *Controller, p params) (interface{}, error) {
+	if rt := p.get("resource_type"); rt != "" && rt != "vpn" {
+		return nil, fmt.Errorf("unsupported resource_type: %q", rt)
+	}
+	conns := []goaviatrix.AwsTgwVpnConnEdit{}
+	t, ok := c.tgws[p.get("tgw_name")]
+	if !ok {
+		// An unknown TGW simply has no attachments.
+		return conns, nil
+	}
+	for _, conn := range t.vpnConns {
+		conns = append(conns, *conn)
+	}
+	return conns, nil
+}
+
+func detachVpnFromTgw(c *Controller, p params) (interface{}, error) {
+	t, err := c.findTgw(p.get("tgw_name"))
+	if err != nil {
+		return nil, err
+	}
+	id := p.get("vpn_id")
+	for i, conn := range t.vpnConns {
+		if conn.VpnID == id {
+			t.vpnConns = append(t.vpnConns[:i], t.vpnConns[i+1:]...)
+			return fmt.Sprintf("VPN connection %s has been detached from AWS TGW %s", conn.ConnName, t.name), nil
+		}
+	}
+	return nil, fmt.Errorf("VPN %s is not attached to AWS TGW %s", id, t.name)
+}
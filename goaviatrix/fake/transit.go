@@ -0,0 +1,388 @@
+package fake
+
+import (
+	"fmt"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+type transitPeering = goaviatrix.TransitGatewayPeering
+
+type vgwConn struct {
+	goaviatrix.VGWConn
+	manualSpokeCidrs []string
+}
+
+func init() {
+	register(map[string]action{
+		"create_transit_gw":                            createTransitGw,
+		"enable_transit_ha":                            enableHa(kindTransit),
+		"enable_transit_gateway_interface_to_aws_tgw":  setHybridConnection(true),
+		"disable_transit_gateway_interface_to_aws_tgw": setHybridConnection(false),
+		"enable_connected_transit_on_gateway":          setConnectedTransit(true),
+		"disable_connected_transit_on_gateway":         setConnectedTransit(false),
+		"enable_gateway_firenet_interfaces":            setFireNetInterfaces(true),
+		"disable_gateway_firenet_interfaces":           setFireNetInterfaces(false),
+
+		"create_spoke_gw":              createSpokeGw,
+		"enable_spoke_ha":              enableHa(kindSpoke),
+		"attach_spoke_to_transit_gw":   attachSpokeToTransitGw,
+		"detach_spoke_from_transit_gw": detachSpokeFromTransitGw,
+
+		"create_inter_transit_gateway_peering": createInterTransitGatewayPeering,
+		"list_inter_transit_gateway_peering":   listInterTransitGatewayPeering,
+		"delete_inter_transit_gateway_peering": deleteInterTransitGatewayPeering,
+
+		"connect_transit_gw_to_vgw":                    connectTransitGwToVgw,
+		"list_vgw_connections":                         listVgwConnections,
+		"disconnect_transit_gw_from_vgw":               disconnectTransitGwFromVgw,
+		"enable_advertise_transit_cidr":                setAdvertiseTransitCidr(true),
+		"disable_advertise_transit_cidr":               setAdvertiseTransitCidr(false),
+		"set_bgp_manual_spoke_advertised_networks":     setBgpManualSpokeAdvertisedNetworks,
+		"disable_bgp_manual_spoke_advertised_networks": disableBgpManualSpokeAdvertisedNetworks,
+	})
+}
+
+// findKind returns the gateway named name if it is of the given kind
+func (c *Controller) findKind(name string, kind string) (*gateway, error) {
+	gw, err := c.findGateway(name)
+	if err != nil {
+		return nil, err
+	}
+	if gw.kind != kind || gw.primary != "" {
+		return nil, fmt.Errorf("gateway %s is not a %s gateway", name, kind)
+	}
+	return gw, nil
+}
+
+// launch creates a transit or spoke gateway from the create_transit_gw and
+// create_spoke_gw parameters, which are the same for both.
+func (c *Controller) launch(kind string, p params) (*gateway, error) {
+	ct, err := cloudType(p)
+	if err != nil {
+		return nil, err
+	}
+	vpcID := p.get("vpc_id")
+	if ct == 8 && vpcID == "" {
+		vpcID = p.get("vnet_and_resource_group_names")
+	}
+	region := p.get("region")
+	if ct == 4 && p.get("zone") != "" {
+		region = p.get("zone")
+	}
+	subnet := p.get("public_subnet")
+	zone := ""
+	insane := p.get("insane_mode") == "on"
+	if insane {
+		if ct != 1 {
+			return nil, fmt.Errorf("insane mode is only supported for AWS")
+		}
+		var ok bool
+		if subnet, zone, ok = splitInsaneSubnet(subnet); !ok {
+			return nil, fmt.Errorf("invalid public_subnet %q for insane mode, must be <cidr>~~<az>", p.get("public_subnet"))
+		}
+	}
+	gw, err := c.newGateway(kind, p.get("gw_name"), p.get("account_name"), ct, vpcID, p.get("gw_size"), subnet, region)
+	if err != nil {
+		return nil, err
+	}
+	if insane {
+		gw.InsaneMode = "yes"
+		gw.GatewayZone = zone
+	}
+	gw.EnableNat = yesNo(p.get("nat_enabled") == "yes")
+	return gw, nil
+}
+
+// splitInsaneSubnet splits an insane mode subnet of the form <cidr>~~<az>
+func splitInsaneSubnet(subnet string) (string, string, bool) {
+	for i := 0; i+2 <= len(subnet); i++ {
+		if subnet[i:i+2] == "~~" {
+			return subnet[:i], subnet[i+2:], i > 0 && i+2 < len(subnet)
+		}
+	}
+	return subnet, "", false
+}
+
+func createTransitGw(c *Controller, p params) (interface{}, error) {
+	gw, err := c.launch(kindTransit, p)
+	if err != nil {
+		return nil, err
+	}
+	if p.get("enable_hybrid_connection") == "true" {
+		if gw.CloudType != 1 {
+			return nil, fmt.Errorf("hybrid connection is only supported for AWS")
+		}
+		gw.EnableHybridConnection = true
+	}
+	gw.ConnectedTransit = yesNo(p.get("connected_transit") == "yes")
+	c.gateways[gw.GwName] = gw
+	c.tagResource("gw", gw.GwName, tagMap(p.get("tags")))
+	return fmt.Sprintf("Transit gateway %s has been created", gw.GwName), nil
+}
+
+func createSpokeGw(c *Controller, p params) (interface{}, error) {
+	gw, err := c.launch(kindSpoke, p)
+	if err != nil {
+		return nil, err
+	}
+	if p.get("single_az_ha") == "enabled" {
+		gw.SingleAZ = "yes"
+	}
+	c.gateways[gw.GwName] = gw
+	c.tagResource("gw", gw.GwName, tagMap(p.get("tags")))
+	return fmt.Sprintf("Spoke gateway %s has been created", gw.GwName), nil
+}
+
+func enableHa(kind string) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		gw, err := c.findKind(p.get("gw_name"), kind)
+		if err != nil {
+			return nil, err
+		}
+		subnet := p.get("public_subnet")
+		if gw.InsaneMode == "yes" {
+			if _, _, ok := splitInsaneSubnet(subnet); !ok {
+				return nil, fmt.Errorf("invalid public_subnet %q for insane mode, must be <cidr>~~<az>", subnet)
+			}
+		}
+		if err := c.addHaGateway(gw, subnet, p.get("new_zone"), ""); err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("HA gateway %s-hagw has been created", gw.GwName), nil
+	}
+}
+
+func setHybridConnection(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		gw, err := c.findKind(p.get("gateway_name"), kindTransit)
+		if err != nil {
+			return nil, err
+		}
+		if gw.CloudType != 1 {
+			return nil, fmt.Errorf("hybrid connection is only supported for AWS")
+		}
+		if enabled && gw.EnableHybridConnection {
+			return nil, fmt.Errorf("gateway %s has already enabled tgw interface", gw.GwName)
+		}
+		if !enabled {
+			for _, t := range c.tgws {
+				if t.attachedGateway(gw) != "" {
+					return nil, inUse("gateway", gw.GwName, []string{"AWS TGW " + t.name})
+				}
+			}
+		}
+		gw.EnableHybridConnection = enabled
+		return fmt.Sprintf("TGW interface has been updated on gateway %s", gw.GwName), nil
+	}
+}
+
+func setConnectedTransit(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		gw, err := c.findKind(p.get("gateway_name"), kindTransit)
+		if err != nil {
+			return nil, err
+		}
+		gw.ConnectedTransit = yesNo(enabled)
+		return fmt.Sprintf("Connected transit has been updated on gateway %s", gw.GwName), nil
+	}
+}
+
+func setFireNetInterfaces(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		name := p.get("gateway_name")
+		if !enabled {
+			name = p.get("gateway")
+		}
+		gw, err := c.findKind(name, kindTransit)
+		if err != nil {
+			return nil, err
+		}
+		gw.dmz = enabled
+		return fmt.Sprintf("FireNet interfaces have been updated on gateway %s", gw.GwName), nil
+	}
+}
+
+func attachSpokeToTransitGw(c *Controller, p params) (interface{}, error) {
+	spoke, err := c.findKind(p.get("spoke_gw"), kindSpoke)
+	if err != nil {
+		return nil, err
+	}
+	transit, err := c.findKind(p.get("transit_gw"), kindTransit)
+	if err != nil {
+		return nil, err
+	}
+	if spoke.SpokeVpc == "yes" {
+		return nil, fmt.Errorf("spoke %s is already attached to transit %s", spoke.GwName, spoke.TransitGwName)
+	}
+	spoke.SpokeVpc = "yes"
+	spoke.TransitGwName = transit.GwName
+	return fmt.Sprintf("Spoke %s has been attached to transit %s", spoke.GwName, transit.GwName), nil
+}
+
+func detachSpokeFromTransitGw(c *Controller, p params) (interface{}, error) {
+	spoke, err := c.findKind(p.get("spoke_gw"), kindSpoke)
+	if err != nil {
+		return nil, err
+	}
+	if spoke.SpokeVpc != "yes" {
+		return nil, fmt.Errorf("spoke %s has not joined to any transit", spoke.GwName)
+	}
+	spoke.SpokeVpc = "no"
+	spoke.TransitGwName = ""
+	return fmt.Sprintf("Spoke %s has been detached", spoke.GwName), nil
+}
+
+// transitPeeringIndex returns the index of the peering between gw1 and gw2 in
+// either direction, or -1.
+func (c *Controller) transitPeeringIndex(gw1 string, gw2 string) int {
+	for i, tp := range c.transitPeerings {
+		if tp.TransitGatewayName1 == gw1 && tp.TransitGatewayName2 == gw2 ||
+			tp.TransitGatewayName1 == gw2 && tp.TransitGatewayName2 == gw1 {
+			return i
+		}
+	}
+	return -1
+}
+
+func createInterTransitGatewayPeering(c *Controller, p params) (interface{}, error) {
+	if err := p.require("gateway1", "gateway2"); err != nil {
+		return nil, err
+	}
+	gw1, gw2 := p.get("gateway1"), p.get("gateway2")
+	if gw1 == gw2 {
+		return nil, fmt.Errorf("invalid peering of gateway %s with itself", gw1)
+	}
+	for _, name := range []string{gw1, gw2} {
+		if _, err := c.findKind(name, kindTransit); err != nil {
+			return nil, err
+		}
+	}
+	if c.transitPeeringIndex(gw1, gw2) >= 0 {
+		return nil, fmt.Errorf("peering between %s and %s already exists", gw1, gw2)
+	}
+	c.transitPeerings = append(c.transitPeerings, transitPeering{TransitGatewayName1: gw1, TransitGatewayName2: gw2})
+	return fmt.Sprintf("Transit gateways %s and %s have been peered", gw1, gw2), nil
+}
+
+func listInterTransitGatewayPeering(c *Controller, p params) (interface{}, error) {
+	list := make([][]transitPeering, 0, len(c.transitPeerings))
+	for _, tp := range c.transitPeerings {
+		list = append(list, []transitPeering{tp})
+	}
+	return list, nil
+}
+
+func deleteInterTransitGatewayPeering(c *Controller, p params) (interface{}, error) {
+	gw1, gw2 := p.get("gateway1"), p.get("gateway2")
+	i := c.transitPeeringIndex(gw1, gw2)
+	if i < 0 {
+		return nil, fmt.Errorf("peering between %s and %s does not exist", gw1, gw2)
+	}
+	c.transitPeerings = append(c.transitPeerings[:i], c.transitPeerings[i+1:]...)
+	return fmt.Sprintf("Peering between %s and %s has been deleted", gw1, gw2), nil
+}
+
+func connectTransitGwToVgw(c *Controller, p params) (interface{}, error) {
+	if err := p.require("vpc_id", "connection_name", "transit_gw", "vgw_id", "bgp_local_as_number"); err != nil {
+		return nil, err
+	}
+	name := p.get("connection_name")
+	if c.connectionExists(name) {
+		return nil, fmt.Errorf("connection %s already exists", name)
+	}
+	transit, err := c.findKind(p.get("transit_gw"), kindTransit)
+	if err != nil {
+		return nil, err
+	}
+	if vpcIDOf(transit.VpcID) != vpcIDOf(p.get("vpc_id")) {
+		return nil, fmt.Errorf("transit gateway %s is not in VPC %s", transit.GwName, p.get("vpc_id"))
+	}
+	c.vgwConns[name] = &vgwConn{VGWConn: goaviatrix.VGWConn{
+		ConnName:      name,
+		GwName:        transit.GwName,
+		VPCId:         p.get("vpc_id"),
+		BgpVGWId:      p.get("vgw_id"),
+		BgpLocalAsNum: p.get("bgp_local_as_number"),
+	}}
+	return fmt.Sprintf("Connection %s has been created", name), nil
+}
+
+// connectionExists reports whether a site2cloud or VGW connection is named
+// name. Both share one namespace on the controller.
+func (c *Controller) connectionExists(name string) bool {
+	_, vgw := c.vgwConns[name]
+	_, s2c := c.site2clouds[name]
+	return vgw || s2c
+}
+
+func listVgwConnections(c *Controller, p params) (interface{}, error) {
+	return sortedKeys(c.vgwConns), nil
+}
+
+func (c *Controller) findVgwConn(p params) (*vgwConn, error) {
+	name := p.get("connection_name")
+	conn, ok := c.vgwConns[name]
+	if !ok || vpcIDOf(conn.VPCId) != vpcIDOf(p.get("vpc_id")) {
+		return nil, fmt.Errorf("connection %s does not exist", name)
+	}
+	return conn, nil
+}
+
+func disconnectTransitGwFromVgw(c *Controller, p params) (interface{}, error) {
+	conn, err := c.findVgwConn(p)
+	if err != nil {
+		return nil, err
+	}
+	delete(c.vgwConns, conn.ConnName)
+	return fmt.Sprintf("Connection %s has been deleted", conn.ConnName), nil
+}
+
+func setAdvertiseTransitCidr(enabled bool) action {
+	return func(c *Controller, p params) (interface{}, error) {
+		conn, err := c.findVgwConn(p)
+		if err != nil {
+			return nil, err
+		}
+		conn.EnableAdvertiseTransitCidr = enabled
+		return fmt.Sprintf("Advertise transit CIDR has been updated on %s", conn.ConnName), nil
+	}
+}
+
+func setBgpManualSpokeAdvertisedNetworks(c *Controller, p params) (interface{}, error) {
+	conn, err := c.findVgwConn(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.require("cidr"); err != nil {
+		return nil, err
+	}
+	conn.manualSpokeCidrs = splitList(p.get("cidr"))
+	return fmt.Sprintf("Manual spoke advertised networks have been set on %s", conn.ConnName), nil
+}
+
+func disableBgpManualSpokeAdvertisedNetworks(c *Controller, p params) (interface{}, error) {
+	conn, err := c.findVgwConn(p)
+	if err != nil {
+		return nil, err
+	}
+	conn.manualSpokeCidrs = nil
+	return fmt.Sprintf("Manual spoke advertised networks have been disabled on %s", conn.ConnName), nil
+}
+
+// vgwConnDetail renders conn the way get_site2cloud_conn_detail does
+func (conn *vgwConn) detail() interface{} {
+	spokeCidrs := [][]string{}
+	if len(conn.manualSpokeCidrs) != 0 {
+		spokeCidrs = append(spokeCidrs, conn.manualSpokeCidrs)
+	}
+	return goaviatrix.VGWConnDetail{Connections: goaviatrix.ConnectionDetail{
+		ConnName:                     []string{conn.ConnName},
+		GwName:                       []string{conn.GwName},
+		VPCId:                        []string{conn.VPCId},
+		BgpVGWId:                     []string{conn.BgpVGWId},
+		BgpLocalAsNum:                []string{conn.BgpLocalAsNum},
+		AdvertiseTransitCidr:         yesNo(conn.EnableAdvertiseTransitCidr),
+		BgpManualSpokeAdvertiseCidrs: spokeCidrs,
+	}}
+}
@@ -0,0 +1,104 @@
+package fake
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+type vpc struct {
+	goaviatrix.VpcEdit
+}
+
+func init() {
+	register(map[string]action{
+		"create_custom_vpc": createCustomVpc,
+		"list_custom_vpcs":  listCustomVpcs,
+		"delete_custom_vpc": deleteCustomVpc,
+	})
+}
+
+func createCustomVpc(c *Controller, p params) (interface{}, error) {
+	if err := p.require("cloud_type", "account_name", "region", "pool_name", "vpc_cidr"); err != nil {
+		return nil, err
+	}
+	ct, err := cloudType(p)
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("pool_name")
+	if _, ok := c.vpcs[name]; ok {
+		return nil, fmt.Errorf("VPC %s already exists", name)
+	}
+	if err := c.requireAccount(p.get("account_name")); err != nil {
+		return nil, err
+	}
+	_, cidr, err := net.ParseCIDR(p.get("vpc_cidr"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid vpc_cidr %q", p.get("vpc_cidr"))
+	}
+	transit := p.get("aviatrix_transit_vpc") == "yes"
+	firenet := p.get("aviatrix_firenet_vpc") == "yes"
+	if transit && firenet {
+		return nil, fmt.Errorf("aviatrix_transit_vpc and aviatrix_firenet_vpc can not both be enabled")
+	}
+
+	v := &vpc{goaviatrix.VpcEdit{
+		CloudType:          ct,
+		AccountName:        p.get("account_name"),
+		Region:             p.get("region"),
+		Name:               name,
+		Cidr:               p.get("vpc_cidr"),
+		AviatrixTransitVpc: transit,
+		AviatrixFireNetVpc: firenet,
+		VpcID:              []string{c.id("vpc-")},
+	}}
+	// Split the first /24s of the VPC into a public and a private subnet,
+	// as the controller does for a VPC with a single availability zone.
+	base := cidr.IP.To4()
+	for i, kind := range []string{"Public", "Private"} {
+		if base == nil {
+			break
+		}
+		subnet := net.IPv4(base[0], base[1], base[2]+byte(i), 0)
+		v.Subnets = append(v.Subnets, goaviatrix.SubnetInfo{
+			Cidr: subnet.String() + "/24",
+			Name: fmt.Sprintf("%s-%s-subnet", name, kind),
+		})
+	}
+	c.vpcs[name] = v
+	c.tagResource("vpc", v.VpcID[0], nil)
+	return fmt.Sprintf("VPC %s has been created", name), nil
+}
+
+func listCustomVpcs(c *Controller, p params) (interface{}, error) {
+	list := make([]goaviatrix.VpcEdit, 0, len(c.vpcs))
+	for _, name := range sortedKeys(c.vpcs) {
+		list = append(list, c.vpcs[name].VpcEdit)
+	}
+	return goaviatrix.AllVpcPoolVpcListResp{AllVpcPoolVpcList: list}, nil
+}
+
+func deleteCustomVpc(c *Controller, p params) (interface{}, error) {
+	name := p.get("pool_name")
+	v, ok := c.vpcs[name]
+	if !ok {
+		return nil, fmt.Errorf("VPC %s does not exist", name)
+	}
+	if v.AccountName != p.get("account_name") {
+		return nil, fmt.Errorf("VPC %s does not exist in account %s", name, p.get("account_name"))
+	}
+	var users []string
+	for _, gw := range c.gateways {
+		if vpcIDOf(gw.VpcID) == v.VpcID[0] {
+			users = append(users, "gateway "+gw.GwName)
+		}
+	}
+	if len(users) != 0 {
+		return nil, inUse("VPC", name, users)
+	}
+	delete(c.vpcs, name)
+	delete(c.tags, "vpc/"+v.VpcID[0])
+	return fmt.Sprintf("VPC %s has been deleted", name), nil
+}
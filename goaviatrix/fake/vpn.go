@@ -0,0 +1,250 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+type vpnUser struct {
+	goaviatrix.VPNUser
+}
+
+type profile struct {
+	name       string
+	basePolicy string
+	rules      []goaviatrix.ProfileRule
+	users      []string
+}
+
+func init() {
+	register(map[string]action{
+		"add_vpn_user":            addVpnUser,
+		"get_vpn_user_by_name":    getVpnUserByName,
+		"delete_vpn_user":         deleteVpnUser,
+		"add_user_profile":        addUserProfile,
+		"update_profile_policy":   updateProfilePolicy,
+		"list_profile_policies":   listProfilePolicies,
+		"list_user_profile_names": listUserProfileNames,
+		"add_profile_member":      addProfileMember,
+		"del_profile_member":      delProfileMember,
+		"get_profile_base_policy": getProfileBasePolicy,
+		"del_user_profile":        delUserProfile,
+		"list_vpn_user_xlr":       listVpnUserXlr,
+		"update_vpn_user_xlr":     updateVpnUserXlr,
+	})
+}
+
+func addVpnUser(c *Controller, p params) (interface{}, error) {
+	if err := p.require("vpc_id", "username", "lb_name"); err != nil {
+		return nil, err
+	}
+	name := p.get("username")
+	if _, ok := c.vpnUsers[name]; ok {
+		return nil, fmt.Errorf("VPN user %s already exists", name)
+	}
+	gws := c.vpnGateways(p.get("vpc_id"), p.get("lb_name"))
+	if len(gws) == 0 {
+		return nil, fmt.Errorf("VPN gateway %s does not exist in VPC %s", p.get("lb_name"), p.get("vpc_id"))
+	}
+	if p.get("saml_endpoint") == "" && gws[0].AuthMethod == "saml" {
+		return nil, fmt.Errorf("saml_endpoint is required for SAML VPN gateway %s", p.get("lb_name"))
+	}
+	c.vpnUsers[name] = &vpnUser{goaviatrix.VPNUser{
+		VpcID:        p.get("vpc_id"),
+		GwName:       p.get("lb_name"),
+		UserName:     name,
+		UserEmail:    p.get("user_email"),
+		SamlEndpoint: p.get("saml_endpoint"),
+	}}
+	if p.get("user_email") != "" {
+		// The controller reports mailing the certificate as part of the reason.
+		return nil, fmt.Errorf("Sending VPN certificates to email %s", p.get("user_email"))
+	}
+	return fmt.Sprintf("VPN user %s has been added", name), nil
+}
+
+func getVpnUserByName(c *Controller, p params) (interface{}, error) {
+	u, ok := c.vpnUsers[p.get("username")]
+	if !ok {
+		return nil, fmt.Errorf("Invalid VPN username %s", p.get("username"))
+	}
+	return goaviatrix.VPNUserInfo{VpnUser: u.VPNUser}, nil
+}
+
+func deleteVpnUser(c *Controller, p params) (interface{}, error) {
+	name := p.get("username")
+	u, ok := c.vpnUsers[name]
+	if !ok || vpcIDOf(u.VpcID) != vpcIDOf(p.get("vpc_id")) {
+		return nil, fmt.Errorf("Invalid VPN username %s", name)
+	}
+	var users []string
+	for _, pname := range sortedKeys(c.profiles) {
+		if contains(c.profiles[pname].users, name) {
+			users = append(users, "profile "+pname)
+		}
+	}
+	if len(users) != 0 {
+		return nil, inUse("VPN user", name, users)
+	}
+	delete(c.vpnUsers, name)
+	return fmt.Sprintf("VPN user %s has been deleted", name), nil
+}
+
+func (c *Controller) findProfile(name string) (*profile, error) {
+	prof, ok := c.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %s does not exist", name)
+	}
+	return prof, nil
+}
+
+func addUserProfile(c *Controller, p params) (interface{}, error) {
+	if err := p.require("profile_name"); err != nil {
+		return nil, err
+	}
+	name := p.get("profile_name")
+	if _, ok := c.profiles[name]; ok {
+		return nil, fmt.Errorf("profile %s already exists", name)
+	}
+	base := orDefault(p.get("base_policy"), "deny_all")
+	if base != "allow_all" && base != "deny_all" {
+		return nil, fmt.Errorf("invalid base_policy: %q", base)
+	}
+	c.profiles[name] = &profile{name: name, basePolicy: base, rules: []goaviatrix.ProfileRule{}}
+	return fmt.Sprintf("Profile %s has been added", name), nil
+}
+
+func updateProfilePolicy(c *Controller, p params) (interface{}, error) {
+	prof, err := c.findProfile(p.get("profile_name"))
+	if err != nil {
+		return nil, err
+	}
+	rules := []goaviatrix.ProfileRule{}
+	if raw := p.get("policy"); raw != "" && raw != "null" {
+		if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+			return nil, fmt.Errorf("invalid policy: %s", err)
+		}
+	}
+	for _, rule := range rules {
+		if rule.Action != "allow" && rule.Action != "deny" {
+			return nil, fmt.Errorf("invalid action: %q", rule.Action)
+		}
+	}
+	prof.rules = rules
+	return fmt.Sprintf("Policies of profile %s have been updated", prof.name), nil
+}
+
+func listProfilePolicies(c *Controller, p params) (interface{}, error) {
+	prof, err := c.findProfile(p.get("profile_name"))
+	if err != nil {
+		return nil, err
+	}
+	return prof.rules, nil
+}
+
+func listUserProfileNames(c *Controller, p params) (interface{}, error) {
+	names := map[string][]string{}
+	for name, prof := range c.profiles {
+		names[name] = append([]string{}, prof.users...)
+	}
+	return names, nil
+}
+
+func addProfileMember(c *Controller, p params) (interface{}, error) {
+	prof, err := c.findProfile(p.get("profile_name"))
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("username")
+	if _, ok := c.vpnUsers[name]; !ok {
+		return nil, fmt.Errorf("Invalid VPN username %s", name)
+	}
+	if contains(prof.users, name) {
+		return nil, fmt.Errorf("VPN user %s is already a member of profile %s", name, prof.name)
+	}
+	prof.users = append(prof.users, name)
+	return fmt.Sprintf("VPN user %s has been added to profile %s", name, prof.name), nil
+}
+
+func delProfileMember(c *Controller, p params) (interface{}, error) {
+	prof, err := c.findProfile(p.get("profile_name"))
+	if err != nil {
+		return nil, err
+	}
+	name := p.get("username")
+	if !contains(prof.users, name) {
+		return nil, fmt.Errorf("VPN user %s is not a member of profile %s", name, prof.name)
+	}
+	prof.users = remove(prof.users, name)
+	return fmt.Sprintf("VPN user %s has been removed from profile %s", name, prof.name), nil
+}
+
+func getProfileBasePolicy(c *Controller, p params) (interface{}, error) {
+	prof, err := c.findProfile(p.get("profile_name"))
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Profile %s base policy is %s", prof.name, strings.Replace(prof.basePolicy, "_", " ", 1)), nil
+}
+
+func delUserProfile(c *Controller, p params) (interface{}, error) {
+	prof, err := c.findProfile(p.get("profile_name"))
+	if err != nil {
+		return nil, err
+	}
+	if len(prof.users) != 0 {
+		var users []string
+		for _, u := range prof.users {
+			users = append(users, "VPN user "+u)
+		}
+		return nil, inUse("profile", prof.name, users)
+	}
+	delete(c.profiles, prof.name)
+	return fmt.Sprintf("Profile %s has been deleted", prof.name), nil
+}
+
+// elbNames lists the ELBs of all VPN gateways, which are the endpoints the
+// VPN user accelerator can be enabled on.
+func (c *Controller) elbNames() []string {
+	var names []string
+	for _, name := range sortedKeys(c.gateways) {
+		gw := c.gateways[name]
+		if gw.VpnStatus == "enabled" && gw.ElbState == "enabled" && !contains(names, gw.ElbName) {
+			names = append(names, gw.ElbName)
+		}
+	}
+	return names
+}
+
+func listVpnUserXlr(c *Controller, p params) (interface{}, error) {
+	all := append([]string{}, c.elbNames()...)
+	free := []string{}
+	for _, elb := range all {
+		if !contains(c.xlrEndpoints, elb) {
+			free = append(free, elb)
+		}
+	}
+	return map[string][]string{
+		"all":   all,
+		"free":  free,
+		"inuse": append([]string{}, c.xlrEndpoints...),
+	}, nil
+}
+
+func updateVpnUserXlr(c *Controller, p params) (interface{}, error) {
+	var endpoints []string
+	if err := json.Unmarshal([]byte(p.get("endpoints")), &endpoints); err != nil {
+		return nil, fmt.Errorf("invalid endpoints: %s", err)
+	}
+	elbs := c.elbNames()
+	for _, elb := range endpoints {
+		if !contains(elbs, elb) {
+			return nil, fmt.Errorf("ELB %s does not exist", elb)
+		}
+	}
+	c.xlrEndpoints = endpoints
+	return "VPN user accelerator has been updated", nil
+}
@@ -1,13 +1,16 @@
 package goaviatrix
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 	"net/url"
 )
 
+// PolicyProtocols are the protocols of gateway firewall and VPN profile rules
+var PolicyProtocols = []string{"all", "tcp", "udp", "icmp", "sctp", "rdp", "dccp"}
+
 type Policy struct {
 	SrcIP      string `form:"s_ip,omitempty" json:"s_ip,omitempty"`
 	DstIP      string `form:"d_ip,omitempty" json:"d_ip,omitempty"`
@@ -34,91 +37,52 @@ type FirewallResp struct {
 }
 
 func (c *Client) SetBasePolicy(firewall *Firewall) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for attach_spoke_to_transit_gw") + err.Error())
-	}
-	setVpcBasePolicy := url.Values{}
-	setVpcBasePolicy.Add("CID", c.CID)
-	setVpcBasePolicy.Add("action", "set_vpc_base_policy")
-	setVpcBasePolicy.Add("vpc_name", firewall.GwName)
-	setVpcBasePolicy.Add("base_policy", firewall.BasePolicy)
-	setVpcBasePolicy.Add("base_policy_log_enable", firewall.BaseLogEnabled)
-	Url.RawQuery = setVpcBasePolicy.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	log.Printf("[INFO] Setting Base Policy: %#v", firewall)
+	return c.SetBasePolicyWithContext(context.Background(), firewall)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_vpc_base_policy failed: " + err.Error())
+func (c *Client) SetBasePolicyWithContext(ctx context.Context, firewall *Firewall) error {
+	form := map[string]string{
+		"vpc_name":               firewall.GwName,
+		"base_policy":            firewall.BasePolicy,
+		"base_policy_log_enable": firewall.BaseLogEnabled,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpc_base_policy failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_vpc_base_policy Get failed: " + data.Reason)
-	}
-	return nil
+	log.Printf("[INFO] Setting Base Policy: %#v", firewall)
+	return c.CallWithContext(ctx, "GET", "set_vpc_base_policy", form, nil)
 }
 
 func (c *Client) UpdatePolicy(firewall *Firewall) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for update_access_policy") + err.Error())
-	}
-	updateAccessPolicy := url.Values{}
-	updateAccessPolicy.Add("CID", c.CID)
-	updateAccessPolicy.Add("action", "update_access_policy")
-	updateAccessPolicy.Add("vpc_name", firewall.GwName)
+	return c.UpdatePolicyWithContext(context.Background(), firewall)
+}
 
+func (c *Client) UpdatePolicyWithContext(ctx context.Context, firewall *Firewall) error {
+	form := url.Values{}
+	form.Add("vpc_name", firewall.GwName)
 	log.Printf("[INFO] Updating Aviatrix firewall for gateway: %#v", firewall)
-
 	args, err := json.Marshal(firewall.PolicyList)
 	if err != nil {
 		return err
 	}
-	updateAccessPolicy.Add("new_policy", string(args))
-	Url.RawQuery = updateAccessPolicy.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get update_access_policy failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_access_policy failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_access_policy Get failed: " + data.Reason)
-	}
-	return nil
+	form.Add("new_policy", string(args))
+	return c.CallWithContext(ctx, "GET", "update_access_policy", form, nil)
 }
 
 func (c *Client) GetPolicy(firewall *Firewall) (*Firewall, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for vpc_access_policy") + err.Error())
-	}
-	vpcAccessPolicy := url.Values{}
-	vpcAccessPolicy.Add("CID", c.CID)
-	vpcAccessPolicy.Add("action", "vpc_access_policy")
-	vpcAccessPolicy.Add("vpc_name", firewall.GwName)
-	Url.RawQuery = vpcAccessPolicy.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetPolicyWithContext(context.Background(), firewall)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get vpc_access_policy failed: " + err.Error())
+func (c *Client) GetPolicyWithContext(ctx context.Context, firewall *Firewall) (*Firewall, error) {
+	form := map[string]string{
+		"vpc_name": firewall.GwName,
 	}
 	var data FirewallResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode vpc_access_policy failed: " + err.Error())
-	}
-	if !data.Return {
-		log.Printf("[INFO] Couldn't find Aviatrix Firewall policies for gateway %s: %s", firewall.GwName,
-			data.Reason)
+	err := c.CallWithContext(ctx, "GET", "vpc_access_policy", form, &data)
+	if isRejection(err) {
+		log.Printf("[INFO] Couldn't find Aviatrix Firewall policies for gateway %s: %s", firewall.GwName, err)
 		return nil, ErrNotFound
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	return &data.Results, nil
 }
@@ -127,9 +91,7 @@ func (c *Client) ValidatePolicy(policy *Policy) error {
 	if policy.Action != "allow" && policy.Action != "deny" {
 		return fmt.Errorf("valid AllowDeny is only 'allow' or 'deny'")
 	}
-	protocolDefaultValues := []string{"all", "tcp", "udp", "icmp", "sctp", "rdp", "dccp"}
-	protocolVal := []string{policy.Protocol}
-	if policy.Protocol == "" || len(Difference(protocolVal, protocolDefaultValues)) != 0 {
+	if !Contains(PolicyProtocols, policy.Protocol) {
 		return fmt.Errorf("protocal can only be one of {'all', 'tcp', 'udp', 'icmp', 'sctp', 'rdp', 'dccp'}")
 	}
 	if policy.Protocol == "all" && policy.Port != "0:65535" {
@@ -1,12 +1,10 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
-	"strings"
+	"net/url"
 )
 
 type CIDRMember struct {
@@ -29,87 +27,51 @@ type FirewallTagResp struct {
 }
 
 func (c *Client) CreateFirewallTag(firewall_tag *FirewallTag) error {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "add_policy_tag"
+	return c.CreateFirewallTagWithContext(context.Background(), firewall_tag)
+}
+
+func (c *Client) CreateFirewallTagWithContext(ctx context.Context, firewall_tag *FirewallTag) error {
 	log.Printf("[INFO] Setting Firewall Tag: %#v", firewall_tag)
-	resp, err := c.Post(c.baseURL, firewall_tag)
-	if err != nil {
-		return err
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return err
-	}
-	if !data.Return {
-		return errors.New(data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "POST", "add_policy_tag", firewall_tag, nil)
 }
 
 func (c *Client) UpdateFirewallTag(firewall_tag *FirewallTag) error {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "update_policy_members"
-	verb := "POST"
-	body := fmt.Sprintf("CID=%s&action=%s&tag_name=%s", c.CID, firewall_tag.Action, firewall_tag.Name)
-	for i, cidr := range firewall_tag.CIDRList {
-		body = body + fmt.Sprintf("&new_policies[%d][name]=%s&new_policies[%d][cidr]=%s", i, cidr.CIDRTag, i, cidr.CIDR)
-	}
-	log.Printf("[TRACE] %s %s Body: %s", verb, c.baseURL, body)
-	req, err := http.NewRequest(verb, c.baseURL, strings.NewReader(body))
-	if err == nil {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		return err
-	}
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return errors.New("HTTP Post update_policy_members failed: " + err.Error())
-	}
+	return c.UpdateFirewallTagWithContext(context.Background(), firewall_tag)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_policy_members failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_policy_members Post failed: " + data.Reason)
+func (c *Client) UpdateFirewallTagWithContext(ctx context.Context, firewall_tag *FirewallTag) error {
+	form := url.Values{}
+	form.Set("tag_name", firewall_tag.Name)
+	for i, cidr := range firewall_tag.CIDRList {
+		form.Set(fmt.Sprintf("new_policies[%d][name]", i), cidr.CIDRTag)
+		form.Set(fmt.Sprintf("new_policies[%d][cidr]", i), cidr.CIDR)
 	}
-	return nil
+	return c.CallWithContext(ctx, "POST", "update_policy_members", form, nil)
 }
 
 func (c *Client) GetFirewallTag(firewall_tag *FirewallTag) (*FirewallTag, error) {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "list_policy_members"
+	return c.GetFirewallTagWithContext(context.Background(), firewall_tag)
+}
 
+func (c *Client) GetFirewallTagWithContext(ctx context.Context, firewall_tag *FirewallTag) (*FirewallTag, error) {
 	log.Printf("[INFO] Getting Firewall Tag: %#v", firewall_tag)
-	resp, err := c.Post(c.baseURL, firewall_tag)
-	if err != nil {
-		return nil, errors.New("HTTP Post list_policy_members failed: " + err.Error())
-	}
 	var data FirewallTagResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_policy_members failed: " + err.Error())
-	}
-	if !data.Return {
-		log.Printf("[INFO] Couldn't find Aviatrix Firewall tag %s: %s", firewall_tag.Name, data.Reason)
+	err := c.CallWithContext(ctx, "POST", "list_policy_members", firewall_tag, &data)
+	if isRejection(err) {
+		log.Printf("[INFO] Couldn't find Aviatrix Firewall tag %s: %s", firewall_tag.Name, err)
 		return nil, ErrNotFound
 	}
+	if err != nil {
+		return nil, err
+	}
 	return &data.Results, nil
 }
 
 func (c *Client) DeleteFirewallTag(firewall_tag *FirewallTag) error {
-	firewall_tag.CID = c.CID
-	firewall_tag.Action = "del_policy_tag"
+	return c.DeleteFirewallTagWithContext(context.Background(), firewall_tag)
+}
+
+func (c *Client) DeleteFirewallTagWithContext(ctx context.Context, firewall_tag *FirewallTag) error {
 	log.Printf("[INFO] Deleting Firewall Tag: %#v", firewall_tag)
-	resp, err := c.Post(c.baseURL, firewall_tag)
-	if err != nil {
-		return errors.New("HTTP Post del_policy_tag failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode del_policy_tag failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API del_policy_tag Post failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "POST", "del_policy_tag", firewall_tag, nil)
 }
@@ -1,11 +1,12 @@
 package goaviatrix
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
-	"net/url"
+	"strconv"
+	"time"
 )
 
 // Gateway simple struct to hold gateway details
@@ -153,142 +154,110 @@ type GatewayDetailApiResp struct {
 }
 
 func (c *Client) CreateGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "connect_container"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post connect_container failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode connect_container failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API connect_container Post failed: " + data.Reason)
-	}
-	return nil
+	return c.CreateGatewayWithContext(context.Background(), gateway)
+}
+
+func (c *Client) CreateGatewayWithContext(ctx context.Context, gateway *Gateway) error {
+	return c.CallWithContext(ctx, "POST", "connect_container", gateway, nil)
 }
 
 func (c *Client) EnableNatGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "enable_nat"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post enable_nat failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_nat failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_nat Post failed: " + data.Reason)
-	}
-	return nil
+	return c.EnableNatGatewayWithContext(context.Background(), gateway)
+}
+
+func (c *Client) EnableNatGatewayWithContext(ctx context.Context, gateway *Gateway) error {
+	return c.CallWithContext(ctx, "POST", "enable_nat", gateway, nil)
 }
 func (c *Client) EnableSingleAZGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "enable_single_az_ha"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post enable_single_az_ha failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_single_az_ha failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_single_az_ha Post failed: " + data.Reason)
-	}
-	return nil
+	return c.EnableSingleAZGatewayWithContext(context.Background(), gateway)
+}
+
+func (c *Client) EnableSingleAZGatewayWithContext(ctx context.Context, gateway *Gateway) error {
+	return c.CallWithContext(ctx, "POST", "enable_single_az_ha", gateway, nil)
 }
 func (c *Client) EnablePeeringHaGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "create_peering_ha_gateway"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post create_peering_ha_gateway failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_peering_ha_gateway failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API create_peering_ha_gateway Post failed: " + data.Reason)
-	}
-	return nil
+	return c.EnablePeeringHaGatewayWithContext(context.Background(), gateway)
+}
+
+func (c *Client) EnablePeeringHaGatewayWithContext(ctx context.Context, gateway *Gateway) error {
+	return c.CallWithContext(ctx, "POST", "create_peering_ha_gateway", gateway, nil)
 }
 
 func (c *Client) DisableSingleAZGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "disable_single_az_ha"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post disable_single_az_ha failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_single_az_ha failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_single_az_ha Post failed: " + data.Reason)
-	}
-	return nil
+	return c.DisableSingleAZGatewayWithContext(context.Background(), gateway)
+}
+
+func (c *Client) DisableSingleAZGatewayWithContext(ctx context.Context, gateway *Gateway) error {
+	return c.CallWithContext(ctx, "POST", "disable_single_az_ha", gateway, nil)
 }
 
 func (c *Client) GetGateway(gateway *Gateway) (*Gateway, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_vpcs_summary") + err.Error())
-	}
-	listVpcSummary := url.Values{}
-	listVpcSummary.Add("CID", c.CID)
-	listVpcSummary.Add("action", "list_vpcs_summary")
-	Url.RawQuery = listVpcSummary.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetGatewayWithContext(context.Background(), gateway)
+}
 
+func (c *Client) GetGatewayWithContext(ctx context.Context, gateway *Gateway) (*Gateway, error) {
+	index, err := c.gatewayIndex(ctx)
 	if err != nil {
-		return nil, errors.New("HTTP Get list_vpcs_summary failed: " + err.Error())
+		return nil, err
 	}
-	var data GatewayListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpcs_summary failed: " + err.Error())
+	if gw, ok := index[gateway.GwName]; ok {
+		return &gw, nil
 	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpcs_summary Get failed: " + data.Reason)
+	log.Printf("Couldn't find Aviatrix gateway %s", gateway.GwName)
+	return nil, ErrNotFound
+}
+
+// gatewayIndex returns the gateways listed by list_vpcs_summary keyed by
+// name. With a response cache the index is built once per cached response.
+func (c *Client) gatewayIndex(ctx context.Context) (map[string]Gateway, error) {
+	build := func(body []byte) (interface{}, error) {
+		var data GatewayListResp
+		if err := json.Unmarshal(body, &data); err != nil {
+			return nil, fmt.Errorf("Json Decode list_vpcs_summary failed: %s", err)
+		}
+		index := make(map[string]Gateway, len(data.Results))
+		for _, gw := range data.Results {
+			if _, ok := index[gw.GwName]; !ok {
+				index[gw.GwName] = gw
+			}
+		}
+		return index, nil
 	}
 
-	gwList := data.Results
-	for i := range gwList {
-		if gwList[i].GwName == gateway.GwName {
-			return &gwList[i], nil
+	if !c.useCache(ctx, "list_vpcs_summary") {
+		_, body, err := c.invoke(ctx, "GET", "list_vpcs_summary", nil)
+		if err != nil {
+			return nil, err
 		}
+		index, err := build(body)
+		if err != nil {
+			return nil, err
+		}
+		return index.(map[string]Gateway), nil
 	}
-	log.Printf("Couldn't find Aviatrix gateway %s", gateway.GwName)
-	return nil, ErrNotFound
-}
 
-func (c *Client) GetGatewayDetail(gateway *Gateway) (*GatewayDetail, error) {
-	Url, err := url.Parse(c.baseURL)
+	e, err := c.cachedCall(ctx, "GET", "list_vpcs_summary", nil)
+	if err != nil {
+		return nil, err
+	}
+	index, err := e.derived(build)
 	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_vpc_by_name") + err.Error())
+		return nil, err
 	}
-	listVpcByName := url.Values{}
-	listVpcByName.Add("CID", c.CID)
-	listVpcByName.Add("action", "list_vpc_by_name")
-	listVpcByName.Add("vpc_name", gateway.GwName)
+	return index.(map[string]Gateway), nil
+}
 
-	Url.RawQuery = listVpcByName.Encode()
-	resp, err := c.Get(Url.String(), nil)
+func (c *Client) GetGatewayDetail(gateway *Gateway) (*GatewayDetail, error) {
+	return c.GetGatewayDetailWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vpc_by_name failed: " + err.Error())
+func (c *Client) GetGatewayDetailWithContext(ctx context.Context, gateway *Gateway) (*GatewayDetail, error) {
+	form := map[string]string{
+		"vpc_name": gateway.GwName,
 	}
 	var data GatewayDetailApiResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpc_by_name failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpc_by_name Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_vpc_by_name", form, &data); err != nil {
+		return nil, err
 	}
 	if data.Results.GwName == gateway.GwName {
 		return &data.Results, nil
@@ -299,153 +268,143 @@ func (c *Client) GetGatewayDetail(gateway *Gateway) (*GatewayDetail, error) {
 }
 
 func (c *Client) UpdateGateway(gateway *Gateway) error {
-	gateway.CID = c.CID
-	gateway.Action = "edit_gw_config"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post edit_gw_config failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_gw_config failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_gw_config Post failed: " + data.Reason)
-	}
-	return nil
+	return c.UpdateGatewayWithContext(context.Background(), gateway)
+}
+
+func (c *Client) UpdateGatewayWithContext(ctx context.Context, gateway *Gateway) error {
+	return c.CallWithContext(ctx, "POST", "edit_gw_config", gateway, nil)
 }
 
 func (c *Client) DeleteGateway(gateway *Gateway) error {
-	path := c.baseURL + fmt.Sprintf("?action=delete_container&CID=%s&cloud_type=%d&gw_name=%s",
-		c.CID, gateway.CloudType, gateway.GwName)
-	resp, err := c.Delete(path, nil)
+	return c.DeleteGatewayWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_container failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_container failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_container Get failed: " + data.Reason)
+func (c *Client) DeleteGatewayWithContext(ctx context.Context, gateway *Gateway) error {
+	form := map[string]string{
+		"cloud_type": strconv.Itoa(gateway.CloudType),
+		"gw_name":    gateway.GwName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "delete_container", form, nil)
 }
 func (c *Client) EnableSNat(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_snat") + err.Error())
-	}
-	enableSNat := url.Values{}
-	enableSNat.Add("CID", c.CID)
-	enableSNat.Add("action", "enable_snat")
-	enableSNat.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = enableSNat.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableSNatWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_snat failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_snat failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_snat Get failed: " + data.Reason)
+func (c *Client) EnableSNatWithContext(ctx context.Context, gateway *Gateway) error {
+	form := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "enable_snat", form, nil)
 }
 func (c *Client) DisableSNat(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_snat") + err.Error())
-	}
-	disableSNat := url.Values{}
-	disableSNat.Add("CID", c.CID)
-	disableSNat.Add("action", "disable_snat")
-	disableSNat.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = disableSNat.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DisableSNatWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_snat failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_snat failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_snat Get failed: " + data.Reason)
+func (c *Client) DisableSNatWithContext(ctx context.Context, gateway *Gateway) error {
+	form := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "disable_snat", form, nil)
 }
 func (c *Client) UpdateVpnCidr(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for set_vpn_client_cidr") + err.Error())
-	}
-	setVpnClientCIDR := url.Values{}
-	setVpnClientCIDR.Add("CID", c.CID)
-	setVpnClientCIDR.Add("action", "set_vpn_client_cidr")
-	setVpnClientCIDR.Add("cidr", gateway.VpnCidr)
-	setVpnClientCIDR.Add("vpc_id", gateway.VpcID)
-	setVpnClientCIDR.Add("lb_or_gateway_name", gateway.ElbName)
-	Url.RawQuery = setVpnClientCIDR.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.UpdateVpnCidrWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_vpn_client_cidr failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpn_client_cidr failed: " + err.Error())
+func (c *Client) UpdateVpnCidrWithContext(ctx context.Context, gateway *Gateway) error {
+	form := map[string]string{
+		"cidr":               gateway.VpnCidr,
+		"vpc_id":             gateway.VpcID,
+		"lb_or_gateway_name": gateway.ElbName,
 	}
-	if !data.Return {
-		return errors.New("Rest API set_vpn_client_cidr Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "set_vpn_client_cidr", form, nil)
 }
 func (c *Client) UpdateMaxVpnConn(gateway *Gateway) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for set_vpn_client_cidr") + err.Error())
-	}
-	setMaxVpnConn := url.Values{}
-	setMaxVpnConn.Add("CID", c.CID)
-	setMaxVpnConn.Add("action", "set_vpn_max_connection")
-	setMaxVpnConn.Add("max_connections", gateway.MaxConn)
-	setMaxVpnConn.Add("vpc_id", gateway.VpcID)
-	setMaxVpnConn.Add("lb_or_gateway_name", gateway.ElbName)
-	Url.RawQuery = setMaxVpnConn.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.UpdateMaxVpnConnWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_vpn_max_connection failed: " + err.Error())
+func (c *Client) UpdateMaxVpnConnWithContext(ctx context.Context, gateway *Gateway) error {
+	form := map[string]string{
+		"max_connections":    gateway.MaxConn,
+		"vpc_id":             gateway.VpcID,
+		"lb_or_gateway_name": gateway.ElbName,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpn_max_connection failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_vpn_max_connection Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "set_vpn_max_connection", form, nil)
 }
 func (c *Client) SetVpnGatewayAuthentication(gateway *VpnGatewayAuth) error {
-	gateway.CID = c.CID
-	gateway.Action = "set_vpn_gateway_authentication"
-	resp, err := c.Post(c.baseURL, gateway)
+	return c.SetVpnGatewayAuthenticationWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Post set_vpn_gateway_authentication failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_vpn_gateway_authentication failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_vpn_gateway_authentication Get failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) SetVpnGatewayAuthenticationWithContext(ctx context.Context, gateway *VpnGatewayAuth) error {
+	return c.CallWithContext(ctx, "POST", "set_vpn_gateway_authentication", gateway, nil)
+}
+
+// Gateway states reported by list_vpcs_summary once a gateway is usable
+const (
+	GatewayInstStateRunning = "running"
+	GatewayVpcStateUp       = "up"
+	gatewayStateReady       = "ready"
+	gatewayStatePending     = "pending"
+)
+
+// gatewayInstStatesFailed are the instance states a launching gateway never
+// recovers from.
+var gatewayInstStatesFailed = []string{"stopping", "stopped", "shutting-down", "terminated"}
+
+func (c *Client) WaitForGatewayReady(gwName string, timeout time.Duration) error {
+	return c.WaitForGatewayReadyWithContext(context.Background(), gwName, timeout)
+}
+
+// WaitForGatewayReadyWithContext waits until the gateway's instance is
+// running and its VPC is up, and fails if the instance is stopped or
+// terminated instead. A zero timeout leaves the wait bounded by ctx alone.
+func (c *Client) WaitForGatewayReadyWithContext(ctx context.Context, gwName string, timeout time.Duration) error {
+	w := &Waiter{
+		Description: "gateway " + gwName,
+		Pending:     []string{gatewayStatePending},
+		Target:      []string{gatewayStateReady},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			// Each poll must see the controller's current state.
+			gw, err := c.GetGatewayWithContext(withoutCache(ctx), &Gateway{GwName: gwName})
+			if err != nil {
+				return "", err
+			}
+			if Contains(gatewayInstStatesFailed, gw.InstState) {
+				return gw.InstState, nil
+			}
+			if gw.InstState == GatewayInstStateRunning && gw.VpcState == GatewayVpcStateUp {
+				return gatewayStateReady, nil
+			}
+			log.Printf("[DEBUG] Gateway %s is %s, VPC state %s", gwName, gw.InstState, gw.VpcState)
+			return gatewayStatePending, nil
+		},
+	}
+	_, err := w.Wait(ctx)
+	return err
+}
+
+func (c *Client) WaitForGatewayDeleted(gwName string, timeout time.Duration) error {
+	return c.WaitForGatewayDeletedWithContext(context.Background(), gwName, timeout)
+}
+
+// WaitForGatewayDeletedWithContext waits until the controller no longer
+// lists the gateway. A zero timeout leaves the wait bounded by ctx alone.
+func (c *Client) WaitForGatewayDeletedWithContext(ctx context.Context, gwName string, timeout time.Duration) error {
+	w := &Waiter{
+		Description: "deletion of gateway " + gwName,
+		Target:      []string{"deleted"},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			_, err := c.GetGatewayWithContext(withoutCache(ctx), &Gateway{GwName: gwName})
+			if err == ErrNotFound {
+				return "deleted", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return "deleting", nil
+		},
+	}
+	_, err := w.Wait(ctx)
+	return err
 }
@@ -0,0 +1,94 @@
+package goaviatrix
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Limiter bounds how many controller actions run at once and, optionally,
+// how many may start per second.
+type Limiter struct {
+	sem    chan struct{}
+	bucket *tokenBucket
+}
+
+// NewLimiter returns a Limiter allowing at most concurrency actions in
+// flight, started at no more than rate per second with bursts of up to
+// burst. A concurrency or rate of zero or less means no limit.
+func NewLimiter(concurrency int, rate float64, burst int) *Limiter {
+	l := &Limiter{}
+	if concurrency > 0 {
+		l.sem = make(chan struct{}, concurrency)
+	}
+	if rate > 0 {
+		if burst < 1 {
+			burst = 1
+		}
+		l.bucket = &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+	}
+	return l
+}
+
+// acquire blocks until the action may start or ctx is done. On success the
+// caller must call release when the action has finished.
+func (l *Limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if l.sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return func() { <-l.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// tokenBucket refills rate tokens per second up to burst
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		need := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleepContext(ctx, need); err != nil {
+			return err
+		}
+	}
+}
+
+// limiterFor returns the limiter that applies to a request for action
+func (c *Client) limiterFor(action string, values url.Values) *Limiter {
+	if isReadRequest(action, values) {
+		return c.ReadLimiter
+	}
+	return c.WriteLimiter
+}
@@ -0,0 +1,126 @@
+package goaviatrix
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterConcurrency(t *testing.T) {
+	l := NewLimiter(2, 0, 0)
+	var inFlight, most int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := l.acquire(context.Background())
+			if err != nil {
+				t.Errorf("acquire: %s", err)
+				return
+			}
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&most)
+				if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			release()
+		}()
+	}
+	wg.Wait()
+	if most != 2 {
+		t.Errorf("%d actions in flight at once, want 2", most)
+	}
+}
+
+func TestLimiterAcquireCanceled(t *testing.T) {
+	l := NewLimiter(1, 0, 0)
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("acquire on a full limiter = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	release()
+	if release, err := l.acquire(context.Background()); err != nil {
+		t.Errorf("acquire after release: %s", err)
+	} else {
+		release()
+	}
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	for _, l := range []*Limiter{nil, NewLimiter(0, 0, 0)} {
+		for i := 0; i < 100; i++ {
+			if _, err := l.acquire(context.Background()); err != nil {
+				t.Fatalf("acquire %d: %s", i, err)
+			}
+		}
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	// A burst of 2, then one action every 10ms.
+	l := NewLimiter(0, 100, 2)
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("burst took %s, want no wait", elapsed)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := l.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("5 actions at 100/s with a burst of 2 took %s, want about 30ms", elapsed)
+	}
+
+	// The bucket is empty, so a canceled context stops the wait.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.acquire(ctx); err != context.Canceled {
+		t.Errorf("acquire = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClientLimiterFor(t *testing.T) {
+	client := &Client{ReadLimiter: NewLimiter(1, 0, 0), WriteLimiter: NewLimiter(1, 0, 0)}
+	cases := []struct {
+		action string
+		values url.Values
+		read   bool
+	}{
+		{"list_vpcs_summary", nil, true},
+		{"get_gateway_info", nil, true},
+		{"config_http_access", url.Values{"operation": {"get"}}, true},
+		{"config_http_access", url.Values{"operation": {"enable"}}, false},
+		{"modify_split_tunnel", url.Values{"command": {"get"}}, true},
+		{"modify_split_tunnel", url.Values{"command": {"modify"}}, false},
+		{"connect_container", nil, false},
+	}
+	for _, tc := range cases {
+		want := client.WriteLimiter
+		if tc.read {
+			want = client.ReadLimiter
+		}
+		if got := client.limiterFor(tc.action, tc.values); got != want {
+			t.Errorf("limiterFor(%s, %v) is the wrong limiter, want the read limiter: %t", tc.action, tc.values, tc.read)
+		}
+	}
+}
@@ -0,0 +1,1658 @@
+// Code generated by gen from api.go. DO NOT EDIT.
+
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix"
+)
+
+// Client implements goaviatrix.API. Each method calls the matching Func
+// field if it is set and otherwise returns zero values and a nil error.
+// Every call is recorded in Calls.
+type Client struct {
+	Recorder
+
+	CreateAccountFunc                              func(*goaviatrix.Account) error
+	GetAccountFunc                                 func(*goaviatrix.Account) (*goaviatrix.Account, error)
+	UpdateAccountFunc                              func(*goaviatrix.Account) error
+	DeleteAccountFunc                              func(*goaviatrix.Account) error
+	UploadGcloudProjectCredentialsFileFunc         func(*goaviatrix.Account) error
+	CreateAccountUserFunc                          func(*goaviatrix.AccountUser) error
+	GetAccountUserFunc                             func(*goaviatrix.AccountUser) (*goaviatrix.AccountUser, error)
+	UpdateAccountUserObjectFunc                    func(*goaviatrix.AccountUserEdit) error
+	DeleteAccountUserFunc                          func(*goaviatrix.AccountUser) error
+	CreateGatewayFunc                              func(*goaviatrix.Gateway) error
+	CreateGatewayWithContextFunc                   func(context.Context, *goaviatrix.Gateway) error
+	GetGatewayFunc                                 func(*goaviatrix.Gateway) (*goaviatrix.Gateway, error)
+	GetGatewayWithContextFunc                      func(context.Context, *goaviatrix.Gateway) (*goaviatrix.Gateway, error)
+	GetGatewayDetailFunc                           func(*goaviatrix.Gateway) (*goaviatrix.GatewayDetail, error)
+	UpdateGatewayFunc                              func(*goaviatrix.Gateway) error
+	UpdateGatewayWithContextFunc                   func(context.Context, *goaviatrix.Gateway) error
+	DeleteGatewayFunc                              func(*goaviatrix.Gateway) error
+	DeleteGatewayWithContextFunc                   func(context.Context, *goaviatrix.Gateway) error
+	EnableSingleAZGatewayFunc                      func(*goaviatrix.Gateway) error
+	EnableSingleAZGatewayWithContextFunc           func(context.Context, *goaviatrix.Gateway) error
+	DisableSingleAZGatewayFunc                     func(*goaviatrix.Gateway) error
+	DisableSingleAZGatewayWithContextFunc          func(context.Context, *goaviatrix.Gateway) error
+	EnablePeeringHaGatewayFunc                     func(*goaviatrix.Gateway) error
+	EnablePeeringHaGatewayWithContextFunc          func(context.Context, *goaviatrix.Gateway) error
+	EnableSNatFunc                                 func(*goaviatrix.Gateway) error
+	EnableSNatWithContextFunc                      func(context.Context, *goaviatrix.Gateway) error
+	DisableSNatFunc                                func(*goaviatrix.Gateway) error
+	DisableSNatWithContextFunc                     func(context.Context, *goaviatrix.Gateway) error
+	UpdateVpnCidrFunc                              func(*goaviatrix.Gateway) error
+	UpdateVpnCidrWithContextFunc                   func(context.Context, *goaviatrix.Gateway) error
+	UpdateMaxVpnConnFunc                           func(*goaviatrix.Gateway) error
+	UpdateMaxVpnConnWithContextFunc                func(context.Context, *goaviatrix.Gateway) error
+	SetVpnGatewayAuthenticationFunc                func(*goaviatrix.VpnGatewayAuth) error
+	SetVpnGatewayAuthenticationWithContextFunc     func(context.Context, *goaviatrix.VpnGatewayAuth) error
+	WaitForGatewayReadyFunc                        func(string, time.Duration) error
+	WaitForGatewayReadyWithContextFunc             func(context.Context, string, time.Duration) error
+	WaitForGatewayDeletedFunc                      func(string, time.Duration) error
+	WaitForGatewayDeletedWithContextFunc           func(context.Context, string, time.Duration) error
+	GetSplitTunnelFunc                             func(*goaviatrix.SplitTunnel) (*goaviatrix.SplitTunnelUnit, error)
+	ModifySplitTunnelFunc                          func(*goaviatrix.SplitTunnel) error
+	ModifySplitTunnelWithContextFunc               func(context.Context, *goaviatrix.SplitTunnel) error
+	GetDefaultTagsFunc                             func() map[string]string
+	GetTagsFunc                                    func(*goaviatrix.Tags) ([]string, error)
+	AddTagsFunc                                    func(*goaviatrix.Tags) error
+	AddTagsWithContextFunc                         func(context.Context, *goaviatrix.Tags) error
+	DeleteTagsFunc                                 func(*goaviatrix.Tags) error
+	DeleteTagsWithContextFunc                      func(context.Context, *goaviatrix.Tags) error
+	LaunchTransitVpcFunc                           func(*goaviatrix.TransitVpc) error
+	LaunchTransitVpcWithContextFunc                func(context.Context, *goaviatrix.TransitVpc) error
+	EnableHaTransitVpcFunc                         func(*goaviatrix.TransitVpc) error
+	EnableHaTransitVpcWithContextFunc              func(context.Context, *goaviatrix.TransitVpc) error
+	AttachTransitGWForHybridFunc                   func(*goaviatrix.TransitVpc) error
+	AttachTransitGWForHybridWithContextFunc        func(context.Context, *goaviatrix.TransitVpc) error
+	DetachTransitGWForHybridFunc                   func(*goaviatrix.TransitVpc) error
+	DetachTransitGWForHybridWithContextFunc        func(context.Context, *goaviatrix.TransitVpc) error
+	EnableConnectedTransitFunc                     func(*goaviatrix.TransitVpc) error
+	EnableConnectedTransitWithContextFunc          func(context.Context, *goaviatrix.TransitVpc) error
+	DisableConnectedTransitFunc                    func(*goaviatrix.TransitVpc) error
+	DisableConnectedTransitWithContextFunc         func(context.Context, *goaviatrix.TransitVpc) error
+	EnableGatewayFireNetInterfacesFunc             func(*goaviatrix.TransitVpc) error
+	EnableGatewayFireNetInterfacesWithContextFunc  func(context.Context, *goaviatrix.TransitVpc) error
+	DisableGatewayFireNetInterfacesFunc            func(*goaviatrix.TransitVpc) error
+	DisableGatewayFireNetInterfacesWithContextFunc func(context.Context, *goaviatrix.TransitVpc) error
+	CreateTransitGatewayPeeringFunc                func(*goaviatrix.TransitGatewayPeering) error
+	GetTransitGatewayPeeringFunc                   func(*goaviatrix.TransitGatewayPeering) error
+	DeleteTransitGatewayPeeringFunc                func(*goaviatrix.TransitGatewayPeering) error
+	CreateVGWConnFunc                              func(*goaviatrix.VGWConn) error
+	GetVGWConnFunc                                 func(*goaviatrix.VGWConn) (*goaviatrix.VGWConn, error)
+	GetVGWConnDetailFunc                           func(*goaviatrix.VGWConn) (*goaviatrix.VGWConn, error)
+	DeleteVGWConnFunc                              func(*goaviatrix.VGWConn) error
+	EnableAdvertiseTransitCidrFunc                 func(*goaviatrix.VGWConn) error
+	DisableAdvertiseTransitCidrFunc                func(*goaviatrix.VGWConn) error
+	SetBgpManualSpokeAdvertisedNetworksFunc        func(*goaviatrix.VGWConn) error
+	DisableBgpManualSpokeAdvertisedNetworksFunc    func(*goaviatrix.VGWConn) error
+	LaunchSpokeVpcFunc                             func(*goaviatrix.SpokeVpc) error
+	LaunchSpokeVpcWithContextFunc                  func(context.Context, *goaviatrix.SpokeVpc) error
+	EnableHaSpokeVpcFunc                           func(*goaviatrix.SpokeVpc) error
+	EnableHaSpokeVpcWithContextFunc                func(context.Context, *goaviatrix.SpokeVpc) error
+	SpokeJoinTransitFunc                           func(*goaviatrix.SpokeVpc) error
+	SpokeJoinTransitWithContextFunc                func(context.Context, *goaviatrix.SpokeVpc) error
+	SpokeLeaveTransitFunc                          func(*goaviatrix.SpokeVpc) error
+	SpokeLeaveTransitWithContextFunc               func(context.Context, *goaviatrix.SpokeVpc) error
+	CreateFQDNFunc                                 func(*goaviatrix.FQDN) error
+	GetFQDNTagFunc                                 func(*goaviatrix.FQDN) (*goaviatrix.FQDN, error)
+	DeleteFQDNFunc                                 func(*goaviatrix.FQDN) error
+	UpdateFQDNStatusFunc                           func(*goaviatrix.FQDN) error
+	UpdateFQDNModeFunc                             func(*goaviatrix.FQDN) error
+	UpdateDomainsFunc                              func(*goaviatrix.FQDN) error
+	ListDomainsFunc                                func(*goaviatrix.FQDN) (*goaviatrix.FQDN, error)
+	ListGwsFunc                                    func(*goaviatrix.FQDN) ([]string, error)
+	AttachTagToGwFunc                              func(*goaviatrix.FQDN, *goaviatrix.Gateway) error
+	DetachGwsFunc                                  func(*goaviatrix.FQDN, []string) error
+	GetGwFilterTagListFunc                         func(*goaviatrix.FQDN) (*goaviatrix.FQDN, error)
+	UpdateSourceIPFiltersFunc                      func(*goaviatrix.FQDN, *goaviatrix.Gateway, []string) error
+	SetBasePolicyFunc                              func(*goaviatrix.Firewall) error
+	GetPolicyFunc                                  func(*goaviatrix.Firewall) (*goaviatrix.Firewall, error)
+	UpdatePolicyFunc                               func(*goaviatrix.Firewall) error
+	ValidatePolicyFunc                             func(*goaviatrix.Policy) error
+	CreateFirewallTagFunc                          func(*goaviatrix.FirewallTag) error
+	GetFirewallTagFunc                             func(*goaviatrix.FirewallTag) (*goaviatrix.FirewallTag, error)
+	UpdateFirewallTagFunc                          func(*goaviatrix.FirewallTag) error
+	DeleteFirewallTagFunc                          func(*goaviatrix.FirewallTag) error
+	CreateSite2CloudFunc                           func(*goaviatrix.Site2Cloud) error
+	GetSite2CloudFunc                              func(*goaviatrix.Site2Cloud) (*goaviatrix.Site2Cloud, error)
+	GetSite2CloudConnDetailFunc                    func(*goaviatrix.Site2Cloud) (*goaviatrix.Site2Cloud, error)
+	UpdateSite2CloudFunc                           func(*goaviatrix.EditSite2Cloud) error
+	DeleteSite2CloudFunc                           func(*goaviatrix.Site2Cloud) error
+	Site2CloudAlgorithmCheckFunc                   func(*goaviatrix.Site2Cloud) error
+	EnableDeadPeerDetectionFunc                    func(*goaviatrix.Site2Cloud) error
+	DisableDeadPeerDetectionFunc                   func(*goaviatrix.Site2Cloud) error
+	WaitForSite2CloudTunnelFunc                    func(*goaviatrix.Site2Cloud, []string, time.Duration) error
+	CreateAWSTgwFunc                               func(*goaviatrix.AWSTgw) error
+	GetAWSTgwFunc                                  func(*goaviatrix.AWSTgw) (*goaviatrix.AWSTgw, error)
+	ListTgwDetailsFunc                             func(*goaviatrix.AWSTgw) (*goaviatrix.AWSTgw, error)
+	DeleteAWSTgwFunc                               func(*goaviatrix.AWSTgw) error
+	WaitForAWSTgwAvailableFunc                     func(*goaviatrix.AWSTgw, time.Duration) error
+	WaitForAWSTgwDeletedFunc                       func(*goaviatrix.AWSTgw, time.Duration) error
+	ValidateAWSTgwDomainsFunc                      func([]string, [][]string, [][]string) ([]string, [][]string, [][]string, error)
+	AttachAviatrixTransitGWToAWSTgwFunc            func(*goaviatrix.AWSTgw, *goaviatrix.Gateway, string) error
+	DetachAviatrixTransitGWFromAWSTgwFunc          func(*goaviatrix.AWSTgw, *goaviatrix.Gateway, string) error
+	AttachVpcToAWSTgwFunc                          func(*goaviatrix.AWSTgw, goaviatrix.VPCSolo, string) error
+	DetachVpcFromAWSTgwFunc                        func(*goaviatrix.AWSTgw, string) error
+	IsVpcAttachedToTgwFunc                         func(*goaviatrix.AWSTgw, *goaviatrix.VPCSolo) (bool, error)
+	CreateSecurityDomainFunc                       func(*goaviatrix.SecurityDomain) error
+	DeleteSecurityDomainFunc                       func(*goaviatrix.SecurityDomain) error
+	CreateDomainConnectionFunc                     func(*goaviatrix.AWSTgw, string, string) error
+	DeleteDomainConnectionFunc                     func(*goaviatrix.AWSTgw, string, string) error
+	CreateAwsTgwVpcAttachmentFunc                  func(*goaviatrix.AwsTgwVpcAttachment) error
+	GetAwsTgwVpcAttachmentFunc                     func(*goaviatrix.AwsTgwVpcAttachment) (*goaviatrix.AwsTgwVpcAttachment, error)
+	DeleteAwsTgwVpcAttachmentFunc                  func(*goaviatrix.AwsTgwVpcAttachment) error
+	CreateAwsTgwVpnConnFunc                        func(*goaviatrix.AwsTgwVpnConn) (string, error)
+	GetAwsTgwVpnConnFunc                           func(*goaviatrix.AwsTgwVpnConn) (*goaviatrix.AwsTgwVpnConn, error)
+	DeleteAwsTgwVpnConnFunc                        func(*goaviatrix.AwsTgwVpnConn) error
+	WaitForAwsTgwVpnConnDeletedFunc                func(*goaviatrix.AwsTgwVpnConn, time.Duration) error
+	CreateVPNUserFunc                              func(*goaviatrix.VPNUser) error
+	GetVPNUserFunc                                 func(*goaviatrix.VPNUser) (*goaviatrix.VPNUser, error)
+	DeleteVPNUserFunc                              func(*goaviatrix.VPNUser) error
+	CreateProfileFunc                              func(*goaviatrix.Profile) error
+	GetProfileFunc                                 func(*goaviatrix.Profile) (*goaviatrix.Profile, error)
+	GetProfileBasePolicyFunc                       func(*goaviatrix.Profile) (*goaviatrix.Profile, error)
+	UpdateProfilePolicyFunc                        func(*goaviatrix.Profile) error
+	DeleteProfileFunc                              func(*goaviatrix.Profile) error
+	AttachUsersFunc                                func(*goaviatrix.Profile) error
+	DetachUsersFunc                                func(*goaviatrix.Profile) error
+	ValidateProfileRuleFunc                        func(*goaviatrix.ProfileRule) error
+	GetVpnUserAcceleratorFunc                      func() ([]string, error)
+	UpdateVpnUserAcceleratorFunc                   func(*goaviatrix.VpnUserXlr) error
+	WaitForVpnUserAcceleratorElbFunc               func(string, time.Duration) error
+	GetCIDFunc                                     func() string
+	GetControllerIPFunc                            func() string
+	ControllerVersionValidationFunc                func(goaviatrix.VersionRange) error
+	RequireCapabilityFunc                          func(goaviatrix.Capability) error
+	GetCurrentVersionFunc                          func() (string, *goaviatrix.AviatrixVersion, error)
+	GetLatestVersionFunc                           func() (string, error)
+	UpgradeFunc                                    func(*goaviatrix.Version) error
+	WaitForVersionFunc                             func(string, time.Duration) error
+	GetHttpAccessEnabledFunc                       func() (string, error)
+	EnableHttpAccessFunc                           func() error
+	DisableHttpAccessFunc                          func() error
+	WaitForHttpAccessFunc                          func(bool, time.Duration) error
+	GetExceptionRuleStatusFunc                     func() (bool, error)
+	EnableExceptionRuleFunc                        func() error
+	DisableExceptionRuleFunc                       func() error
+	GetSecurityGroupManagementStatusFunc           func() (*goaviatrix.SecurityGroupInfo, error)
+	EnableSecurityGroupManagementFunc              func(string) error
+	DisableSecurityGroupManagementFunc             func() error
+	CreateAWSPeerFunc                              func(*goaviatrix.AWSPeer) (string, error)
+	GetAWSPeerFunc                                 func(*goaviatrix.AWSPeer) (*goaviatrix.AWSPeer, error)
+	DeleteAWSPeerFunc                              func(*goaviatrix.AWSPeer) error
+	CreateARMPeerFunc                              func(*goaviatrix.ARMPeer) error
+	GetARMPeerFunc                                 func(*goaviatrix.ARMPeer) (*goaviatrix.ARMPeer, error)
+	DeleteARMPeerFunc                              func(*goaviatrix.ARMPeer) error
+	CreateTransPeerFunc                            func(*goaviatrix.TransPeer) error
+	GetTransPeerFunc                               func(*goaviatrix.TransPeer) (*goaviatrix.TransPeer, error)
+	DeleteTransPeerFunc                            func(*goaviatrix.TransPeer) error
+	CreateTunnelFunc                               func(*goaviatrix.Tunnel) error
+	GetTunnelFunc                                  func(*goaviatrix.Tunnel) (*goaviatrix.Tunnel, error)
+	UpdateTunnelFunc                               func(*goaviatrix.Tunnel) error
+	DeleteTunnelFunc                               func(*goaviatrix.Tunnel) error
+	CreateVpcFunc                                  func(*goaviatrix.Vpc) error
+	GetVpcFunc                                     func(*goaviatrix.Vpc) (*goaviatrix.Vpc, error)
+	DeleteVpcFunc                                  func(*goaviatrix.Vpc) error
+}
+
+var _ goaviatrix.API = (*Client)(nil)
+
+func (m *Client) CreateAccount(account *goaviatrix.Account) error {
+	m.record("CreateAccount", account)
+	if m.CreateAccountFunc == nil {
+		return nil
+	}
+	return m.CreateAccountFunc(account)
+}
+
+func (m *Client) GetAccount(account *goaviatrix.Account) (*goaviatrix.Account, error) {
+	m.record("GetAccount", account)
+	if m.GetAccountFunc == nil {
+		return nil, nil
+	}
+	return m.GetAccountFunc(account)
+}
+
+func (m *Client) UpdateAccount(account *goaviatrix.Account) error {
+	m.record("UpdateAccount", account)
+	if m.UpdateAccountFunc == nil {
+		return nil
+	}
+	return m.UpdateAccountFunc(account)
+}
+
+func (m *Client) DeleteAccount(account *goaviatrix.Account) error {
+	m.record("DeleteAccount", account)
+	if m.DeleteAccountFunc == nil {
+		return nil
+	}
+	return m.DeleteAccountFunc(account)
+}
+
+func (m *Client) UploadGcloudProjectCredentialsFile(account *goaviatrix.Account) error {
+	m.record("UploadGcloudProjectCredentialsFile", account)
+	if m.UploadGcloudProjectCredentialsFileFunc == nil {
+		return nil
+	}
+	return m.UploadGcloudProjectCredentialsFileFunc(account)
+}
+
+func (m *Client) CreateAccountUser(user *goaviatrix.AccountUser) error {
+	m.record("CreateAccountUser", user)
+	if m.CreateAccountUserFunc == nil {
+		return nil
+	}
+	return m.CreateAccountUserFunc(user)
+}
+
+func (m *Client) GetAccountUser(user *goaviatrix.AccountUser) (*goaviatrix.AccountUser, error) {
+	m.record("GetAccountUser", user)
+	if m.GetAccountUserFunc == nil {
+		return nil, nil
+	}
+	return m.GetAccountUserFunc(user)
+}
+
+func (m *Client) UpdateAccountUserObject(user *goaviatrix.AccountUserEdit) error {
+	m.record("UpdateAccountUserObject", user)
+	if m.UpdateAccountUserObjectFunc == nil {
+		return nil
+	}
+	return m.UpdateAccountUserObjectFunc(user)
+}
+
+func (m *Client) DeleteAccountUser(user *goaviatrix.AccountUser) error {
+	m.record("DeleteAccountUser", user)
+	if m.DeleteAccountUserFunc == nil {
+		return nil
+	}
+	return m.DeleteAccountUserFunc(user)
+}
+
+func (m *Client) CreateGateway(gateway *goaviatrix.Gateway) error {
+	m.record("CreateGateway", gateway)
+	if m.CreateGatewayFunc == nil {
+		return nil
+	}
+	return m.CreateGatewayFunc(gateway)
+}
+
+func (m *Client) CreateGatewayWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("CreateGatewayWithContext", ctx, gateway)
+	if m.CreateGatewayWithContextFunc == nil {
+		return nil
+	}
+	return m.CreateGatewayWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) GetGateway(gateway *goaviatrix.Gateway) (*goaviatrix.Gateway, error) {
+	m.record("GetGateway", gateway)
+	if m.GetGatewayFunc == nil {
+		return nil, nil
+	}
+	return m.GetGatewayFunc(gateway)
+}
+
+func (m *Client) GetGatewayWithContext(ctx context.Context, gateway *goaviatrix.Gateway) (*goaviatrix.Gateway, error) {
+	m.record("GetGatewayWithContext", ctx, gateway)
+	if m.GetGatewayWithContextFunc == nil {
+		return nil, nil
+	}
+	return m.GetGatewayWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) GetGatewayDetail(gateway *goaviatrix.Gateway) (*goaviatrix.GatewayDetail, error) {
+	m.record("GetGatewayDetail", gateway)
+	if m.GetGatewayDetailFunc == nil {
+		return nil, nil
+	}
+	return m.GetGatewayDetailFunc(gateway)
+}
+
+func (m *Client) UpdateGateway(gateway *goaviatrix.Gateway) error {
+	m.record("UpdateGateway", gateway)
+	if m.UpdateGatewayFunc == nil {
+		return nil
+	}
+	return m.UpdateGatewayFunc(gateway)
+}
+
+func (m *Client) UpdateGatewayWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("UpdateGatewayWithContext", ctx, gateway)
+	if m.UpdateGatewayWithContextFunc == nil {
+		return nil
+	}
+	return m.UpdateGatewayWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) DeleteGateway(gateway *goaviatrix.Gateway) error {
+	m.record("DeleteGateway", gateway)
+	if m.DeleteGatewayFunc == nil {
+		return nil
+	}
+	return m.DeleteGatewayFunc(gateway)
+}
+
+func (m *Client) DeleteGatewayWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("DeleteGatewayWithContext", ctx, gateway)
+	if m.DeleteGatewayWithContextFunc == nil {
+		return nil
+	}
+	return m.DeleteGatewayWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) EnableSingleAZGateway(gateway *goaviatrix.Gateway) error {
+	m.record("EnableSingleAZGateway", gateway)
+	if m.EnableSingleAZGatewayFunc == nil {
+		return nil
+	}
+	return m.EnableSingleAZGatewayFunc(gateway)
+}
+
+func (m *Client) EnableSingleAZGatewayWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("EnableSingleAZGatewayWithContext", ctx, gateway)
+	if m.EnableSingleAZGatewayWithContextFunc == nil {
+		return nil
+	}
+	return m.EnableSingleAZGatewayWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) DisableSingleAZGateway(gateway *goaviatrix.Gateway) error {
+	m.record("DisableSingleAZGateway", gateway)
+	if m.DisableSingleAZGatewayFunc == nil {
+		return nil
+	}
+	return m.DisableSingleAZGatewayFunc(gateway)
+}
+
+func (m *Client) DisableSingleAZGatewayWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("DisableSingleAZGatewayWithContext", ctx, gateway)
+	if m.DisableSingleAZGatewayWithContextFunc == nil {
+		return nil
+	}
+	return m.DisableSingleAZGatewayWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) EnablePeeringHaGateway(gateway *goaviatrix.Gateway) error {
+	m.record("EnablePeeringHaGateway", gateway)
+	if m.EnablePeeringHaGatewayFunc == nil {
+		return nil
+	}
+	return m.EnablePeeringHaGatewayFunc(gateway)
+}
+
+func (m *Client) EnablePeeringHaGatewayWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("EnablePeeringHaGatewayWithContext", ctx, gateway)
+	if m.EnablePeeringHaGatewayWithContextFunc == nil {
+		return nil
+	}
+	return m.EnablePeeringHaGatewayWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) EnableSNat(gateway *goaviatrix.Gateway) error {
+	m.record("EnableSNat", gateway)
+	if m.EnableSNatFunc == nil {
+		return nil
+	}
+	return m.EnableSNatFunc(gateway)
+}
+
+func (m *Client) EnableSNatWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("EnableSNatWithContext", ctx, gateway)
+	if m.EnableSNatWithContextFunc == nil {
+		return nil
+	}
+	return m.EnableSNatWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) DisableSNat(gateway *goaviatrix.Gateway) error {
+	m.record("DisableSNat", gateway)
+	if m.DisableSNatFunc == nil {
+		return nil
+	}
+	return m.DisableSNatFunc(gateway)
+}
+
+func (m *Client) DisableSNatWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("DisableSNatWithContext", ctx, gateway)
+	if m.DisableSNatWithContextFunc == nil {
+		return nil
+	}
+	return m.DisableSNatWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) UpdateVpnCidr(gateway *goaviatrix.Gateway) error {
+	m.record("UpdateVpnCidr", gateway)
+	if m.UpdateVpnCidrFunc == nil {
+		return nil
+	}
+	return m.UpdateVpnCidrFunc(gateway)
+}
+
+func (m *Client) UpdateVpnCidrWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("UpdateVpnCidrWithContext", ctx, gateway)
+	if m.UpdateVpnCidrWithContextFunc == nil {
+		return nil
+	}
+	return m.UpdateVpnCidrWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) UpdateMaxVpnConn(gateway *goaviatrix.Gateway) error {
+	m.record("UpdateMaxVpnConn", gateway)
+	if m.UpdateMaxVpnConnFunc == nil {
+		return nil
+	}
+	return m.UpdateMaxVpnConnFunc(gateway)
+}
+
+func (m *Client) UpdateMaxVpnConnWithContext(ctx context.Context, gateway *goaviatrix.Gateway) error {
+	m.record("UpdateMaxVpnConnWithContext", ctx, gateway)
+	if m.UpdateMaxVpnConnWithContextFunc == nil {
+		return nil
+	}
+	return m.UpdateMaxVpnConnWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) SetVpnGatewayAuthentication(gateway *goaviatrix.VpnGatewayAuth) error {
+	m.record("SetVpnGatewayAuthentication", gateway)
+	if m.SetVpnGatewayAuthenticationFunc == nil {
+		return nil
+	}
+	return m.SetVpnGatewayAuthenticationFunc(gateway)
+}
+
+func (m *Client) SetVpnGatewayAuthenticationWithContext(ctx context.Context, gateway *goaviatrix.VpnGatewayAuth) error {
+	m.record("SetVpnGatewayAuthenticationWithContext", ctx, gateway)
+	if m.SetVpnGatewayAuthenticationWithContextFunc == nil {
+		return nil
+	}
+	return m.SetVpnGatewayAuthenticationWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) WaitForGatewayReady(gwName string, timeout time.Duration) error {
+	m.record("WaitForGatewayReady", gwName, timeout)
+	if m.WaitForGatewayReadyFunc == nil {
+		return nil
+	}
+	return m.WaitForGatewayReadyFunc(gwName, timeout)
+}
+
+func (m *Client) WaitForGatewayReadyWithContext(ctx context.Context, gwName string, timeout time.Duration) error {
+	m.record("WaitForGatewayReadyWithContext", ctx, gwName, timeout)
+	if m.WaitForGatewayReadyWithContextFunc == nil {
+		return nil
+	}
+	return m.WaitForGatewayReadyWithContextFunc(ctx, gwName, timeout)
+}
+
+func (m *Client) WaitForGatewayDeleted(gwName string, timeout time.Duration) error {
+	m.record("WaitForGatewayDeleted", gwName, timeout)
+	if m.WaitForGatewayDeletedFunc == nil {
+		return nil
+	}
+	return m.WaitForGatewayDeletedFunc(gwName, timeout)
+}
+
+func (m *Client) WaitForGatewayDeletedWithContext(ctx context.Context, gwName string, timeout time.Duration) error {
+	m.record("WaitForGatewayDeletedWithContext", ctx, gwName, timeout)
+	if m.WaitForGatewayDeletedWithContextFunc == nil {
+		return nil
+	}
+	return m.WaitForGatewayDeletedWithContextFunc(ctx, gwName, timeout)
+}
+
+func (m *Client) GetSplitTunnel(splitTunnel *goaviatrix.SplitTunnel) (*goaviatrix.SplitTunnelUnit, error) {
+	m.record("GetSplitTunnel", splitTunnel)
+	if m.GetSplitTunnelFunc == nil {
+		return nil, nil
+	}
+	return m.GetSplitTunnelFunc(splitTunnel)
+}
+
+func (m *Client) ModifySplitTunnel(splitTunnel *goaviatrix.SplitTunnel) error {
+	m.record("ModifySplitTunnel", splitTunnel)
+	if m.ModifySplitTunnelFunc == nil {
+		return nil
+	}
+	return m.ModifySplitTunnelFunc(splitTunnel)
+}
+
+func (m *Client) ModifySplitTunnelWithContext(ctx context.Context, splitTunnel *goaviatrix.SplitTunnel) error {
+	m.record("ModifySplitTunnelWithContext", ctx, splitTunnel)
+	if m.ModifySplitTunnelWithContextFunc == nil {
+		return nil
+	}
+	return m.ModifySplitTunnelWithContextFunc(ctx, splitTunnel)
+}
+
+func (m *Client) GetDefaultTags() map[string]string {
+	m.record("GetDefaultTags")
+	if m.GetDefaultTagsFunc == nil {
+		return nil
+	}
+	return m.GetDefaultTagsFunc()
+}
+
+func (m *Client) GetTags(tags *goaviatrix.Tags) ([]string, error) {
+	m.record("GetTags", tags)
+	if m.GetTagsFunc == nil {
+		return nil, nil
+	}
+	return m.GetTagsFunc(tags)
+}
+
+func (m *Client) AddTags(tags *goaviatrix.Tags) error {
+	m.record("AddTags", tags)
+	if m.AddTagsFunc == nil {
+		return nil
+	}
+	return m.AddTagsFunc(tags)
+}
+
+func (m *Client) AddTagsWithContext(ctx context.Context, tags *goaviatrix.Tags) error {
+	m.record("AddTagsWithContext", ctx, tags)
+	if m.AddTagsWithContextFunc == nil {
+		return nil
+	}
+	return m.AddTagsWithContextFunc(ctx, tags)
+}
+
+func (m *Client) DeleteTags(tags *goaviatrix.Tags) error {
+	m.record("DeleteTags", tags)
+	if m.DeleteTagsFunc == nil {
+		return nil
+	}
+	return m.DeleteTagsFunc(tags)
+}
+
+func (m *Client) DeleteTagsWithContext(ctx context.Context, tags *goaviatrix.Tags) error {
+	m.record("DeleteTagsWithContext", ctx, tags)
+	if m.DeleteTagsWithContextFunc == nil {
+		return nil
+	}
+	return m.DeleteTagsWithContextFunc(ctx, tags)
+}
+
+func (m *Client) LaunchTransitVpc(gateway *goaviatrix.TransitVpc) error {
+	m.record("LaunchTransitVpc", gateway)
+	if m.LaunchTransitVpcFunc == nil {
+		return nil
+	}
+	return m.LaunchTransitVpcFunc(gateway)
+}
+
+func (m *Client) LaunchTransitVpcWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("LaunchTransitVpcWithContext", ctx, gateway)
+	if m.LaunchTransitVpcWithContextFunc == nil {
+		return nil
+	}
+	return m.LaunchTransitVpcWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) EnableHaTransitVpc(gateway *goaviatrix.TransitVpc) error {
+	m.record("EnableHaTransitVpc", gateway)
+	if m.EnableHaTransitVpcFunc == nil {
+		return nil
+	}
+	return m.EnableHaTransitVpcFunc(gateway)
+}
+
+func (m *Client) EnableHaTransitVpcWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("EnableHaTransitVpcWithContext", ctx, gateway)
+	if m.EnableHaTransitVpcWithContextFunc == nil {
+		return nil
+	}
+	return m.EnableHaTransitVpcWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) AttachTransitGWForHybrid(gateway *goaviatrix.TransitVpc) error {
+	m.record("AttachTransitGWForHybrid", gateway)
+	if m.AttachTransitGWForHybridFunc == nil {
+		return nil
+	}
+	return m.AttachTransitGWForHybridFunc(gateway)
+}
+
+func (m *Client) AttachTransitGWForHybridWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("AttachTransitGWForHybridWithContext", ctx, gateway)
+	if m.AttachTransitGWForHybridWithContextFunc == nil {
+		return nil
+	}
+	return m.AttachTransitGWForHybridWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) DetachTransitGWForHybrid(gateway *goaviatrix.TransitVpc) error {
+	m.record("DetachTransitGWForHybrid", gateway)
+	if m.DetachTransitGWForHybridFunc == nil {
+		return nil
+	}
+	return m.DetachTransitGWForHybridFunc(gateway)
+}
+
+func (m *Client) DetachTransitGWForHybridWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("DetachTransitGWForHybridWithContext", ctx, gateway)
+	if m.DetachTransitGWForHybridWithContextFunc == nil {
+		return nil
+	}
+	return m.DetachTransitGWForHybridWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) EnableConnectedTransit(gateway *goaviatrix.TransitVpc) error {
+	m.record("EnableConnectedTransit", gateway)
+	if m.EnableConnectedTransitFunc == nil {
+		return nil
+	}
+	return m.EnableConnectedTransitFunc(gateway)
+}
+
+func (m *Client) EnableConnectedTransitWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("EnableConnectedTransitWithContext", ctx, gateway)
+	if m.EnableConnectedTransitWithContextFunc == nil {
+		return nil
+	}
+	return m.EnableConnectedTransitWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) DisableConnectedTransit(gateway *goaviatrix.TransitVpc) error {
+	m.record("DisableConnectedTransit", gateway)
+	if m.DisableConnectedTransitFunc == nil {
+		return nil
+	}
+	return m.DisableConnectedTransitFunc(gateway)
+}
+
+func (m *Client) DisableConnectedTransitWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("DisableConnectedTransitWithContext", ctx, gateway)
+	if m.DisableConnectedTransitWithContextFunc == nil {
+		return nil
+	}
+	return m.DisableConnectedTransitWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) EnableGatewayFireNetInterfaces(gateway *goaviatrix.TransitVpc) error {
+	m.record("EnableGatewayFireNetInterfaces", gateway)
+	if m.EnableGatewayFireNetInterfacesFunc == nil {
+		return nil
+	}
+	return m.EnableGatewayFireNetInterfacesFunc(gateway)
+}
+
+func (m *Client) EnableGatewayFireNetInterfacesWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("EnableGatewayFireNetInterfacesWithContext", ctx, gateway)
+	if m.EnableGatewayFireNetInterfacesWithContextFunc == nil {
+		return nil
+	}
+	return m.EnableGatewayFireNetInterfacesWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) DisableGatewayFireNetInterfaces(gateway *goaviatrix.TransitVpc) error {
+	m.record("DisableGatewayFireNetInterfaces", gateway)
+	if m.DisableGatewayFireNetInterfacesFunc == nil {
+		return nil
+	}
+	return m.DisableGatewayFireNetInterfacesFunc(gateway)
+}
+
+func (m *Client) DisableGatewayFireNetInterfacesWithContext(ctx context.Context, gateway *goaviatrix.TransitVpc) error {
+	m.record("DisableGatewayFireNetInterfacesWithContext", ctx, gateway)
+	if m.DisableGatewayFireNetInterfacesWithContextFunc == nil {
+		return nil
+	}
+	return m.DisableGatewayFireNetInterfacesWithContextFunc(ctx, gateway)
+}
+
+func (m *Client) CreateTransitGatewayPeering(transitGatewayPeering *goaviatrix.TransitGatewayPeering) error {
+	m.record("CreateTransitGatewayPeering", transitGatewayPeering)
+	if m.CreateTransitGatewayPeeringFunc == nil {
+		return nil
+	}
+	return m.CreateTransitGatewayPeeringFunc(transitGatewayPeering)
+}
+
+func (m *Client) GetTransitGatewayPeering(transitGatewayPeering *goaviatrix.TransitGatewayPeering) error {
+	m.record("GetTransitGatewayPeering", transitGatewayPeering)
+	if m.GetTransitGatewayPeeringFunc == nil {
+		return nil
+	}
+	return m.GetTransitGatewayPeeringFunc(transitGatewayPeering)
+}
+
+func (m *Client) DeleteTransitGatewayPeering(transitGatewayPeering *goaviatrix.TransitGatewayPeering) error {
+	m.record("DeleteTransitGatewayPeering", transitGatewayPeering)
+	if m.DeleteTransitGatewayPeeringFunc == nil {
+		return nil
+	}
+	return m.DeleteTransitGatewayPeeringFunc(transitGatewayPeering)
+}
+
+func (m *Client) CreateVGWConn(vgwConn *goaviatrix.VGWConn) error {
+	m.record("CreateVGWConn", vgwConn)
+	if m.CreateVGWConnFunc == nil {
+		return nil
+	}
+	return m.CreateVGWConnFunc(vgwConn)
+}
+
+func (m *Client) GetVGWConn(vgwConn *goaviatrix.VGWConn) (*goaviatrix.VGWConn, error) {
+	m.record("GetVGWConn", vgwConn)
+	if m.GetVGWConnFunc == nil {
+		return nil, nil
+	}
+	return m.GetVGWConnFunc(vgwConn)
+}
+
+func (m *Client) GetVGWConnDetail(vgwConn *goaviatrix.VGWConn) (*goaviatrix.VGWConn, error) {
+	m.record("GetVGWConnDetail", vgwConn)
+	if m.GetVGWConnDetailFunc == nil {
+		return nil, nil
+	}
+	return m.GetVGWConnDetailFunc(vgwConn)
+}
+
+func (m *Client) DeleteVGWConn(vgwConn *goaviatrix.VGWConn) error {
+	m.record("DeleteVGWConn", vgwConn)
+	if m.DeleteVGWConnFunc == nil {
+		return nil
+	}
+	return m.DeleteVGWConnFunc(vgwConn)
+}
+
+func (m *Client) EnableAdvertiseTransitCidr(vgwConn *goaviatrix.VGWConn) error {
+	m.record("EnableAdvertiseTransitCidr", vgwConn)
+	if m.EnableAdvertiseTransitCidrFunc == nil {
+		return nil
+	}
+	return m.EnableAdvertiseTransitCidrFunc(vgwConn)
+}
+
+func (m *Client) DisableAdvertiseTransitCidr(vgwConn *goaviatrix.VGWConn) error {
+	m.record("DisableAdvertiseTransitCidr", vgwConn)
+	if m.DisableAdvertiseTransitCidrFunc == nil {
+		return nil
+	}
+	return m.DisableAdvertiseTransitCidrFunc(vgwConn)
+}
+
+func (m *Client) SetBgpManualSpokeAdvertisedNetworks(vgwConn *goaviatrix.VGWConn) error {
+	m.record("SetBgpManualSpokeAdvertisedNetworks", vgwConn)
+	if m.SetBgpManualSpokeAdvertisedNetworksFunc == nil {
+		return nil
+	}
+	return m.SetBgpManualSpokeAdvertisedNetworksFunc(vgwConn)
+}
+
+func (m *Client) DisableBgpManualSpokeAdvertisedNetworks(vgwConn *goaviatrix.VGWConn) error {
+	m.record("DisableBgpManualSpokeAdvertisedNetworks", vgwConn)
+	if m.DisableBgpManualSpokeAdvertisedNetworksFunc == nil {
+		return nil
+	}
+	return m.DisableBgpManualSpokeAdvertisedNetworksFunc(vgwConn)
+}
+
+func (m *Client) LaunchSpokeVpc(spoke *goaviatrix.SpokeVpc) error {
+	m.record("LaunchSpokeVpc", spoke)
+	if m.LaunchSpokeVpcFunc == nil {
+		return nil
+	}
+	return m.LaunchSpokeVpcFunc(spoke)
+}
+
+func (m *Client) LaunchSpokeVpcWithContext(ctx context.Context, spoke *goaviatrix.SpokeVpc) error {
+	m.record("LaunchSpokeVpcWithContext", ctx, spoke)
+	if m.LaunchSpokeVpcWithContextFunc == nil {
+		return nil
+	}
+	return m.LaunchSpokeVpcWithContextFunc(ctx, spoke)
+}
+
+func (m *Client) EnableHaSpokeVpc(spoke *goaviatrix.SpokeVpc) error {
+	m.record("EnableHaSpokeVpc", spoke)
+	if m.EnableHaSpokeVpcFunc == nil {
+		return nil
+	}
+	return m.EnableHaSpokeVpcFunc(spoke)
+}
+
+func (m *Client) EnableHaSpokeVpcWithContext(ctx context.Context, spoke *goaviatrix.SpokeVpc) error {
+	m.record("EnableHaSpokeVpcWithContext", ctx, spoke)
+	if m.EnableHaSpokeVpcWithContextFunc == nil {
+		return nil
+	}
+	return m.EnableHaSpokeVpcWithContextFunc(ctx, spoke)
+}
+
+func (m *Client) SpokeJoinTransit(spoke *goaviatrix.SpokeVpc) error {
+	m.record("SpokeJoinTransit", spoke)
+	if m.SpokeJoinTransitFunc == nil {
+		return nil
+	}
+	return m.SpokeJoinTransitFunc(spoke)
+}
+
+func (m *Client) SpokeJoinTransitWithContext(ctx context.Context, spoke *goaviatrix.SpokeVpc) error {
+	m.record("SpokeJoinTransitWithContext", ctx, spoke)
+	if m.SpokeJoinTransitWithContextFunc == nil {
+		return nil
+	}
+	return m.SpokeJoinTransitWithContextFunc(ctx, spoke)
+}
+
+func (m *Client) SpokeLeaveTransit(spoke *goaviatrix.SpokeVpc) error {
+	m.record("SpokeLeaveTransit", spoke)
+	if m.SpokeLeaveTransitFunc == nil {
+		return nil
+	}
+	return m.SpokeLeaveTransitFunc(spoke)
+}
+
+func (m *Client) SpokeLeaveTransitWithContext(ctx context.Context, spoke *goaviatrix.SpokeVpc) error {
+	m.record("SpokeLeaveTransitWithContext", ctx, spoke)
+	if m.SpokeLeaveTransitWithContextFunc == nil {
+		return nil
+	}
+	return m.SpokeLeaveTransitWithContextFunc(ctx, spoke)
+}
+
+func (m *Client) CreateFQDN(fqdn *goaviatrix.FQDN) error {
+	m.record("CreateFQDN", fqdn)
+	if m.CreateFQDNFunc == nil {
+		return nil
+	}
+	return m.CreateFQDNFunc(fqdn)
+}
+
+func (m *Client) GetFQDNTag(fqdn *goaviatrix.FQDN) (*goaviatrix.FQDN, error) {
+	m.record("GetFQDNTag", fqdn)
+	if m.GetFQDNTagFunc == nil {
+		return nil, nil
+	}
+	return m.GetFQDNTagFunc(fqdn)
+}
+
+func (m *Client) DeleteFQDN(fqdn *goaviatrix.FQDN) error {
+	m.record("DeleteFQDN", fqdn)
+	if m.DeleteFQDNFunc == nil {
+		return nil
+	}
+	return m.DeleteFQDNFunc(fqdn)
+}
+
+func (m *Client) UpdateFQDNStatus(fqdn *goaviatrix.FQDN) error {
+	m.record("UpdateFQDNStatus", fqdn)
+	if m.UpdateFQDNStatusFunc == nil {
+		return nil
+	}
+	return m.UpdateFQDNStatusFunc(fqdn)
+}
+
+func (m *Client) UpdateFQDNMode(fqdn *goaviatrix.FQDN) error {
+	m.record("UpdateFQDNMode", fqdn)
+	if m.UpdateFQDNModeFunc == nil {
+		return nil
+	}
+	return m.UpdateFQDNModeFunc(fqdn)
+}
+
+func (m *Client) UpdateDomains(fqdn *goaviatrix.FQDN) error {
+	m.record("UpdateDomains", fqdn)
+	if m.UpdateDomainsFunc == nil {
+		return nil
+	}
+	return m.UpdateDomainsFunc(fqdn)
+}
+
+func (m *Client) ListDomains(fqdn *goaviatrix.FQDN) (*goaviatrix.FQDN, error) {
+	m.record("ListDomains", fqdn)
+	if m.ListDomainsFunc == nil {
+		return nil, nil
+	}
+	return m.ListDomainsFunc(fqdn)
+}
+
+func (m *Client) ListGws(fqdn *goaviatrix.FQDN) ([]string, error) {
+	m.record("ListGws", fqdn)
+	if m.ListGwsFunc == nil {
+		return nil, nil
+	}
+	return m.ListGwsFunc(fqdn)
+}
+
+func (m *Client) AttachTagToGw(fqdn *goaviatrix.FQDN, gateway *goaviatrix.Gateway) error {
+	m.record("AttachTagToGw", fqdn, gateway)
+	if m.AttachTagToGwFunc == nil {
+		return nil
+	}
+	return m.AttachTagToGwFunc(fqdn, gateway)
+}
+
+func (m *Client) DetachGws(fqdn *goaviatrix.FQDN, gwList []string) error {
+	m.record("DetachGws", fqdn, gwList)
+	if m.DetachGwsFunc == nil {
+		return nil
+	}
+	return m.DetachGwsFunc(fqdn, gwList)
+}
+
+func (m *Client) GetGwFilterTagList(fqdn *goaviatrix.FQDN) (*goaviatrix.FQDN, error) {
+	m.record("GetGwFilterTagList", fqdn)
+	if m.GetGwFilterTagListFunc == nil {
+		return nil, nil
+	}
+	return m.GetGwFilterTagListFunc(fqdn)
+}
+
+func (m *Client) UpdateSourceIPFilters(fqdn *goaviatrix.FQDN, gateway *goaviatrix.Gateway, sourceIPs []string) error {
+	m.record("UpdateSourceIPFilters", fqdn, gateway, sourceIPs)
+	if m.UpdateSourceIPFiltersFunc == nil {
+		return nil
+	}
+	return m.UpdateSourceIPFiltersFunc(fqdn, gateway, sourceIPs)
+}
+
+func (m *Client) SetBasePolicy(firewall *goaviatrix.Firewall) error {
+	m.record("SetBasePolicy", firewall)
+	if m.SetBasePolicyFunc == nil {
+		return nil
+	}
+	return m.SetBasePolicyFunc(firewall)
+}
+
+func (m *Client) GetPolicy(firewall *goaviatrix.Firewall) (*goaviatrix.Firewall, error) {
+	m.record("GetPolicy", firewall)
+	if m.GetPolicyFunc == nil {
+		return nil, nil
+	}
+	return m.GetPolicyFunc(firewall)
+}
+
+func (m *Client) UpdatePolicy(firewall *goaviatrix.Firewall) error {
+	m.record("UpdatePolicy", firewall)
+	if m.UpdatePolicyFunc == nil {
+		return nil
+	}
+	return m.UpdatePolicyFunc(firewall)
+}
+
+func (m *Client) ValidatePolicy(policy *goaviatrix.Policy) error {
+	m.record("ValidatePolicy", policy)
+	if m.ValidatePolicyFunc == nil {
+		return nil
+	}
+	return m.ValidatePolicyFunc(policy)
+}
+
+func (m *Client) CreateFirewallTag(firewallTag *goaviatrix.FirewallTag) error {
+	m.record("CreateFirewallTag", firewallTag)
+	if m.CreateFirewallTagFunc == nil {
+		return nil
+	}
+	return m.CreateFirewallTagFunc(firewallTag)
+}
+
+func (m *Client) GetFirewallTag(firewallTag *goaviatrix.FirewallTag) (*goaviatrix.FirewallTag, error) {
+	m.record("GetFirewallTag", firewallTag)
+	if m.GetFirewallTagFunc == nil {
+		return nil, nil
+	}
+	return m.GetFirewallTagFunc(firewallTag)
+}
+
+func (m *Client) UpdateFirewallTag(firewallTag *goaviatrix.FirewallTag) error {
+	m.record("UpdateFirewallTag", firewallTag)
+	if m.UpdateFirewallTagFunc == nil {
+		return nil
+	}
+	return m.UpdateFirewallTagFunc(firewallTag)
+}
+
+func (m *Client) DeleteFirewallTag(firewallTag *goaviatrix.FirewallTag) error {
+	m.record("DeleteFirewallTag", firewallTag)
+	if m.DeleteFirewallTagFunc == nil {
+		return nil
+	}
+	return m.DeleteFirewallTagFunc(firewallTag)
+}
+
+func (m *Client) CreateSite2Cloud(site2cloud *goaviatrix.Site2Cloud) error {
+	m.record("CreateSite2Cloud", site2cloud)
+	if m.CreateSite2CloudFunc == nil {
+		return nil
+	}
+	return m.CreateSite2CloudFunc(site2cloud)
+}
+
+func (m *Client) GetSite2Cloud(site2cloud *goaviatrix.Site2Cloud) (*goaviatrix.Site2Cloud, error) {
+	m.record("GetSite2Cloud", site2cloud)
+	if m.GetSite2CloudFunc == nil {
+		return nil, nil
+	}
+	return m.GetSite2CloudFunc(site2cloud)
+}
+
+func (m *Client) GetSite2CloudConnDetail(site2cloud *goaviatrix.Site2Cloud) (*goaviatrix.Site2Cloud, error) {
+	m.record("GetSite2CloudConnDetail", site2cloud)
+	if m.GetSite2CloudConnDetailFunc == nil {
+		return nil, nil
+	}
+	return m.GetSite2CloudConnDetailFunc(site2cloud)
+}
+
+func (m *Client) UpdateSite2Cloud(site2cloud *goaviatrix.EditSite2Cloud) error {
+	m.record("UpdateSite2Cloud", site2cloud)
+	if m.UpdateSite2CloudFunc == nil {
+		return nil
+	}
+	return m.UpdateSite2CloudFunc(site2cloud)
+}
+
+func (m *Client) DeleteSite2Cloud(site2cloud *goaviatrix.Site2Cloud) error {
+	m.record("DeleteSite2Cloud", site2cloud)
+	if m.DeleteSite2CloudFunc == nil {
+		return nil
+	}
+	return m.DeleteSite2CloudFunc(site2cloud)
+}
+
+func (m *Client) Site2CloudAlgorithmCheck(site2cloud *goaviatrix.Site2Cloud) error {
+	m.record("Site2CloudAlgorithmCheck", site2cloud)
+	if m.Site2CloudAlgorithmCheckFunc == nil {
+		return nil
+	}
+	return m.Site2CloudAlgorithmCheckFunc(site2cloud)
+}
+
+func (m *Client) EnableDeadPeerDetection(site2cloud *goaviatrix.Site2Cloud) error {
+	m.record("EnableDeadPeerDetection", site2cloud)
+	if m.EnableDeadPeerDetectionFunc == nil {
+		return nil
+	}
+	return m.EnableDeadPeerDetectionFunc(site2cloud)
+}
+
+func (m *Client) DisableDeadPeerDetection(site2cloud *goaviatrix.Site2Cloud) error {
+	m.record("DisableDeadPeerDetection", site2cloud)
+	if m.DisableDeadPeerDetectionFunc == nil {
+		return nil
+	}
+	return m.DisableDeadPeerDetectionFunc(site2cloud)
+}
+
+func (m *Client) WaitForSite2CloudTunnel(site2cloud *goaviatrix.Site2Cloud, statuses []string, timeout time.Duration) error {
+	m.record("WaitForSite2CloudTunnel", site2cloud, statuses, timeout)
+	if m.WaitForSite2CloudTunnelFunc == nil {
+		return nil
+	}
+	return m.WaitForSite2CloudTunnelFunc(site2cloud, statuses, timeout)
+}
+
+func (m *Client) CreateAWSTgw(awsTgw *goaviatrix.AWSTgw) error {
+	m.record("CreateAWSTgw", awsTgw)
+	if m.CreateAWSTgwFunc == nil {
+		return nil
+	}
+	return m.CreateAWSTgwFunc(awsTgw)
+}
+
+func (m *Client) GetAWSTgw(awsTgw *goaviatrix.AWSTgw) (*goaviatrix.AWSTgw, error) {
+	m.record("GetAWSTgw", awsTgw)
+	if m.GetAWSTgwFunc == nil {
+		return nil, nil
+	}
+	return m.GetAWSTgwFunc(awsTgw)
+}
+
+func (m *Client) ListTgwDetails(awsTgw *goaviatrix.AWSTgw) (*goaviatrix.AWSTgw, error) {
+	m.record("ListTgwDetails", awsTgw)
+	if m.ListTgwDetailsFunc == nil {
+		return nil, nil
+	}
+	return m.ListTgwDetailsFunc(awsTgw)
+}
+
+func (m *Client) DeleteAWSTgw(awsTgw *goaviatrix.AWSTgw) error {
+	m.record("DeleteAWSTgw", awsTgw)
+	if m.DeleteAWSTgwFunc == nil {
+		return nil
+	}
+	return m.DeleteAWSTgwFunc(awsTgw)
+}
+
+func (m *Client) WaitForAWSTgwAvailable(awsTgw *goaviatrix.AWSTgw, timeout time.Duration) error {
+	m.record("WaitForAWSTgwAvailable", awsTgw, timeout)
+	if m.WaitForAWSTgwAvailableFunc == nil {
+		return nil
+	}
+	return m.WaitForAWSTgwAvailableFunc(awsTgw, timeout)
+}
+
+func (m *Client) WaitForAWSTgwDeleted(awsTgw *goaviatrix.AWSTgw, timeout time.Duration) error {
+	m.record("WaitForAWSTgwDeleted", awsTgw, timeout)
+	if m.WaitForAWSTgwDeletedFunc == nil {
+		return nil
+	}
+	return m.WaitForAWSTgwDeletedFunc(awsTgw, timeout)
+}
+
+func (m *Client) ValidateAWSTgwDomains(domainsAll []string, domainConnAll [][]string, attachedVPCAll [][]string) ([]string, [][]string, [][]string, error) {
+	m.record("ValidateAWSTgwDomains", domainsAll, domainConnAll, attachedVPCAll)
+	if m.ValidateAWSTgwDomainsFunc == nil {
+		return nil, nil, nil, nil
+	}
+	return m.ValidateAWSTgwDomainsFunc(domainsAll, domainConnAll, attachedVPCAll)
+}
+
+func (m *Client) AttachAviatrixTransitGWToAWSTgw(awsTgw *goaviatrix.AWSTgw, gateway *goaviatrix.Gateway, SecurityDomainName string) error {
+	m.record("AttachAviatrixTransitGWToAWSTgw", awsTgw, gateway, SecurityDomainName)
+	if m.AttachAviatrixTransitGWToAWSTgwFunc == nil {
+		return nil
+	}
+	return m.AttachAviatrixTransitGWToAWSTgwFunc(awsTgw, gateway, SecurityDomainName)
+}
+
+func (m *Client) DetachAviatrixTransitGWFromAWSTgw(awsTgw *goaviatrix.AWSTgw, gateway *goaviatrix.Gateway, SecurityDomainName string) error {
+	m.record("DetachAviatrixTransitGWFromAWSTgw", awsTgw, gateway, SecurityDomainName)
+	if m.DetachAviatrixTransitGWFromAWSTgwFunc == nil {
+		return nil
+	}
+	return m.DetachAviatrixTransitGWFromAWSTgwFunc(awsTgw, gateway, SecurityDomainName)
+}
+
+func (m *Client) AttachVpcToAWSTgw(awsTgw *goaviatrix.AWSTgw, vpcSolo goaviatrix.VPCSolo, SecurityDomainName string) error {
+	m.record("AttachVpcToAWSTgw", awsTgw, vpcSolo, SecurityDomainName)
+	if m.AttachVpcToAWSTgwFunc == nil {
+		return nil
+	}
+	return m.AttachVpcToAWSTgwFunc(awsTgw, vpcSolo, SecurityDomainName)
+}
+
+func (m *Client) DetachVpcFromAWSTgw(awsTgw *goaviatrix.AWSTgw, vpcID string) error {
+	m.record("DetachVpcFromAWSTgw", awsTgw, vpcID)
+	if m.DetachVpcFromAWSTgwFunc == nil {
+		return nil
+	}
+	return m.DetachVpcFromAWSTgwFunc(awsTgw, vpcID)
+}
+
+func (m *Client) IsVpcAttachedToTgw(awsTgw *goaviatrix.AWSTgw, vpcSolo *goaviatrix.VPCSolo) (bool, error) {
+	m.record("IsVpcAttachedToTgw", awsTgw, vpcSolo)
+	if m.IsVpcAttachedToTgwFunc == nil {
+		return false, nil
+	}
+	return m.IsVpcAttachedToTgwFunc(awsTgw, vpcSolo)
+}
+
+func (m *Client) CreateSecurityDomain(securityDomain *goaviatrix.SecurityDomain) error {
+	m.record("CreateSecurityDomain", securityDomain)
+	if m.CreateSecurityDomainFunc == nil {
+		return nil
+	}
+	return m.CreateSecurityDomainFunc(securityDomain)
+}
+
+func (m *Client) DeleteSecurityDomain(securityDomain *goaviatrix.SecurityDomain) error {
+	m.record("DeleteSecurityDomain", securityDomain)
+	if m.DeleteSecurityDomainFunc == nil {
+		return nil
+	}
+	return m.DeleteSecurityDomainFunc(securityDomain)
+}
+
+func (m *Client) CreateDomainConnection(awsTgw *goaviatrix.AWSTgw, sourceDomain string, destinationDomain string) error {
+	m.record("CreateDomainConnection", awsTgw, sourceDomain, destinationDomain)
+	if m.CreateDomainConnectionFunc == nil {
+		return nil
+	}
+	return m.CreateDomainConnectionFunc(awsTgw, sourceDomain, destinationDomain)
+}
+
+func (m *Client) DeleteDomainConnection(awsTgw *goaviatrix.AWSTgw, sourceDomain string, destinationDomain string) error {
+	m.record("DeleteDomainConnection", awsTgw, sourceDomain, destinationDomain)
+	if m.DeleteDomainConnectionFunc == nil {
+		return nil
+	}
+	return m.DeleteDomainConnectionFunc(awsTgw, sourceDomain, destinationDomain)
+}
+
+func (m *Client) CreateAwsTgwVpcAttachment(awsTgwVpcAttachment *goaviatrix.AwsTgwVpcAttachment) error {
+	m.record("CreateAwsTgwVpcAttachment", awsTgwVpcAttachment)
+	if m.CreateAwsTgwVpcAttachmentFunc == nil {
+		return nil
+	}
+	return m.CreateAwsTgwVpcAttachmentFunc(awsTgwVpcAttachment)
+}
+
+func (m *Client) GetAwsTgwVpcAttachment(awsTgwVpcAttachment *goaviatrix.AwsTgwVpcAttachment) (*goaviatrix.AwsTgwVpcAttachment, error) {
+	m.record("GetAwsTgwVpcAttachment", awsTgwVpcAttachment)
+	if m.GetAwsTgwVpcAttachmentFunc == nil {
+		return nil, nil
+	}
+	return m.GetAwsTgwVpcAttachmentFunc(awsTgwVpcAttachment)
+}
+
+func (m *Client) DeleteAwsTgwVpcAttachment(awsTgwVpcAttachment *goaviatrix.AwsTgwVpcAttachment) error {
+	m.record("DeleteAwsTgwVpcAttachment", awsTgwVpcAttachment)
+	if m.DeleteAwsTgwVpcAttachmentFunc == nil {
+		return nil
+	}
+	return m.DeleteAwsTgwVpcAttachmentFunc(awsTgwVpcAttachment)
+}
+
+func (m *Client) CreateAwsTgwVpnConn(awsTgwVpnConn *goaviatrix.AwsTgwVpnConn) (string, error) {
+	m.record("CreateAwsTgwVpnConn", awsTgwVpnConn)
+	if m.CreateAwsTgwVpnConnFunc == nil {
+		return "", nil
+	}
+	return m.CreateAwsTgwVpnConnFunc(awsTgwVpnConn)
+}
+
+func (m *Client) GetAwsTgwVpnConn(awsTgwVpnConn *goaviatrix.AwsTgwVpnConn) (*goaviatrix.AwsTgwVpnConn, error) {
+	m.record("GetAwsTgwVpnConn", awsTgwVpnConn)
+	if m.GetAwsTgwVpnConnFunc == nil {
+		return nil, nil
+	}
+	return m.GetAwsTgwVpnConnFunc(awsTgwVpnConn)
+}
+
+func (m *Client) DeleteAwsTgwVpnConn(awsTgwVpnConn *goaviatrix.AwsTgwVpnConn) error {
+	m.record("DeleteAwsTgwVpnConn", awsTgwVpnConn)
+	if m.DeleteAwsTgwVpnConnFunc == nil {
+		return nil
+	}
+	return m.DeleteAwsTgwVpnConnFunc(awsTgwVpnConn)
+}
+
+func (m *Client) WaitForAwsTgwVpnConnDeleted(awsTgwVpnConn *goaviatrix.AwsTgwVpnConn, timeout time.Duration) error {
+	m.record("WaitForAwsTgwVpnConnDeleted", awsTgwVpnConn, timeout)
+	if m.WaitForAwsTgwVpnConnDeletedFunc == nil {
+		return nil
+	}
+	return m.WaitForAwsTgwVpnConnDeletedFunc(awsTgwVpnConn, timeout)
+}
+
+func (m *Client) CreateVPNUser(vpnUser *goaviatrix.VPNUser) error {
+	m.record("CreateVPNUser", vpnUser)
+	if m.CreateVPNUserFunc == nil {
+		return nil
+	}
+	return m.CreateVPNUserFunc(vpnUser)
+}
+
+func (m *Client) GetVPNUser(vpnUser *goaviatrix.VPNUser) (*goaviatrix.VPNUser, error) {
+	m.record("GetVPNUser", vpnUser)
+	if m.GetVPNUserFunc == nil {
+		return nil, nil
+	}
+	return m.GetVPNUserFunc(vpnUser)
+}
+
+func (m *Client) DeleteVPNUser(vpnUser *goaviatrix.VPNUser) error {
+	m.record("DeleteVPNUser", vpnUser)
+	if m.DeleteVPNUserFunc == nil {
+		return nil
+	}
+	return m.DeleteVPNUserFunc(vpnUser)
+}
+
+func (m *Client) CreateProfile(profile *goaviatrix.Profile) error {
+	m.record("CreateProfile", profile)
+	if m.CreateProfileFunc == nil {
+		return nil
+	}
+	return m.CreateProfileFunc(profile)
+}
+
+func (m *Client) GetProfile(profile *goaviatrix.Profile) (*goaviatrix.Profile, error) {
+	m.record("GetProfile", profile)
+	if m.GetProfileFunc == nil {
+		return nil, nil
+	}
+	return m.GetProfileFunc(profile)
+}
+
+func (m *Client) GetProfileBasePolicy(profile *goaviatrix.Profile) (*goaviatrix.Profile, error) {
+	m.record("GetProfileBasePolicy", profile)
+	if m.GetProfileBasePolicyFunc == nil {
+		return nil, nil
+	}
+	return m.GetProfileBasePolicyFunc(profile)
+}
+
+func (m *Client) UpdateProfilePolicy(profile *goaviatrix.Profile) error {
+	m.record("UpdateProfilePolicy", profile)
+	if m.UpdateProfilePolicyFunc == nil {
+		return nil
+	}
+	return m.UpdateProfilePolicyFunc(profile)
+}
+
+func (m *Client) DeleteProfile(profile *goaviatrix.Profile) error {
+	m.record("DeleteProfile", profile)
+	if m.DeleteProfileFunc == nil {
+		return nil
+	}
+	return m.DeleteProfileFunc(profile)
+}
+
+func (m *Client) AttachUsers(profile *goaviatrix.Profile) error {
+	m.record("AttachUsers", profile)
+	if m.AttachUsersFunc == nil {
+		return nil
+	}
+	return m.AttachUsersFunc(profile)
+}
+
+func (m *Client) DetachUsers(profile *goaviatrix.Profile) error {
+	m.record("DetachUsers", profile)
+	if m.DetachUsersFunc == nil {
+		return nil
+	}
+	return m.DetachUsersFunc(profile)
+}
+
+func (m *Client) ValidateProfileRule(profileRule *goaviatrix.ProfileRule) error {
+	m.record("ValidateProfileRule", profileRule)
+	if m.ValidateProfileRuleFunc == nil {
+		return nil
+	}
+	return m.ValidateProfileRuleFunc(profileRule)
+}
+
+func (m *Client) GetVpnUserAccelerator() ([]string, error) {
+	m.record("GetVpnUserAccelerator")
+	if m.GetVpnUserAcceleratorFunc == nil {
+		return nil, nil
+	}
+	return m.GetVpnUserAcceleratorFunc()
+}
+
+func (m *Client) UpdateVpnUserAccelerator(xlr *goaviatrix.VpnUserXlr) error {
+	m.record("UpdateVpnUserAccelerator", xlr)
+	if m.UpdateVpnUserAcceleratorFunc == nil {
+		return nil
+	}
+	return m.UpdateVpnUserAcceleratorFunc(xlr)
+}
+
+func (m *Client) WaitForVpnUserAcceleratorElb(elb string, timeout time.Duration) error {
+	m.record("WaitForVpnUserAcceleratorElb", elb, timeout)
+	if m.WaitForVpnUserAcceleratorElbFunc == nil {
+		return nil
+	}
+	return m.WaitForVpnUserAcceleratorElbFunc(elb, timeout)
+}
+
+func (m *Client) GetCID() string {
+	m.record("GetCID")
+	if m.GetCIDFunc == nil {
+		return ""
+	}
+	return m.GetCIDFunc()
+}
+
+func (m *Client) GetControllerIP() string {
+	m.record("GetControllerIP")
+	if m.GetControllerIPFunc == nil {
+		return ""
+	}
+	return m.GetControllerIPFunc()
+}
+
+func (m *Client) ControllerVersionValidation(supported goaviatrix.VersionRange) error {
+	m.record("ControllerVersionValidation", supported)
+	if m.ControllerVersionValidationFunc == nil {
+		return nil
+	}
+	return m.ControllerVersionValidationFunc(supported)
+}
+
+func (m *Client) RequireCapability(capability goaviatrix.Capability) error {
+	m.record("RequireCapability", capability)
+	if m.RequireCapabilityFunc == nil {
+		return nil
+	}
+	return m.RequireCapabilityFunc(capability)
+}
+
+func (m *Client) GetCurrentVersion() (string, *goaviatrix.AviatrixVersion, error) {
+	m.record("GetCurrentVersion")
+	if m.GetCurrentVersionFunc == nil {
+		return "", nil, nil
+	}
+	return m.GetCurrentVersionFunc()
+}
+
+func (m *Client) GetLatestVersion() (string, error) {
+	m.record("GetLatestVersion")
+	if m.GetLatestVersionFunc == nil {
+		return "", nil
+	}
+	return m.GetLatestVersionFunc()
+}
+
+func (m *Client) Upgrade(version *goaviatrix.Version) error {
+	m.record("Upgrade", version)
+	if m.UpgradeFunc == nil {
+		return nil
+	}
+	return m.UpgradeFunc(version)
+}
+
+func (m *Client) WaitForVersion(version string, timeout time.Duration) error {
+	m.record("WaitForVersion", version, timeout)
+	if m.WaitForVersionFunc == nil {
+		return nil
+	}
+	return m.WaitForVersionFunc(version, timeout)
+}
+
+func (m *Client) GetHttpAccessEnabled() (string, error) {
+	m.record("GetHttpAccessEnabled")
+	if m.GetHttpAccessEnabledFunc == nil {
+		return "", nil
+	}
+	return m.GetHttpAccessEnabledFunc()
+}
+
+func (m *Client) EnableHttpAccess() error {
+	m.record("EnableHttpAccess")
+	if m.EnableHttpAccessFunc == nil {
+		return nil
+	}
+	return m.EnableHttpAccessFunc()
+}
+
+func (m *Client) DisableHttpAccess() error {
+	m.record("DisableHttpAccess")
+	if m.DisableHttpAccessFunc == nil {
+		return nil
+	}
+	return m.DisableHttpAccessFunc()
+}
+
+func (m *Client) WaitForHttpAccess(enabled bool, timeout time.Duration) error {
+	m.record("WaitForHttpAccess", enabled, timeout)
+	if m.WaitForHttpAccessFunc == nil {
+		return nil
+	}
+	return m.WaitForHttpAccessFunc(enabled, timeout)
+}
+
+func (m *Client) GetExceptionRuleStatus() (bool, error) {
+	m.record("GetExceptionRuleStatus")
+	if m.GetExceptionRuleStatusFunc == nil {
+		return false, nil
+	}
+	return m.GetExceptionRuleStatusFunc()
+}
+
+func (m *Client) EnableExceptionRule() error {
+	m.record("EnableExceptionRule")
+	if m.EnableExceptionRuleFunc == nil {
+		return nil
+	}
+	return m.EnableExceptionRuleFunc()
+}
+
+func (m *Client) DisableExceptionRule() error {
+	m.record("DisableExceptionRule")
+	if m.DisableExceptionRuleFunc == nil {
+		return nil
+	}
+	return m.DisableExceptionRuleFunc()
+}
+
+func (m *Client) GetSecurityGroupManagementStatus() (*goaviatrix.SecurityGroupInfo, error) {
+	m.record("GetSecurityGroupManagementStatus")
+	if m.GetSecurityGroupManagementStatusFunc == nil {
+		return nil, nil
+	}
+	return m.GetSecurityGroupManagementStatusFunc()
+}
+
+func (m *Client) EnableSecurityGroupManagement(account string) error {
+	m.record("EnableSecurityGroupManagement", account)
+	if m.EnableSecurityGroupManagementFunc == nil {
+		return nil
+	}
+	return m.EnableSecurityGroupManagementFunc(account)
+}
+
+func (m *Client) DisableSecurityGroupManagement() error {
+	m.record("DisableSecurityGroupManagement")
+	if m.DisableSecurityGroupManagementFunc == nil {
+		return nil
+	}
+	return m.DisableSecurityGroupManagementFunc()
+}
+
+func (m *Client) CreateAWSPeer(awsPeer *goaviatrix.AWSPeer) (string, error) {
+	m.record("CreateAWSPeer", awsPeer)
+	if m.CreateAWSPeerFunc == nil {
+		return "", nil
+	}
+	return m.CreateAWSPeerFunc(awsPeer)
+}
+
+func (m *Client) GetAWSPeer(awsPeer *goaviatrix.AWSPeer) (*goaviatrix.AWSPeer, error) {
+	m.record("GetAWSPeer", awsPeer)
+	if m.GetAWSPeerFunc == nil {
+		return nil, nil
+	}
+	return m.GetAWSPeerFunc(awsPeer)
+}
+
+func (m *Client) DeleteAWSPeer(awsPeer *goaviatrix.AWSPeer) error {
+	m.record("DeleteAWSPeer", awsPeer)
+	if m.DeleteAWSPeerFunc == nil {
+		return nil
+	}
+	return m.DeleteAWSPeerFunc(awsPeer)
+}
+
+func (m *Client) CreateARMPeer(armPeer *goaviatrix.ARMPeer) error {
+	m.record("CreateARMPeer", armPeer)
+	if m.CreateARMPeerFunc == nil {
+		return nil
+	}
+	return m.CreateARMPeerFunc(armPeer)
+}
+
+func (m *Client) GetARMPeer(armPeer *goaviatrix.ARMPeer) (*goaviatrix.ARMPeer, error) {
+	m.record("GetARMPeer", armPeer)
+	if m.GetARMPeerFunc == nil {
+		return nil, nil
+	}
+	return m.GetARMPeerFunc(armPeer)
+}
+
+func (m *Client) DeleteARMPeer(armPeer *goaviatrix.ARMPeer) error {
+	m.record("DeleteARMPeer", armPeer)
+	if m.DeleteARMPeerFunc == nil {
+		return nil
+	}
+	return m.DeleteARMPeerFunc(armPeer)
+}
+
+func (m *Client) CreateTransPeer(transPeer *goaviatrix.TransPeer) error {
+	m.record("CreateTransPeer", transPeer)
+	if m.CreateTransPeerFunc == nil {
+		return nil
+	}
+	return m.CreateTransPeerFunc(transPeer)
+}
+
+func (m *Client) GetTransPeer(transPeer *goaviatrix.TransPeer) (*goaviatrix.TransPeer, error) {
+	m.record("GetTransPeer", transPeer)
+	if m.GetTransPeerFunc == nil {
+		return nil, nil
+	}
+	return m.GetTransPeerFunc(transPeer)
+}
+
+func (m *Client) DeleteTransPeer(transPeer *goaviatrix.TransPeer) error {
+	m.record("DeleteTransPeer", transPeer)
+	if m.DeleteTransPeerFunc == nil {
+		return nil
+	}
+	return m.DeleteTransPeerFunc(transPeer)
+}
+
+func (m *Client) CreateTunnel(tunnel *goaviatrix.Tunnel) error {
+	m.record("CreateTunnel", tunnel)
+	if m.CreateTunnelFunc == nil {
+		return nil
+	}
+	return m.CreateTunnelFunc(tunnel)
+}
+
+func (m *Client) GetTunnel(tunnel *goaviatrix.Tunnel) (*goaviatrix.Tunnel, error) {
+	m.record("GetTunnel", tunnel)
+	if m.GetTunnelFunc == nil {
+		return nil, nil
+	}
+	return m.GetTunnelFunc(tunnel)
+}
+
+func (m *Client) UpdateTunnel(tunnel *goaviatrix.Tunnel) error {
+	m.record("UpdateTunnel", tunnel)
+	if m.UpdateTunnelFunc == nil {
+		return nil
+	}
+	return m.UpdateTunnelFunc(tunnel)
+}
+
+func (m *Client) DeleteTunnel(tunnel *goaviatrix.Tunnel) error {
+	m.record("DeleteTunnel", tunnel)
+	if m.DeleteTunnelFunc == nil {
+		return nil
+	}
+	return m.DeleteTunnelFunc(tunnel)
+}
+
+func (m *Client) CreateVpc(vpc *goaviatrix.Vpc) error {
+	m.record("CreateVpc", vpc)
+	if m.CreateVpcFunc == nil {
+		return nil
+	}
+	return m.CreateVpcFunc(vpc)
+}
+
+func (m *Client) GetVpc(vpc *goaviatrix.Vpc) (*goaviatrix.Vpc, error) {
+	m.record("GetVpc", vpc)
+	if m.GetVpcFunc == nil {
+		return nil, nil
+	}
+	return m.GetVpcFunc(vpc)
+}
+
+func (m *Client) DeleteVpc(vpc *goaviatrix.Vpc) error {
+	m.record("DeleteVpc", vpc)
+	if m.DeleteVpcFunc == nil {
+		return nil
+	}
+	return m.DeleteVpcFunc(vpc)
+}
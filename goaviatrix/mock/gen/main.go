@@ -0,0 +1,208 @@
+// Command gen writes the mock package's Client from the interfaces in
+// goaviatrix/api.go. Run it through "go generate" in the mock package.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+const (
+	apiFile = "../api.go"
+	outFile = "client.go"
+	pkg     = "goaviatrix"
+)
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, apiFile, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	interfaces := map[string]*ast.InterfaceType{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if it, ok := ts.Type.(*ast.InterfaceType); ok {
+				interfaces[ts.Name.Name] = it
+			}
+		}
+	}
+	api, ok := interfaces["API"]
+	if !ok {
+		log.Fatalf("%s has no API interface", apiFile)
+	}
+
+	var methods []*ast.Field
+	var collect func(it *ast.InterfaceType)
+	collect = func(it *ast.InterfaceType) {
+		for _, m := range it.Methods.List {
+			if ident, ok := m.Type.(*ast.Ident); ok {
+				collect(interfaces[ident.Name])
+				continue
+			}
+			methods = append(methods, m)
+		}
+	}
+	collect(api)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen from %s. DO NOT EDIT.\n\n", strings.TrimPrefix(apiFile, "../"))
+	fmt.Fprintf(&b, "package mock\n\nimport (\n")
+	// The imports of api.go, such as time, are used by the method
+	// signatures; gofmt sorts them.
+	for _, imp := range file.Imports {
+		fmt.Fprintf(&b, "\t%s\n", imp.Path.Value)
+	}
+	fmt.Fprintf(&b, "\n\t\"github.com/terraform-providers/terraform-provider-aviatrix/goaviatrix\"\n)\n\n")
+	fmt.Fprintf(&b, "// Client implements goaviatrix.API. Each method calls the matching Func\n")
+	fmt.Fprintf(&b, "// field if it is set and otherwise returns zero values and a nil error.\n")
+	fmt.Fprintf(&b, "// Every call is recorded in Calls.\n")
+	fmt.Fprintf(&b, "type Client struct {\n\tRecorder\n\n")
+	for _, m := range methods {
+		name := m.Names[0].Name
+		fmt.Fprintf(&b, "\t%sFunc func%s\n", name, signature(fset, m.Type.(*ast.FuncType), false))
+	}
+	fmt.Fprintf(&b, "}\n\nvar _ goaviatrix.API = (*Client)(nil)\n")
+
+	for _, m := range methods {
+		name := m.Names[0].Name
+		ft := m.Type.(*ast.FuncType)
+		args := paramNames(ft)
+		fmt.Fprintf(&b, "\nfunc (m *Client) %s%s {\n", name, signature(fset, ft, true))
+		fmt.Fprintf(&b, "\tm.record(%q%s)\n", name, prefixed(args))
+		fmt.Fprintf(&b, "\tif m.%sFunc == nil {\n", name)
+		if ft.Results != nil {
+			fmt.Fprintf(&b, "\t\treturn %s\n", zeroValues(ft.Results))
+		} else {
+			fmt.Fprintf(&b, "\t\treturn\n")
+		}
+		fmt.Fprintf(&b, "\t}\n")
+		call := fmt.Sprintf("m.%sFunc(%s)", name, strings.Join(args, ", "))
+		if ft.Results != nil {
+			fmt.Fprintf(&b, "\treturn %s\n", call)
+		} else {
+			fmt.Fprintf(&b, "\t%s\n", call)
+		}
+		fmt.Fprintf(&b, "}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %s\n%s", err, b.Bytes())
+	}
+	if err := ioutil.WriteFile(outFile, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "wrote %d methods to %s\n", len(methods), outFile)
+}
+
+// paramNames returns the parameter names of ft, naming unnamed ones a0, a1...
+func paramNames(ft *ast.FuncType) []string {
+	var names []string
+	for _, p := range ft.Params.List {
+		if len(p.Names) == 0 {
+			names = append(names, fmt.Sprintf("a%d", len(names)))
+			continue
+		}
+		for _, n := range p.Names {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// signature renders ft's parameters and results with package-local types
+// qualified by goaviatrix.
+func signature(fset *token.FileSet, ft *ast.FuncType, named bool) string {
+	var params []string
+	i := 0
+	for _, p := range ft.Params.List {
+		typ := qualify(fset, p.Type)
+		n := len(p.Names)
+		if n == 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			if named {
+				params = append(params, fmt.Sprintf("%s %s", paramNames(ft)[i], typ))
+			} else {
+				params = append(params, typ)
+			}
+			i++
+		}
+	}
+	sig := "(" + strings.Join(params, ", ") + ")"
+	if ft.Results == nil {
+		return sig
+	}
+	var results []string
+	for _, r := range ft.Results.List {
+		results = append(results, qualify(fset, r.Type))
+	}
+	if len(results) == 1 {
+		return sig + " " + results[0]
+	}
+	return sig + " (" + strings.Join(results, ", ") + ")"
+}
+
+// qualify renders expr, prefixing exported identifiers with the package name
+func qualify(fset *token.FileSet, expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return pkg + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return qualify(fset, t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + qualify(fset, t.X)
+	case *ast.ArrayType:
+		return "[]" + qualify(fset, t.Elt)
+	case *ast.MapType:
+		return "map[" + qualify(fset, t.Key) + "]" + qualify(fset, t.Value)
+	}
+	log.Fatalf("%s: unsupported type %T", fset.Position(expr.Pos()), expr)
+	return ""
+}
+
+// zeroValues renders the zero value of each result type
+func zeroValues(results *ast.FieldList) string {
+	var zeros []string
+	for _, r := range results.List {
+		zero := "nil"
+		if ident, ok := r.Type.(*ast.Ident); ok {
+			switch ident.Name {
+			case "string":
+				zero = `""`
+			case "bool":
+				zero = "false"
+			case "int", "int64", "float64":
+				zero = "0"
+			}
+		}
+		zeros = append(zeros, zero)
+	}
+	return strings.Join(zeros, ", ")
+}
+
+func prefixed(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
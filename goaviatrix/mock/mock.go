@@ -0,0 +1,45 @@
+// Package mock provides a goaviatrix.API implementation for unit testing
+// provider resources without a controller.
+package mock
+
+//go:generate go run ./gen
+
+import (
+	"sync"
+)
+
+// Call is one recorded method call
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder keeps the calls made to a mock in order
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Methods returns the names of the methods called so far, in order
+func (r *Recorder) Methods() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var methods []string
+	for _, c := range r.calls {
+		methods = append(methods, c.Method)
+	}
+	return methods
+}
@@ -0,0 +1,148 @@
+package goaviatrix
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Observer is notified before and after every HTTP round trip the client
+// makes to the controller, including logins and retries.
+type Observer interface {
+	BeforeRequest(req *RequestInfo)
+	AfterResponse(req *RequestInfo, resp *ResponseInfo)
+}
+
+// RequestInfo describes a round trip about to be sent
+type RequestInfo struct {
+	Verb   string
+	Action string
+	Start  time.Time
+}
+
+// ResponseInfo describes the outcome of a round trip. StatusCode is zero if
+// no response was received, and Return/Reason are only set if the body was
+// decoded.
+type ResponseInfo struct {
+	Duration   time.Duration
+	StatusCode int
+	Return     bool
+	Reason     string
+	Err        error
+}
+
+// beforeRequest notifies the client's Observer that a round trip for action
+// is starting.
+func (c *Client) beforeRequest(verb string, action string) *RequestInfo {
+	req := &RequestInfo{Verb: verb, Action: action, Start: time.Now()}
+	if c.Observer != nil {
+		c.Observer.BeforeRequest(req)
+	}
+	return req
+}
+
+// afterResponse notifies the client's Observer that the round trip req has
+// finished.
+func (c *Client) afterResponse(req *RequestInfo, resp *http.Response, data *APIResp, err error) {
+	if c.Observer == nil {
+		return
+	}
+	info := &ResponseInfo{Duration: time.Since(req.Start), Err: err}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+	}
+	if data != nil {
+		info.Return = data.Return
+		info.Reason = data.Reason
+	}
+	c.Observer.AfterResponse(req, info)
+}
+
+// CallMetrics is an Observer that counts round trips and records their
+// latency per action.
+type CallMetrics struct {
+	mu      sync.Mutex
+	actions map[string]*actionMetrics
+}
+
+type actionMetrics struct {
+	calls     int
+	failures  int
+	latencies []time.Duration
+}
+
+// NewCallMetrics returns an empty CallMetrics
+func NewCallMetrics() *CallMetrics {
+	return &CallMetrics{actions: map[string]*actionMetrics{}}
+}
+
+// BeforeRequest implements Observer
+func (m *CallMetrics) BeforeRequest(req *RequestInfo) {}
+
+// AfterResponse implements Observer
+func (m *CallMetrics) AfterResponse(req *RequestInfo, resp *ResponseInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.actions[req.Action]
+	if !ok {
+		a = &actionMetrics{}
+		m.actions[req.Action] = a
+	}
+	a.calls++
+	if resp.Err != nil || !resp.Return {
+		a.failures++
+	}
+	a.latencies = append(a.latencies, resp.Duration)
+}
+
+// Summary returns one line per action with its call and failure counts and
+// p50/p95 latency, slowest p95 first.
+func (m *CallMetrics) Summary() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type row struct {
+		action   string
+		calls    int
+		failures int
+		p50, p95 time.Duration
+	}
+	var rows []row
+	total := 0
+	for action, a := range m.actions {
+		sorted := append([]time.Duration(nil), a.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		rows = append(rows, row{action, a.calls, a.failures, percentile(sorted, 50), percentile(sorted, 95)})
+		total += a.calls
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].p95 != rows[j].p95 {
+			return rows[i].p95 > rows[j].p95
+		}
+		return rows[i].action < rows[j].action
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d controller calls", total)
+	for _, r := range rows {
+		fmt.Fprintf(&b, "\n  %-50s calls=%d failed=%d p50=%s p95=%s", r.action, r.calls, r.failures,
+			r.p50.Round(time.Millisecond), r.p95.Round(time.Millisecond))
+	}
+	return b.String()
+}
+
+// percentile returns the p-th percentile of sorted using the nearest-rank
+// method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,70 @@
+package goaviatrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	var twenty []time.Duration
+	for i := 1; i <= 20; i++ {
+		twenty = append(twenty, time.Duration(i)*time.Millisecond)
+	}
+	cases := []struct {
+		sorted []time.Duration
+		p      int
+		want   time.Duration
+	}{
+		{nil, 50, 0},
+		{[]time.Duration{7}, 50, 7},
+		{[]time.Duration{7}, 95, 7},
+		{[]time.Duration{1, 2, 3}, 50, 2},
+		{[]time.Duration{1, 2, 3}, 95, 3},
+		{[]time.Duration{1, 2, 3, 4}, 50, 2},
+		{twenty, 50, 10 * time.Millisecond},
+		{twenty, 95, 19 * time.Millisecond},
+		{twenty, 100, 20 * time.Millisecond},
+		{twenty, 0, time.Millisecond},
+	}
+	for _, tc := range cases {
+		if got := percentile(tc.sorted, tc.p); got != tc.want {
+			t.Errorf("percentile(%v, %d) = %s, want %s", tc.sorted, tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestCallMetricsSummary(t *testing.T) {
+	m := NewCallMetrics()
+	if got, want := m.Summary(), "0 controller calls"; got != want {
+		t.Errorf("empty Summary = %q, want %q", got, want)
+	}
+
+	// list_vpcs_summary takes 20ms down to 1ms, out of order.
+	for i := 20; i >= 1; i-- {
+		m.AfterResponse(&RequestInfo{Action: "list_vpcs_summary"},
+			&ResponseInfo{Duration: time.Duration(i) * time.Millisecond, Return: true})
+	}
+	m.AfterResponse(&RequestInfo{Action: "create_gateway"}, &ResponseInfo{Duration: time.Second, Return: true})
+	m.AfterResponse(&RequestInfo{Action: "create_gateway"}, &ResponseInfo{Duration: 3 * time.Second, Reason: "busy"})
+	m.AfterResponse(&RequestInfo{Action: "create_gateway"}, &ResponseInfo{Duration: 2 * time.Second, Err: errors.New("timeout")})
+	m.AfterResponse(&RequestInfo{Action: "get_gateway_info"}, &ResponseInfo{Duration: 19 * time.Millisecond, Return: true})
+
+	lines := strings.Split(m.Summary(), "\n")
+	want := []string{
+		"24 controller calls",
+		fmt.Sprintf("  %-50s calls=3 failed=2 p50=2s p95=3s", "create_gateway"),
+		fmt.Sprintf("  %-50s calls=1 failed=0 p50=19ms p95=19ms", "get_gateway_info"),
+		fmt.Sprintf("  %-50s calls=20 failed=0 p50=10ms p95=19ms", "list_vpcs_summary"),
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Summary = %q, want %q", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Summary line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
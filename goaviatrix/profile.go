@@ -1,11 +1,10 @@
 package goaviatrix
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
-	"net/url"
 	"strings"
 )
 
@@ -51,114 +50,56 @@ type ProfileBasePolicyResp struct {
 }
 
 func (c *Client) CreateProfile(profile *Profile) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for add_user_profile") + err.Error())
-	}
-	addUserProfile := url.Values{}
-	addUserProfile.Add("CID", c.CID)
-	addUserProfile.Add("action", "add_user_profile")
-	addUserProfile.Add("profile_name", profile.Name)
-	addUserProfile.Add("base_policy", profile.BaseRule)
-	Url.RawQuery = addUserProfile.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get add_user_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_user_profile failed: " + err.Error())
+	return c.CreateProfileWithContext(context.Background(), profile)
+}
+
+func (c *Client) CreateProfileWithContext(ctx context.Context, profile *Profile) error {
+	form := map[string]string{
+		"profile_name": profile.Name,
+		"base_policy":  profile.BaseRule,
 	}
-	if !data.Return {
-		return errors.New("Rest API add_user_profile Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "add_user_profile", form, nil); err != nil {
+		return err
 	}
 
 	policyStr, _ := json.Marshal(profile.Policy)
-	updateProfilePolicy := url.Values{}
-	updateProfilePolicy.Add("CID", c.CID)
-	updateProfilePolicy.Add("action", "update_profile_policy")
-	updateProfilePolicy.Add("profile_name", profile.Name)
-	updateProfilePolicy.Add("policy", string(policyStr))
-	Url.RawQuery = updateProfilePolicy.Encode()
+	updateProfilePolicy := map[string]string{
+		"profile_name": profile.Name,
+		"policy":       string(policyStr),
+	}
 
-	log.Printf("[INFO] Creating Aviatrix Profile with Policy: %v", Url.String())
+	log.Printf("[INFO] Creating Aviatrix Profile with Policy: %s", policyStr)
 
-	resp, err = c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get update_profile_policy failed: " + err.Error())
-	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_profile_policy failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_profile_policy failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "update_profile_policy", updateProfilePolicy, nil); err != nil {
+		return err
 	}
 
-	for _, user := range profile.UserList {
-		addProfileMember := url.Values{}
-		addProfileMember.Add("CID", c.CID)
-		addProfileMember.Add("action", "add_profile_member")
-		addProfileMember.Add("profile_name", profile.Name)
-		addProfileMember.Add("username", user)
-		Url.RawQuery = addProfileMember.Encode()
-
-		resp, err = c.Get(Url.String(), nil)
-		if err != nil {
-			return errors.New("HTTP Get add_profile_member failed: " + err.Error())
-		}
-		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return errors.New("Json Decode add_profile_member failed: " + err.Error())
-		}
-		if !data.Return {
-			return errors.New("API Get add_profile_member failed: " + data.Reason)
-		}
-	}
-	return nil
+	return c.AttachUsersWithContext(ctx, profile)
 }
 
 func (c *Client) GetProfile(profile *Profile) (*Profile, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_profile_policies") + err.Error())
-	}
-	listProfilePolicy := url.Values{}
-	listProfilePolicy.Add("CID", c.CID)
-	listProfilePolicy.Add("action", "list_profile_policies")
-	listProfilePolicy.Add("profile_name", profile.Name)
-	Url.RawQuery = listProfilePolicy.Encode()
-
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetProfileWithContext(context.Background(), profile)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_profile_policies failed: " + err.Error())
+func (c *Client) GetProfileWithContext(ctx context.Context, profile *Profile) (*Profile, error) {
+	form := map[string]string{
+		"profile_name": profile.Name,
 	}
 	var data ProfilePolicyListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_profile_policies failed: " + err.Error())
-	}
-
-	if !data.Return {
+	err := c.CallWithContext(ctx, "GET", "list_profile_policies", form, &data)
+	if err != nil {
 		log.Printf("Couldn't find Aviatrix profile %s", profile.Name)
-		if strings.Contains(data.Reason, "does not exist") {
+		if reasonContains(err, "does not exist") {
 			return nil, ErrNotFound
 		}
-		return nil, errors.New("API Get list_profile_policies failed: " + data.Reason)
+		return nil, err
 	}
 	profile.Policy = data.Results
 	log.Printf("[TRACE] Profile policy %s", profile.Policy)
 
-	listUserProfileNames := url.Values{}
-	listUserProfileNames.Add("CID", c.CID)
-	listUserProfileNames.Add("action", "list_user_profile_names")
-	Url.RawQuery = listUserProfileNames.Encode()
-
-	resp, err = c.Get(Url.String(), nil)
-	if err != nil {
-		return nil, errors.New("HTTP Get list_user_profile_names failed: " + err.Error())
-	}
 	var data2 ProfileUserListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data2); err != nil {
-		return nil, errors.New("Json Decode list_user_profile_names failed: " + err.Error())
+	if err = c.CallWithContext(ctx, "GET", "list_user_profile_names", nil, &data2); err != nil {
+		return nil, err
 	}
 
 	//profile.BaseRule = data2.Results[profile.Name]
@@ -170,87 +111,51 @@ func (c *Client) GetProfile(profile *Profile) (*Profile, error) {
 }
 
 func (c *Client) UpdateProfilePolicy(profile *Profile) error {
+	return c.UpdateProfilePolicyWithContext(context.Background(), profile)
+}
+
+func (c *Client) UpdateProfilePolicyWithContext(ctx context.Context, profile *Profile) error {
 	log.Printf("[TRACE] Updating Profile Policy %#v", profile)
 
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for update_profile_policy") + err.Error())
-	}
 	policyStr, _ := json.Marshal(profile.Policy)
-	updateProfilePolicy := url.Values{}
-	updateProfilePolicy.Add("CID", c.CID)
-	updateProfilePolicy.Add("action", "update_profile_policy")
-	updateProfilePolicy.Add("profile_name", profile.Name)
-	updateProfilePolicy.Add("policy", string(policyStr))
-	Url.RawQuery = updateProfilePolicy.Encode()
-
-	var data APIResp
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get update_profile_policy failed: " + err.Error())
-	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_profile_policy failed: " + err.Error())
+	form := map[string]string{
+		"profile_name": profile.Name,
+		"policy":       string(policyStr),
 	}
-	if !data.Return {
-		return errors.New("Rest API update_profile_policy failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "update_profile_policy", form, nil)
 }
 
 func (c *Client) AttachUsers(profile *Profile) error {
+	return c.AttachUsersWithContext(context.Background(), profile)
+}
+
+func (c *Client) AttachUsersWithContext(ctx context.Context, profile *Profile) error {
 	log.Printf("[TRACE] Attaching users %s", profile.UserList)
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		panic("boom")
-	}
 	for _, user := range profile.UserList {
-		addProfileMember := url.Values{}
-		addProfileMember.Add("CID", c.CID)
-		addProfileMember.Add("action", "add_profile_member")
-		addProfileMember.Add("profile_name", profile.Name)
-		addProfileMember.Add("username", user)
-		Url.RawQuery = addProfileMember.Encode()
-
-		var data APIResp
-		resp, err := c.Get(Url.String(), nil)
-		if err != nil {
-			return errors.New("HTTP Get add_profile_member failed: " + err.Error())
-		}
-		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return errors.New("Json Decode add_profile_member failed: " + err.Error())
+		form := map[string]string{
+			"profile_name": profile.Name,
+			"username":     user,
 		}
-		if !data.Return {
-			return errors.New("API Get add_profile_member failed: " + data.Reason)
+		if err := c.CallWithContext(ctx, "GET", "add_profile_member", form, nil); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
 func (c *Client) DetachUsers(profile *Profile) error {
+	return c.DetachUsersWithContext(context.Background(), profile)
+}
+
+func (c *Client) DetachUsersWithContext(ctx context.Context, profile *Profile) error {
 	log.Printf("[TRACE] Detaching users %s", profile.UserList)
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for del_profile_member") + err.Error())
-	}
 	for _, user := range profile.UserList {
-		delProfileMember := url.Values{}
-		delProfileMember.Add("CID", c.CID)
-		delProfileMember.Add("action", "del_profile_member")
-		delProfileMember.Add("profile_name", profile.Name)
-		delProfileMember.Add("username", user)
-		Url.RawQuery = delProfileMember.Encode()
-
-		var data APIResp
-		resp, err := c.Get(Url.String(), nil)
-		if err != nil {
-			return errors.New("HTTP Get del_profile_member failed: " + err.Error())
-		}
-		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return errors.New("Json Decode del_profile_member failed: " + err.Error())
+		form := map[string]string{
+			"profile_name": profile.Name,
+			"username":     user,
 		}
-		if !data.Return {
-			return errors.New("API Get del_profile_member failed: " + data.Reason)
+		if err := c.CallWithContext(ctx, "GET", "del_profile_member", form, nil); err != nil {
+			return err
 		}
 	}
 
@@ -258,57 +163,32 @@ func (c *Client) DetachUsers(profile *Profile) error {
 }
 
 func (c *Client) DeleteProfile(profile *Profile) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for del_user_profile") + err.Error())
-	}
-	delUserProfile := url.Values{}
-	delUserProfile.Add("CID", c.CID)
-	delUserProfile.Add("action", "del_user_profile")
-	delUserProfile.Add("profile_name", profile.Name)
-	Url.RawQuery = delUserProfile.Encode()
+	return c.DeleteProfileWithContext(context.Background(), profile)
+}
 
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get del_user_profile failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode del_user_profile failed: " + err.Error())
+func (c *Client) DeleteProfileWithContext(ctx context.Context, profile *Profile) error {
+	form := map[string]string{
+		"profile_name": profile.Name,
 	}
-	if !data.Return {
-		return errors.New("API Get del_user_profile failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "del_user_profile", form, nil)
 }
 
 func (c *Client) GetProfileBasePolicy(profile *Profile) (*Profile, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for get_profile_base_policy") + err.Error())
-	}
-	getProfileBasePolicy := url.Values{}
-	getProfileBasePolicy.Add("CID", c.CID)
-	getProfileBasePolicy.Add("action", "get_profile_base_policy")
-	getProfileBasePolicy.Add("profile_name", profile.Name)
-	Url.RawQuery = getProfileBasePolicy.Encode()
+	return c.GetProfileBasePolicyWithContext(context.Background(), profile)
+}
 
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return nil, errors.New("HTTP Get get_profile_base_policy failed: " + err.Error())
+func (c *Client) GetProfileBasePolicyWithContext(ctx context.Context, profile *Profile) (*Profile, error) {
+	form := map[string]string{
+		"profile_name": profile.Name,
 	}
 	var data ProfileBasePolicyResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode get_profile_base_policy failed: " + err.Error())
+	if err := c.CallWithContext(ctx, "GET", "get_profile_base_policy", form, &data); err != nil {
+		return nil, err
 	}
-	if !data.Return {
-		return nil, errors.New("API Get get_profile_base_policy failed: " + data.Reason)
-	} else {
-		if strings.Contains(data.Results, "allow all") {
-			profile.BaseRule = "allow_all"
-		} else if strings.Contains(data.Results, "deny all") {
-			profile.BaseRule = "deny_all"
-		}
+	if strings.Contains(data.Results, "allow all") {
+		profile.BaseRule = "allow_all"
+	} else if strings.Contains(data.Results, "deny all") {
+		profile.BaseRule = "deny_all"
 	}
 
 	return profile, nil
@@ -318,9 +198,7 @@ func (c *Client) ValidateProfileRule(profileRule *ProfileRule) error {
 	if profileRule.Action != "allow" && profileRule.Action != "deny" {
 		return fmt.Errorf("valid action is only 'allow' or 'deny'")
 	}
-	protocolDefaultValues := []string{"all", "tcp", "udp", "icmp", "sctp", "rdp", "dccp"}
-	protocolVal := []string{profileRule.Protocol}
-	if profileRule.Protocol == "" || len(Difference(protocolVal, protocolDefaultValues)) != 0 {
+	if !Contains(PolicyProtocols, profileRule.Protocol) {
 		return fmt.Errorf("proto can only be one of {'all', 'tcp', 'udp', 'icmp', 'sctp', 'rdp', 'dccp'}")
 	}
 	if (profileRule.Protocol == "all" || profileRule.Protocol == "icmp") && (profileRule.Port != "0:65535") {
@@ -0,0 +1,76 @@
+package goaviatrix
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+)
+
+// ErrReadOnly is returned for actions that would change the controller
+// when the client is read-only.
+var ErrReadOnly = errors.New("the Aviatrix provider is read-only")
+
+// ErrDryRun is returned, after logging them, for actions that would change
+// the controller when the client is in dry-run mode.
+var ErrDryRun = errors.New("dry run")
+
+// readPrefixes and readActions name the actions that only read controller
+// state.
+var readPrefixes = []string{"list_", "get_", "view_"}
+
+var readActions = map[string]bool{
+	"login":             true,
+	"vpc_access_policy": true,
+}
+
+// isReadAction reports whether action only reads controller state
+func isReadAction(action string) bool {
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return readActions[action]
+}
+
+// isReadRequest is isReadAction for a complete request, also allowing
+// actions that read or write depending on their parameters.
+func isReadRequest(action string, values url.Values) bool {
+	switch action {
+	case "config_http_access":
+		return values.Get("operation") == "get"
+	case "modify_split_tunnel":
+		return values.Get("command") == "get"
+	}
+	return isReadAction(action)
+}
+
+// isReadCall is isReadRequest for the params of a call
+func (c *Client) isReadCall(action string, params interface{}) bool {
+	values, err := c.actionValues(action, params)
+	return err == nil && isReadRequest(action, values)
+}
+
+// checkMutation enforces ReadOnly and DryRun before an action is sent. It
+// returns nil for reads and when neither mode is on.
+func (c *Client) checkMutation(verb string, action string, params interface{}) error {
+	if !c.ReadOnly && !c.DryRun {
+		return nil
+	}
+	values, err := c.actionValues(action, params)
+	if err != nil {
+		return err
+	}
+	if isReadRequest(action, values) {
+		return nil
+	}
+	if c.ReadOnly {
+		return fmt.Errorf("%w: refusing to send %s", ErrReadOnly, action)
+	}
+	values.Del("CID")
+	redacted := c.redactValues(values).Encode()
+	log.Printf("[INFO] Dry run: not sending %s %s: %s", verb, action, redacted)
+	return fmt.Errorf("%w: would send %s %s with %s", ErrDryRun, verb, action, redacted)
+}
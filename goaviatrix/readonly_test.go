@@ -0,0 +1,104 @@
+package goaviatrix
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckMutationReadOnly(t *testing.T) {
+	client := &Client{ReadOnly: true}
+	cases := []struct {
+		action string
+		params map[string]string
+		allow  bool
+	}{
+		{"list_vpcs_summary", nil, true},
+		{"get_gateway_info", nil, true},
+		{"view_site2cloud_conn", nil, true},
+		{"login", nil, true},
+		{"vpc_access_policy", nil, true},
+		{"config_http_access", map[string]string{"operation": "get"}, true},
+		{"config_http_access", map[string]string{"operation": "enable"}, false},
+		{"modify_split_tunnel", map[string]string{"command": "get"}, true},
+		{"modify_split_tunnel", map[string]string{"command": "modify"}, false},
+		{"create_vpc", nil, false},
+		{"delete_container", nil, false},
+		{"edit_account_user", nil, false},
+	}
+	for _, tc := range cases {
+		err := client.checkMutation("POST", tc.action, tc.params)
+		if tc.allow && err != nil {
+			t.Errorf("%s refused: %s", tc.action, err)
+		}
+		if !tc.allow && !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s err = %v, want %v", tc.action, err, ErrReadOnly)
+		}
+	}
+}
+
+func TestCheckMutationDryRun(t *testing.T) {
+	client := &Client{DryRun: true, CID: "secret-cid"}
+	if err := client.checkMutation("GET", "list_accounts", nil); err != nil {
+		t.Errorf("list_accounts refused: %s", err)
+	}
+
+	err := client.checkMutation("POST", "edit_account_user", map[string]string{
+		"username":     "bob",
+		"new_password": "hunter2",
+	})
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("err = %v, want %v", err, ErrDryRun)
+	}
+	msg := err.Error()
+	for _, want := range []string{"POST edit_account_user", "username=bob", "new_password=REDACTED"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("err = %q, want it to contain %q", msg, want)
+		}
+	}
+	for _, secret := range []string{"hunter2", "secret-cid"} {
+		if strings.Contains(msg, secret) {
+			t.Errorf("err = %q, contains %q", msg, secret)
+		}
+	}
+}
+
+func TestClientReadOnlyDoesNotSend(t *testing.T) {
+	for _, mode := range []struct {
+		name    string
+		set     func(*Client)
+		wantErr error
+	}{
+		{"read-only", func(c *Client) { c.ReadOnly = true }, ErrReadOnly},
+		{"dry run", func(c *Client) { c.DryRun = true }, ErrDryRun},
+	} {
+		var sent []string
+		client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			sent = append(sent, r.Form.Get("action"))
+			writeJSON(w, APIResp{Return: true})
+		})
+		mode.set(client)
+
+		if err := client.Call("GET", "list_accounts", nil, nil); err != nil {
+			t.Errorf("%s: list_accounts: %s", mode.name, err)
+		}
+		if err := client.Call("POST", "create_vpc", nil, nil); !errors.Is(err, mode.wantErr) {
+			t.Errorf("%s: create_vpc err = %v, want %v", mode.name, err, mode.wantErr)
+		}
+		if err := client.Pre32Upgrade(); !errors.Is(err, mode.wantErr) {
+			t.Errorf("%s: Pre32Upgrade err = %v, want %v", mode.name, err, mode.wantErr)
+		}
+		if _, err := client.GetSplitTunnel(&SplitTunnel{VpcID: "vpc-0123"}); err != nil {
+			t.Errorf("%s: GetSplitTunnel: %s", mode.name, err)
+		}
+		if err := client.ModifySplitTunnel(&SplitTunnel{VpcID: "vpc-0123"}); !errors.Is(err, mode.wantErr) {
+			t.Errorf("%s: ModifySplitTunnel err = %v, want %v", mode.name, err, mode.wantErr)
+		}
+		server.Close()
+		if got, want := strings.Join(sent, ","), "list_accounts,modify_split_tunnel"; got != want {
+			t.Errorf("%s: sent %s, want only %s", mode.name, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package goaviatrix
+
+import (
+	"net/url"
+	"strings"
+)
+
+// redactedValue replaces the value of a sensitive key in logged requests
+const redactedValue = "REDACTED"
+
+// sensitiveKeys are the form and query keys whose values are never logged
+var sensitiveKeys = []string{
+	"okta_token",
+	"contents",
+	"CID",
+}
+
+// sensitiveKeyParts mask every key containing one of them, such as
+// "new_password", "awsgov_secret_key" or "pre_shared_key_tun_1", so secrets
+// of new actions are masked without listing each key.
+var sensitiveKeyParts = []string{
+	"password",
+	"secret",
+	"pre_shared_key",
+	"credentials",
+}
+
+// isSensitiveKey reports whether key, or its last bracketed segment for keys
+// such as "users[0][password]", is one of sensitiveKeys or extra, or contains
+// one of sensitiveKeyParts.
+func isSensitiveKey(key string, extra []string) bool {
+	if i := strings.LastIndex(key, "["); i >= 0 && strings.HasSuffix(key, "]") {
+		key = key[i+1 : len(key)-1]
+	}
+	lower := strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	for _, lists := range [][]string{sensitiveKeys, extra} {
+		for _, k := range lists {
+			if strings.EqualFold(key, k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactValues returns a copy of values with sensitive values masked.
+func (c *Client) redactValues(values url.Values) url.Values {
+	redacted := url.Values{}
+	for k, v := range values {
+		if isSensitiveKey(k, c.RedactKeys) {
+			redacted[k] = []string{redactedValue}
+		} else {
+			redacted[k] = v
+		}
+	}
+	return redacted
+}
+
+// redactQuery masks sensitive values in an encoded form body or query
+// string. Input that doesn't parse is replaced entirely, since it can't be
+// shown to be safe.
+func (c *Client) redactQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return redactedValue
+	}
+	return c.redactValues(values).Encode()
+}
+
+// redactURL masks sensitive values in the query string of rawURL.
+func (c *Client) redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redactedValue
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = c.redactQuery(u.RawQuery)
+	}
+	return u.String()
+}
@@ -0,0 +1,82 @@
+package goaviatrix
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestRedactQuery(t *testing.T) {
+	secrets := []string{
+		"password",
+		"CID",
+		"aws_secret_key",
+		"awsgov_secret_key",
+		"awschina_secret_key",
+		"arm_application_client_secret",
+		"arm_china_application_client_secret",
+		"pre_shared_key",
+		"pre_shared_key_tun_1",
+		"pre_shared_key_tun_2",
+		"backup_pre_shared_key",
+		"old_password",
+		"new_password",
+		"gcloud_project_credentials",
+		"okta_token",
+		"contents",
+		"users[0][password]",
+		"custom_key",
+	}
+	values := url.Values{"action": {"login"}, "username": {"admin"}}
+	for _, k := range secrets {
+		values.Set(k, "hunter2")
+	}
+
+	client := &Client{RedactKeys: []string{"custom_key"}}
+	redacted, err := url.ParseQuery(client.redactQuery(values.Encode()))
+	if err != nil {
+		t.Fatalf("redacted query doesn't parse: %s", err)
+	}
+	for _, k := range secrets {
+		if got := redacted.Get(k); got != redactedValue {
+			t.Errorf("%s = %q, want %q", k, got, redactedValue)
+		}
+	}
+	for _, k := range []string{"action", "username"} {
+		if got, want := redacted.Get(k), values.Get(k); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+
+	if got := client.redactQuery("password=%zz"); got != redactedValue {
+		t.Errorf("unparsable query = %q, want %q", got, redactedValue)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	client := &Client{}
+	cases := []struct {
+		in, want string
+	}{
+		{
+			"https://10.0.0.1/v1/api",
+			"https://10.0.0.1/v1/api",
+		},
+		{
+			"https://10.0.0.1/v1/api?CID=abc&action=list_accounts",
+			"https://10.0.0.1/v1/api?CID=REDACTED&action=list_accounts",
+		},
+		{
+			"https://10.0.0.1/v1/api?action=edit_account_user&new_password=s3cret",
+			"https://10.0.0.1/v1/api?action=edit_account_user&new_password=REDACTED",
+		},
+		{
+			"://bad",
+			redactedValue,
+		},
+	}
+	for _, tc := range cases {
+		if got := client.redactURL(tc.in); got != tc.want {
+			t.Errorf("redactURL(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
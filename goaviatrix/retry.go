@@ -0,0 +1,193 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"math/rand"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how Client retries an action that failed with a
+// transport error or a transient controller rejection.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// InitialInterval is the wait before the first retry.
+	InitialInterval time.Duration
+	// MaxInterval caps the wait between two attempts.
+	MaxInterval time.Duration
+	// MaxElapsedTime caps the total time spent retrying one action; zero
+	// means no limit.
+	MaxElapsedTime time.Duration
+	// Multiplier grows the wait after each retry.
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction (0 to 1).
+	Jitter float64
+	// Retryable decides whether err is worth retrying. Defaults to
+	// DefaultRetryable.
+	Retryable func(action string, err error) bool
+	// SafeActions lists actions that change the controller but are safe to
+	// resend: creates retried at all, and other changes retried after
+	// transport errors too.
+	SafeActions map[string]bool
+}
+
+// DefaultRetryPolicy returns the policy NewClient installs on a Client.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:      5,
+		InitialInterval: 2 * time.Second,
+		MaxInterval:     60 * time.Second,
+		MaxElapsedTime:  10 * time.Minute,
+		Multiplier:      2,
+		Jitter:          0.2,
+		SafeActions:     map[string]bool{},
+	}
+}
+
+// DefaultRetryable retries controller rejections classified as transient,
+// such as "in progress" or "busy", and transport failures of read actions.
+// A transport failure may hide a change the controller made, so other
+// actions are only resent after one when listed in SafeActions.
+func DefaultRetryable(action string, err error) bool {
+	if isCanceled(err) {
+		return false
+	}
+	if IsTransient(err) {
+		return true
+	}
+	return isTransportError(err) && isReadAction(action)
+}
+
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// isTransportError reports whether err is a failure to exchange a request
+// and response with the controller.
+func isTransportError(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// createPrefixes and createActions name the actions that create something
+// on the controller. Resending one after a lost response may create it
+// twice, so they are only retried when listed in SafeActions.
+var createPrefixes = []string{"add_", "create_", "attach_", "connect_", "setup_", "launch_"}
+
+var createActions = map[string]bool{
+	"peer_vpc_pair":      true,
+	"arm_peer_vnet_pair": true,
+	"enable_transit_ha":  true,
+	"enable_spoke_ha":    true,
+	"upload_file":        true,
+}
+
+func isCreateAction(action string) bool {
+	if createActions[action] {
+		return true
+	}
+	for _, prefix := range createPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) shouldRetry(action string, attempt int, err error) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	safe := p.SafeActions[action]
+	if isCreateAction(action) && !safe {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(action, err)
+	}
+	if safe && isTransportError(err) && !isCanceled(err) {
+		return true
+	}
+	return DefaultRetryable(action, err)
+}
+
+// upgradeRetryPolicy rides out another upgrade in progress, which takes
+// minutes, by waiting a minute up to three times. Nothing else is retried,
+// as the upgrade actions change the controller.
+func upgradeRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:      3,
+		InitialInterval: time.Minute,
+		MaxInterval:     time.Minute,
+		Multiplier:      1,
+		Retryable: func(action string, err error) bool {
+			return reasonContains(err, "in progress")
+		},
+	}
+}
+
+type retryPolicyKey struct{}
+
+// withRetryPolicy returns a context making the calls under it retry
+// according to p rather than the client's RetryPolicy. A client without a
+// RetryPolicy still never retries.
+func withRetryPolicy(ctx context.Context, p *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, p)
+}
+
+// backoff returns the wait before retry number attempt+1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt))
+	if p.MaxInterval > 0 && wait > float64(p.MaxInterval) {
+		wait = float64(p.MaxInterval)
+	}
+	if p.Jitter > 0 {
+		wait += wait * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(wait)
+}
+
+// retry calls fn until it succeeds, the policy gives up, the error is not
+// retryable or ctx is done, sleeping between attempts.
+func (c *Client) retry(ctx context.Context, action string, fn func() error) error {
+	p := c.RetryPolicy
+	if p == nil {
+		return fn()
+	}
+	if override, ok := ctx.Value(retryPolicyKey{}).(*RetryPolicy); ok {
+		p = override
+	}
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !p.shouldRetry(action, attempt, err) {
+			return err
+		}
+		wait := p.backoff(attempt)
+		if p.MaxElapsedTime > 0 && time.Since(start)+wait > p.MaxElapsedTime {
+			return err
+		}
+		log.Printf("[INFO] %s failed: %s. Retry after %s...", action, err, wait.Round(time.Second))
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepContext waits for d, returning early with ctx's error if ctx is done
+// first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,231 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"busy", newAPIError("list_vpcs", http.StatusOK, "Controller is busy. Please try again later."), true},
+		{"in progress", newAPIError("list_vpcs", http.StatusOK, "Another operation is in progress"), true},
+		{"server error", newAPIError("list_vpcs", http.StatusBadGateway, ""), true},
+		{"too many requests", newAPIError("list_vpcs", http.StatusTooManyRequests, ""), true},
+		{"transport", &url.Error{Op: "Post", URL: "https://ctrl/v1/api", Err: errors.New("connection reset")}, true},
+		{"not found", newAPIError("get_gateway_info", http.StatusOK, "Gateway gw1 does not exist"), false},
+		{"validation", newAPIError("create_vpc", http.StatusOK, "Invalid CIDR"), false},
+		{"auth", newAPIError("login", http.StatusOK, "Invalid username or password"), false},
+		{"canceled", context.Canceled, false},
+		{"deadline", &url.Error{Op: "Post", URL: "https://ctrl/v1/api", Err: context.DeadlineExceeded}, false},
+		{"other", errors.New("Json Decode list_vpcs failed"), false},
+	}
+	for _, tc := range cases {
+		if got := DefaultRetryable("list_vpcs", tc.err); got != tc.want {
+			t.Errorf("%s: DefaultRetryable(%v) = %t, want %t", tc.name, tc.err, got, tc.want)
+		}
+	}
+
+	// Only reads are resent after a transport error, which may hide a
+	// change the controller made.
+	transport := &url.Error{Op: "Post", URL: "https://ctrl/v1/api", Err: errors.New("connection reset")}
+	busy := newAPIError("", http.StatusOK, "Controller is busy")
+	for action, want := range map[string]bool{
+		"get_gateway_info":    true,
+		"vpc_access_policy":   true,
+		"upgrade":             false,
+		"delete_container":    false,
+		"detach_vpc":          false,
+		"disable_snat":        false,
+		"modify_split_tunnel": false,
+	} {
+		if got := DefaultRetryable(action, transport); got != want {
+			t.Errorf("DefaultRetryable(%s, transport error) = %t, want %t", action, got, want)
+		}
+		if !DefaultRetryable(action, busy) {
+			t.Errorf("DefaultRetryable(%s, busy) = false, want true", action)
+		}
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	busy := newAPIError("", http.StatusOK, "Controller is busy")
+	p := DefaultRetryPolicy()
+	p.SafeActions["create_vpc"] = true
+
+	cases := []struct {
+		action  string
+		attempt int
+		want    bool
+	}{
+		{"list_vpcs", 0, true},
+		{"list_vpcs", 4, true},
+		{"list_vpcs", 5, false},
+		{"delete_vpc", 0, true},
+		{"create_transit_gw", 0, false},
+		{"connect_container", 0, false},
+		{"peer_vpc_pair", 0, false},
+		{"enable_spoke_ha", 0, false},
+		{"create_vpc", 0, true},
+	}
+	for _, tc := range cases {
+		if got := p.shouldRetry(tc.action, tc.attempt, busy); got != tc.want {
+			t.Errorf("shouldRetry(%s, %d) = %t, want %t", tc.action, tc.attempt, got, tc.want)
+		}
+	}
+
+	transport := &url.Error{Op: "Post", URL: "https://ctrl/v1/api", Err: errors.New("connection reset")}
+	p.SafeActions["delete_container"] = true
+	for action, want := range map[string]bool{"delete_container": true, "detach_vpc": false, "create_vpc": true} {
+		if got := p.shouldRetry(action, 0, transport); got != want {
+			t.Errorf("shouldRetry(%s, transport error) = %t, want %t", action, got, want)
+		}
+	}
+
+	p.Retryable = func(action string, err error) bool { return false }
+	if p.shouldRetry("list_vpcs", 0, busy) {
+		t.Errorf("shouldRetry ignored a custom Retryable")
+	}
+}
+
+func TestUpgradeRetryPolicy(t *testing.T) {
+	p := upgradeRetryPolicy()
+	inProgress := newAPIError("upgrade", http.StatusOK, "Active upgrade in progress.")
+	for attempt := 0; attempt < 3; attempt++ {
+		if !p.shouldRetry("upgrade", attempt, inProgress) {
+			t.Errorf("shouldRetry(upgrade, %d, in progress) = false, want true", attempt)
+		}
+	}
+	if p.shouldRetry("upgrade", 3, inProgress) {
+		t.Errorf("shouldRetry(upgrade, 3, in progress) = true, want false")
+	}
+	for _, err := range []error{
+		newAPIError("upgrade", http.StatusOK, "Controller is busy"),
+		&url.Error{Op: "Get", URL: "https://ctrl/v1/api", Err: errors.New("connection reset")},
+	} {
+		if p.shouldRetry("upgrade", 0, err) {
+			t.Errorf("shouldRetry(upgrade, %v) = true, want false", err)
+		}
+	}
+	// Three waits of a minute ride out an upgrade of several minutes.
+	if total := 3 * p.backoff(0); total != 3*time.Minute {
+		t.Errorf("upgrade retries wait %s, want 3m", total)
+	}
+}
+
+func TestClientRetryPolicyFromContext(t *testing.T) {
+	var calls int32
+	client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeJSON(w, APIResp{Return: false, Reason: "Controller is busy"})
+	})
+	defer server.Close()
+	client.RetryPolicy = quickRetryPolicy()
+
+	override := quickRetryPolicy()
+	override.MaxRetries = 1
+	client.CallWithContext(withRetryPolicy(context.Background(), override), "GET", "list_vpcs", nil, nil)
+	if calls != 2 {
+		t.Errorf("%d calls, want 2", calls)
+	}
+
+	// A client without a policy still never retries.
+	calls = 0
+	client.RetryPolicy = nil
+	client.CallWithContext(withRetryPolicy(context.Background(), override), "GET", "list_vpcs", nil, nil)
+	if calls != 1 {
+		t.Errorf("%d calls without a client policy, want 1", calls)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialInterval: time.Second, MaxInterval: 5 * time.Second, Multiplier: 2}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, w := range want {
+		if got := p.backoff(attempt); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, w)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(0); got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("backoff(0) with jitter 0.5 = %s, want within [500ms, 1.5s]", got)
+		}
+	}
+}
+
+// quickRetryPolicy retries up to three times without waiting noticeably.
+func quickRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:      3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Multiplier:      1,
+		SafeActions:     map[string]bool{},
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	cases := []struct {
+		name      string
+		action    string
+		failures  int32
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"succeeds after transient failures", "list_vpcs", 2, 3, false},
+		{"gives up after MaxRetries", "list_vpcs", 10, 4, true},
+		{"never resends a create", "create_transit_gw", 10, 1, true},
+	}
+	for _, tc := range cases {
+		var calls int32
+		client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) <= tc.failures {
+				writeJSON(w, APIResp{Return: false, Reason: "Controller is busy"})
+				return
+			}
+			writeJSON(w, APIResp{Return: true})
+		})
+		client.RetryPolicy = quickRetryPolicy()
+
+		err := client.Call("POST", tc.action, nil, nil)
+		server.Close()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, want error %t", tc.name, err, tc.wantErr)
+		}
+		if calls != tc.wantCalls {
+			t.Errorf("%s: %d calls, want %d", tc.name, calls, tc.wantCalls)
+		}
+	}
+}
+
+func TestClientRetryStopsWhenContextDone(t *testing.T) {
+	var calls int32
+	client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeJSON(w, APIResp{Return: false, Reason: "Controller is busy"})
+	})
+	defer server.Close()
+	client.RetryPolicy = quickRetryPolicy()
+	client.RetryPolicy.InitialInterval = time.Hour
+	client.RetryPolicy.MaxInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := client.CallWithContext(ctx, "GET", "list_vpcs", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want 1", calls)
+	}
+}
@@ -1,9 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"net/url"
+	"context"
 )
 
 // AwsTGW simple struct to hold aws_tgw details
@@ -41,41 +39,25 @@ type VPCSolo struct {
 }
 
 func (c *Client) CreateSecurityDomain(securityDomain *SecurityDomain) error {
-	securityDomain.CID = c.CID
-	securityDomain.Action = "add_route_domain"
-	resp, err := c.Post(c.baseURL, securityDomain)
-	if err != nil {
-		return errors.New("HTTP Post add_route_domain failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_route_domain failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_route_domain Post failed: " + data.Reason)
-	}
+	return c.CreateSecurityDomainWithContext(context.Background(), securityDomain)
+}
 
-	return nil
+func (c *Client) CreateSecurityDomainWithContext(ctx context.Context, securityDomain *SecurityDomain) error {
+	return c.CallWithContext(ctx, "POST", "add_route_domain", securityDomain, nil)
 }
 
 func (c *Client) GetSecurityDomain(securityDomain *SecurityDomain) (string, error) {
-	securityDomain.CID = c.CID
-	securityDomain.Action = "list_route_domain_names"
-	resp, err := c.Post(c.baseURL, securityDomain)
-	if err != nil {
-		return "", errors.New("HTTP Post list_route_domain_names failed: " + err.Error())
-	}
+	return c.GetSecurityDomainWithContext(context.Background(), securityDomain)
+}
 
+func (c *Client) GetSecurityDomainWithContext(ctx context.Context, securityDomain *SecurityDomain) (string, error) {
 	data := SecurityDomainAPIResp{
 		Return:  false,
 		Results: make([]string, 0),
 		Reason:  "",
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode list_route_domain_names failed: " + err.Error())
-	}
-	if !data.Return {
-		return "", errors.New("Rest API list_route_domain_names Post failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "POST", "list_route_domain_names", securityDomain, &data); err != nil {
+		return "", err
 	}
 
 	securityDomainList := data.Results
@@ -93,82 +75,39 @@ func (c *Client) UpdateSecurityDomain(securityDomain *SecurityDomain) error {
 }
 
 func (c *Client) DeleteSecurityDomain(securityDomain *SecurityDomain) error {
-	securityDomain.CID = c.CID
-	securityDomain.Action = "delete_route_domain"
-	resp, err := c.Post(c.baseURL, securityDomain)
-	if err != nil {
-		return errors.New("HTTP Post delete_route_domain failed: " + err.Error())
-	}
-
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_route_domain failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_route_domain Post failed: " + data.Reason)
-	}
+	return c.DeleteSecurityDomainWithContext(context.Background(), securityDomain)
+}
 
-	return nil
+func (c *Client) DeleteSecurityDomainWithContext(ctx context.Context, securityDomain *SecurityDomain) error {
+	return c.CallWithContext(ctx, "POST", "delete_route_domain", securityDomain, nil)
 }
 
 func (c *Client) CreateDomainConnection(awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for add_connection_between_route_domains") + err.Error())
-	}
-	addConnectionBetweenRouteDomains := url.Values{}
-	addConnectionBetweenRouteDomains.Add("CID", c.CID)
-	addConnectionBetweenRouteDomains.Add("action", "add_connection_between_route_domains")
-	addConnectionBetweenRouteDomains.Add("account_name", awsTgw.AccountName)
-	addConnectionBetweenRouteDomains.Add("region", awsTgw.Region)
-	addConnectionBetweenRouteDomains.Add("tgw_name", awsTgw.Name)
-	addConnectionBetweenRouteDomains.Add("source_route_domain_name", sourceDomain)
-	addConnectionBetweenRouteDomains.Add("destination_route_domain_name", destinationDomain)
-	Url.RawQuery = addConnectionBetweenRouteDomains.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get add_connection_between_route_domains failed: " + err.Error())
-	}
+	return c.CreateDomainConnectionWithContext(context.Background(), awsTgw, sourceDomain, destinationDomain)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_connection_between_route_domains failed: " + err.Error())
+func (c *Client) CreateDomainConnectionWithContext(ctx context.Context, awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
+	form := map[string]string{
+		"account_name":                  awsTgw.AccountName,
+		"region":                        awsTgw.Region,
+		"tgw_name":                      awsTgw.Name,
+		"source_route_domain_name":      sourceDomain,
+		"destination_route_domain_name": destinationDomain,
 	}
-	if !data.Return {
-		return errors.New("Rest API add_connection_between_route_domains Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "add_connection_between_route_domains", form, nil)
 }
 
 func (c *Client) DeleteDomainConnection(awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_connection_between_route_domains") + err.Error())
-	}
-	deleteConnectionBetweenRouteDomains := url.Values{}
-	deleteConnectionBetweenRouteDomains.Add("CID", c.CID)
-	deleteConnectionBetweenRouteDomains.Add("action", "delete_connection_between_route_domains")
-	deleteConnectionBetweenRouteDomains.Add("account_name", awsTgw.AccountName)
-	deleteConnectionBetweenRouteDomains.Add("region", awsTgw.Region)
-	deleteConnectionBetweenRouteDomains.Add("tgw_name", awsTgw.Name)
-	deleteConnectionBetweenRouteDomains.Add("source_route_domain_name", sourceDomain)
-	deleteConnectionBetweenRouteDomains.Add("destination_route_domain_name", destinationDomain)
-	Url.RawQuery = deleteConnectionBetweenRouteDomains.Encode()
-	resp, err := c.Get(Url.String(), nil)
-
-	if err != nil {
-		return errors.New("HTTP Get delete_connection_between_route_domains failed: " + err.Error())
-	}
+	return c.DeleteDomainConnectionWithContext(context.Background(), awsTgw, sourceDomain, destinationDomain)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_connection_between_route_domains failed: " + err.Error())
+func (c *Client) DeleteDomainConnectionWithContext(ctx context.Context, awsTgw *AWSTgw, sourceDomain string, destinationDomain string) error {
+	form := map[string]string{
+		"account_name":                  awsTgw.AccountName,
+		"region":                        awsTgw.Region,
+		"tgw_name":                      awsTgw.Name,
+		"source_route_domain_name":      sourceDomain,
+		"destination_route_domain_name": destinationDomain,
 	}
-	if !data.Return {
-		return errors.New("Rest API delete_connection_between_route_domains Get failed: " + data.Reason)
-	}
-
-	return nil
+	return c.CallWithContext(ctx, "GET", "delete_connection_between_route_domains", form, nil)
 }
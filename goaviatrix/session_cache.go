@@ -0,0 +1,86 @@
+package goaviatrix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// SessionCache keeps controller CIDs on disk so that separate provider
+// runs against the same controller and user share one login. Each session
+// is a file only readable by its owner.
+type SessionCache struct {
+	Dir string
+}
+
+type cachedSession struct {
+	ControllerIP string `json:"controller_ip"`
+	Username     string `json:"username"`
+	CID          string `json:"cid"`
+}
+
+// NewSessionCache returns a cache keeping sessions in dir. A leading "~/"
+// is replaced with the home directory.
+func NewSessionCache(dir string) (*SessionCache, error) {
+	if len(dir) >= 2 && dir[:2] == "~/" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, dir[2:])
+	}
+	return &SessionCache{Dir: dir}, nil
+}
+
+func (s *SessionCache) path(controllerIP string, username string) string {
+	sum := sha256.Sum256([]byte(controllerIP + "\x00" + username))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Load returns the cached CID for the user on the controller, or "" if
+// there is none.
+func (s *SessionCache) Load(controllerIP string, username string) string {
+	data, err := ioutil.ReadFile(s.path(controllerIP, username))
+	if err != nil {
+		return ""
+	}
+	var session cachedSession
+	if json.Unmarshal(data, &session) != nil ||
+		session.ControllerIP != controllerIP || session.Username != username {
+		return ""
+	}
+	return session.CID
+}
+
+// Store saves the CID of the user on the controller. The file is replaced
+// atomically, since other provider processes may be reading it.
+func (s *SessionCache) Store(controllerIP string, username string, cid string) error {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cachedSession{ControllerIP: controllerIP, Username: username, CID: cid})
+	if err != nil {
+		return err
+	}
+	// TempFile creates the file with mode 0600.
+	f, err := ioutil.TempFile(s.Dir, ".session-*")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Chmod(0600)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path(controllerIP, username))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package goaviatrix
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSessionCacheStoreLoad(t *testing.T) {
+	cache := &SessionCache{Dir: filepath.Join(t.TempDir(), "sessions")}
+	if cid := cache.Load("10.0.0.1", "admin"); cid != "" {
+		t.Errorf("Load from an empty cache = %q, want none", cid)
+	}
+
+	if err := cache.Store("10.0.0.1", "admin", "cid-1"); err != nil {
+		t.Fatalf("Store: %s", err)
+	}
+	if err := cache.Store("10.0.0.1", "other", "cid-2"); err != nil {
+		t.Fatalf("Store: %s", err)
+	}
+	if err := cache.Store("10.0.0.1", "admin", "cid-3"); err != nil {
+		t.Fatalf("Store: %s", err)
+	}
+
+	cases := []struct {
+		controller, user, want string
+	}{
+		{"10.0.0.1", "admin", "cid-3"},
+		{"10.0.0.1", "other", "cid-2"},
+		{"10.0.0.2", "admin", ""},
+	}
+	for _, tc := range cases {
+		if cid := cache.Load(tc.controller, tc.user); cid != tc.want {
+			t.Errorf("Load(%s, %s) = %q, want %q", tc.controller, tc.user, cid, tc.want)
+		}
+	}
+
+	files, err := ioutil.ReadDir(cache.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("%d files in the cache, want 2 without leftover temporary files", len(files))
+	}
+	if runtime.GOOS != "windows" {
+		for _, f := range files {
+			if mode := f.Mode().Perm(); mode != 0600 {
+				t.Errorf("%s has mode %o, want 600", f.Name(), mode)
+			}
+		}
+	}
+}
+
+func TestSessionCacheIgnoresBadFiles(t *testing.T) {
+	cache := &SessionCache{Dir: t.TempDir()}
+	path := cache.path("10.0.0.1", "admin")
+
+	ioutil.WriteFile(path, []byte("not json"), 0600)
+	if cid := cache.Load("10.0.0.1", "admin"); cid != "" {
+		t.Errorf("Load of a corrupt file = %q, want none", cid)
+	}
+
+	// A session stored for someone else is never handed out.
+	ioutil.WriteFile(path, []byte(`{"controller_ip":"10.0.0.9","username":"admin","cid":"x"}`), 0600)
+	if cid := cache.Load("10.0.0.1", "admin"); cid != "" {
+		t.Errorf("Load of another controller's session = %q, want none", cid)
+	}
+}
+
+func TestNewSessionCacheExpandsHome(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	cache, err := NewSessionCache("~/.aviatrix/sessions")
+	if err != nil {
+		t.Fatalf("NewSessionCache: %s", err)
+	}
+	if want := filepath.Join(home, ".aviatrix", "sessions"); cache.Dir != want {
+		t.Errorf("Dir = %q, want %q", cache.Dir, want)
+	}
+}
+
+func TestClientWithSessionCache(t *testing.T) {
+	var logins int32
+	server := newLoginCountingServer(&logins)
+	defer server.Close()
+	cache := &SessionCache{Dir: t.TempDir()}
+
+	// The first client logs in and caches its CID; the second reuses it.
+	for i := 0; i < 2; i++ {
+		client, err := NewClientWithSessionCache("admin", "password", server.URL, cache, server.Client())
+		if err != nil {
+			t.Fatalf("NewClientWithSessionCache: %s", err)
+		}
+		if err := client.Call("GET", "list_accounts", nil, nil); err != nil {
+			t.Fatalf("Call: %s", err)
+		}
+	}
+	if logins != 1 {
+		t.Errorf("logged in %d times, want 1", logins)
+	}
+
+	// An expired cached CID is replaced by a new login.
+	cache.Store(server.URL, "admin", "expired")
+	client, err := NewClientWithSessionCache("admin", "password", server.URL, cache, server.Client())
+	if err != nil {
+		t.Fatalf("NewClientWithSessionCache: %s", err)
+	}
+	if err := client.Call("GET", "list_accounts", nil, nil); err != nil {
+		t.Fatalf("Call: %s", err)
+	}
+	if logins != 2 {
+		t.Errorf("logged in %d times, want 2", logins)
+	}
+	if cid := cache.Load(server.URL, "admin"); cid != "cid-2" {
+		t.Errorf("cached CID = %q, want %q", cid, "cid-2")
+	}
+}
+
+// newLoginCountingServer is a controller that hands out CIDs cid-1, cid-2,
+// ... and rejects any other CID as expired.
+func newLoginCountingServer(logins *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("action") == "login" {
+			n := atomic.AddInt32(logins, 1)
+			writeJSON(w, LoginResp{Return: true, CID: "cid-" + string(rune('0'+n))})
+			return
+		}
+		if n := atomic.LoadInt32(logins); r.Form.Get("CID") != "cid-"+string(rune('0'+n)) {
+			writeJSON(w, APIResp{Return: false, Reason: cidExpiredReason})
+			return
+		}
+		writeJSON(w, APIResp{Return: true})
+	}))
+}
@@ -1,14 +1,12 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"log"
-	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
+	"time"
 )
 
 const Phase1AuthDefault = "SHA-1"
@@ -19,6 +17,17 @@ const Phase2DhGroupDefault = "2"
 const Phase2EncryptionDefault = "AES-256-CBC"
 const SslServerPoolDefault = "192.168.44.0/24"
 
+// Algorithm values accepted for custom site2cloud algorithms
+var (
+	Phase1AuthValues       = []string{"SHA-1", "SHA-256", "SHA-384", "SHA-512"}
+	Phase1DhGroupValues    = []string{"1", "2", "5", "14", "15", "16", "17", "18"}
+	Phase1EncryptionValues = []string{"AES-128-CBC", "AES-192-CBC", "AES-256-CBC", "3DES"}
+	Phase2AuthValues       = []string{"HMAC-SHA-1", "HMAC-SHA-256", "HMAC-SHA-384", "HMAC-SHA-512", "NO-AUTH"}
+	Phase2DhGroupValues    = []string{"1", "2", "5", "14", "15", "16", "17", "18"}
+	Phase2EncryptionValues = []string{"AES-128-CBC", "AES-128-GCM-64", "AES-128-GCM-96", "AES-128-GCM-128",
+		"AES-192-CBC", "AES-256-CBC", "3DES", "NULL-ENCR"}
+)
+
 // Site2Cloud simple struct to hold site2cloud details
 type Site2Cloud struct {
 	Action                  string   `form:"action,omitempty"`
@@ -133,99 +142,68 @@ type AlgorithmInfo struct {
 }
 
 func (c *Client) CreateSite2Cloud(site2cloud *Site2Cloud) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for add_site2cloud " + err.Error())
-	}
-	addSite2cloud := url.Values{}
-	addSite2cloud.Add("CID", c.CID)
-	addSite2cloud.Add("action", "add_site2cloud")
-	addSite2cloud.Add("vpc_id", site2cloud.VpcID)
-	addSite2cloud.Add("connection_name", site2cloud.TunnelName)
-	addSite2cloud.Add("connection_type", site2cloud.ConnType)
-	addSite2cloud.Add("remote_gateway_type", site2cloud.RemoteGwType)
-	addSite2cloud.Add("tunnel_type", site2cloud.TunnelType)
-
-	addSite2cloud.Add("ha_enabled", site2cloud.HAEnabled)
-	addSite2cloud.Add("backup_gateway_name", site2cloud.BackupGwName)
-	addSite2cloud.Add("backup_remote_gateway_ip", site2cloud.RemoteGwIP2)
-
-	addSite2cloud.Add("phase1_auth", site2cloud.Phase1Auth)
-	addSite2cloud.Add("phase1_dh_group", site2cloud.Phase1DhGroups)
-	addSite2cloud.Add("phase1_encryption", site2cloud.Phase1Encryption)
-	addSite2cloud.Add("phase2_auth", site2cloud.Phase2Auth)
-	addSite2cloud.Add("phase2_dh_group", site2cloud.Phase2DhGroups)
-	addSite2cloud.Add("phase2_encryption", site2cloud.Phase2Encryption)
+	return c.CreateSite2CloudWithContext(context.Background(), site2cloud)
+}
 
+func (c *Client) CreateSite2CloudWithContext(ctx context.Context, site2cloud *Site2Cloud) error {
+	form := url.Values{}
+	form.Add("vpc_id", site2cloud.VpcID)
+	form.Add("connection_name", site2cloud.TunnelName)
+	form.Add("connection_type", site2cloud.ConnType)
+	form.Add("remote_gateway_type", site2cloud.RemoteGwType)
+	form.Add("tunnel_type", site2cloud.TunnelType)
+	form.Add("ha_enabled", site2cloud.HAEnabled)
+	form.Add("backup_gateway_name", site2cloud.BackupGwName)
+	form.Add("backup_remote_gateway_ip", site2cloud.RemoteGwIP2)
+	form.Add("phase1_auth", site2cloud.Phase1Auth)
+	form.Add("phase1_dh_group", site2cloud.Phase1DhGroups)
+	form.Add("phase1_encryption", site2cloud.Phase1Encryption)
+	form.Add("phase2_auth", site2cloud.Phase2Auth)
+	form.Add("phase2_dh_group", site2cloud.Phase2DhGroups)
+	form.Add("phase2_encryption", site2cloud.Phase2Encryption)
 	if site2cloud.TunnelType == "tcp" {
-		addSite2cloud.Add("ssl_server_pool", site2cloud.SslServerPool)
+		form.Add("ssl_server_pool", site2cloud.SslServerPool)
 	}
-
 	if site2cloud.PrivateRouteEncryption == "true" {
-		addSite2cloud.Add("private_route_encryption", site2cloud.PrivateRouteEncryption)
+		form.Add("private_route_encryption", site2cloud.PrivateRouteEncryption)
 		if len(site2cloud.RouteTableList) != 0 {
 			for i := range site2cloud.RouteTableList {
-				addSite2cloud.Add("route_table_list["+strconv.Itoa(i)+"]", site2cloud.RouteTableList[i])
+				form.Add("route_table_list["+strconv.Itoa(i)+"]", site2cloud.RouteTableList[i])
 			}
 		}
 		latitude := fmt.Sprintf("%f", site2cloud.RemoteGwLatitude)
 		longitude := fmt.Sprintf("%f", site2cloud.RemoteGwLongitude)
-		addSite2cloud.Add("remote_gateway_latitude", latitude)
-		addSite2cloud.Add("remote_gateway_longitude", longitude)
+		form.Add("remote_gateway_latitude", latitude)
+		form.Add("remote_gateway_longitude", longitude)
 		if site2cloud.HAEnabled == "yes" {
 			backupLatitude := fmt.Sprintf("%f", site2cloud.BackupRemoteGwLatitude)
 			backupLongitude := fmt.Sprintf("%f", site2cloud.BackupRemoteGwLongitude)
-			addSite2cloud.Add("remote_gateway_latitude", backupLatitude)
-			addSite2cloud.Add("remote_gateway_longitude", backupLongitude)
+			form.Add("remote_gateway_latitude", backupLatitude)
+			form.Add("remote_gateway_longitude", backupLongitude)
 		}
 	}
-
-	addSite2cloud.Add("primary_cloud_gateway_name", site2cloud.GwName)
-	addSite2cloud.Add("remote_gateway_ip", site2cloud.RemoteGwIP)
-	addSite2cloud.Add("remote_subnet_cidr", site2cloud.RemoteSubnet)
-	addSite2cloud.Add("local_subnet_cidr", site2cloud.LocalSubnet)
-	addSite2cloud.Add("virtual_remote_subnet_cidr", site2cloud.RemoteSubnetVirtual)
-	addSite2cloud.Add("virtual_local_subnet_cidr", site2cloud.LocalSubnetVirtual)
-
-	addSite2cloud.Add("pre_shared_key", site2cloud.PreSharedKey)
-	addSite2cloud.Add("backup_pre_shared_key", site2cloud.BackupPreSharedKey)
-
-	Url.RawQuery = addSite2cloud.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Get add_site2cloud failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_site2cloud failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_site2cloud Get failed: " + data.Reason)
-	}
-	return nil
+	form.Add("primary_cloud_gateway_name", site2cloud.GwName)
+	form.Add("remote_gateway_ip", site2cloud.RemoteGwIP)
+	form.Add("remote_subnet_cidr", site2cloud.RemoteSubnet)
+	form.Add("local_subnet_cidr", site2cloud.LocalSubnet)
+	form.Add("virtual_remote_subnet_cidr", site2cloud.RemoteSubnetVirtual)
+	form.Add("virtual_local_subnet_cidr", site2cloud.LocalSubnetVirtual)
+	form.Add("pre_shared_key", site2cloud.PreSharedKey)
+	form.Add("backup_pre_shared_key", site2cloud.BackupPreSharedKey)
+	return c.CallWithContext(ctx, "GET", "add_site2cloud", form, nil)
 }
 
 func (c *Client) GetSite2Cloud(site2cloud *Site2Cloud) (*Site2Cloud, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_site2cloud_conn") + err.Error())
-	}
-	listSite2CloudConn := url.Values{}
-	listSite2CloudConn.Add("CID", c.CID)
-	listSite2CloudConn.Add("action", "list_site2cloud_conn")
-	listSite2CloudConn.Add("connection_name", site2cloud.TunnelName)
-	Url.RawQuery = listSite2CloudConn.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetSite2CloudWithContext(context.Background(), site2cloud)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_site2cloud_conn failed: " + err.Error())
+func (c *Client) GetSite2CloudWithContext(ctx context.Context, site2cloud *Site2Cloud) (*Site2Cloud, error) {
+	form := map[string]string{
+		"connection_name": site2cloud.TunnelName,
 	}
 	var data Site2CloudResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_site2cloud_conn failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_site2cloud_conn Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_site2cloud_conn", form, &data); err != nil {
+		return nil, err
 	}
 	for i := 0; i < len(data.Results.Connections); i++ {
 		conn := data.Results.Connections[i]
@@ -237,30 +215,21 @@ func (c *Client) GetSite2Cloud(site2cloud *Site2Cloud) (*Site2Cloud, error) {
 }
 
 func (c *Client) GetSite2CloudConnDetail(site2cloud *Site2Cloud) (*Site2Cloud, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for get_site2cloud_conn_detail") + err.Error())
-	}
-	getSite2CloudConnDetail := url.Values{}
-	getSite2CloudConnDetail.Add("CID", c.CID)
-	getSite2CloudConnDetail.Add("action", "get_site2cloud_conn_detail")
-	getSite2CloudConnDetail.Add("conn_name", site2cloud.TunnelName)
-	getSite2CloudConnDetail.Add("vpc_id", site2cloud.VpcID)
-	Url.RawQuery = getSite2CloudConnDetail.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetSite2CloudConnDetailWithContext(context.Background(), site2cloud)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get get_site2cloud_conn_detail failed: " + err.Error())
+func (c *Client) GetSite2CloudConnDetailWithContext(ctx context.Context, site2cloud *Site2Cloud) (*Site2Cloud, error) {
+	form := map[string]string{
+		"conn_name": site2cloud.TunnelName,
+		"vpc_id":    site2cloud.VpcID,
 	}
 	var data Site2CloudConnDetailResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode get_site2cloud_conn_detail failed: " + err.Error())
+	err := c.CallWithContext(ctx, "GET", "get_site2cloud_conn_detail", form, &data)
+	if reasonContains(err, "does not exist") {
+		return nil, ErrNotFound
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "does not exist") {
-			return nil, ErrNotFound
-		}
-		return nil, errors.New("Rest API get_site2cloud_conn_detail Get failed: " + data.Reason)
+	if err != nil {
+		return nil, err
 	}
 
 	s2cConnDetail := data.Results.Connections
@@ -331,131 +300,118 @@ func (c *Client) GetSite2CloudConnDetail(site2cloud *Site2Cloud) (*Site2Cloud, e
 }
 
 func (c *Client) UpdateSite2Cloud(site2cloud *EditSite2Cloud) error {
-	site2cloud.CID = c.CID
-	site2cloud.Action = "edit_site2cloud_conn"
-	resp, err := c.Post(c.baseURL, site2cloud)
-	if err != nil {
-		return errors.New("HTTP Post edit_site2cloud_conn failed: " + err.Error())
-	}
+	return c.UpdateSite2CloudWithContext(context.Background(), site2cloud)
+}
 
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode edit_site2cloud_conn failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API edit_site2cloud_conn Post failed: " + data.Reason)
-	}
-	return nil
+func (c *Client) UpdateSite2CloudWithContext(ctx context.Context, site2cloud *EditSite2Cloud) error {
+	return c.CallWithContext(ctx, "POST", "edit_site2cloud_conn", site2cloud, nil)
 }
 
 func (c *Client) DeleteSite2Cloud(site2cloud *Site2Cloud) error {
-	site2cloud.CID = c.CID
-	site2cloud.Action = "delete_site2cloud_connection"
-	verb := "POST"
-	body := fmt.Sprintf("CID=%s&action=%s&vpc_id=%s&connection_name=%s", c.CID, site2cloud.Action,
-		site2cloud.VpcID, site2cloud.TunnelName)
-
-	log.Printf("[TRACE] %s %s Body: %s", verb, c.baseURL, body)
-	req, err := http.NewRequest(verb, c.baseURL, strings.NewReader(body))
-	if err == nil {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		return errors.New("HTTP Post NewRequest delete_site2cloud_connection failed: " + err.Error())
-	}
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return errors.New("HTTP Post delete_site2cloud_connection failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_site2cloud_connection failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_site2cloud_connection Post failed: " + data.Reason)
+	return c.DeleteSite2CloudWithContext(context.Background(), site2cloud)
+}
+
+func (c *Client) DeleteSite2CloudWithContext(ctx context.Context, site2cloud *Site2Cloud) error {
+	form := map[string]string{
+		"vpc_id":          site2cloud.VpcID,
+		"connection_name": site2cloud.TunnelName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "POST", "delete_site2cloud_connection", form, nil)
 }
 
 func (c *Client) Site2CloudAlgorithmCheck(site2cloud *Site2Cloud) error {
-	Phase1AuthList := []string{"SHA-1", "SHA-256", "SHA-384", "SHA-512"}
-	Phase1DhGroupsList := []string{"1", "2", "5", "14", "15", "16", "17", "18"}
-	Phase1EncryptionList := []string{"AES-128-CBC", "AES-192-CBC", "AES-256-CBC", "3DES"}
-	Phase2AuthList := []string{"HMAC-SHA-1", "HMAC-SHA-256", "HMAC-SHA-384", "HMAC-SHA-512", "NO-AUTH"}
-	Phase2DhGroupsList := []string{"1", "2", "5", "14", "15", "16", "17", "18"}
-	Phase2EncryptionList := []string{"AES-128-CBC", "AES-128-GCM-64", "AES-128-GCM-96", "AES-128-GCM-128",
-		"AES-128-CBC", "AES-192-CBC", "AES-256-CBC", "3DES", "NULL-ENCR"}
-
-	if !Contains(Phase1AuthList, site2cloud.Phase1Auth) {
+	if !Contains(Phase1AuthValues, site2cloud.Phase1Auth) {
 		return errors.New("invalid value for phase_1_authentication")
 	}
-	if !Contains(Phase1DhGroupsList, site2cloud.Phase1DhGroups) {
+	if !Contains(Phase1DhGroupValues, site2cloud.Phase1DhGroups) {
 		return errors.New("invalid value for phase_1_dh_groups")
 	}
-	if !Contains(Phase1EncryptionList, site2cloud.Phase1Encryption) {
+	if !Contains(Phase1EncryptionValues, site2cloud.Phase1Encryption) {
 		return errors.New("invalid value for phase_1_encryption")
 	}
-	if !Contains(Phase2AuthList, site2cloud.Phase2Auth) {
+	if !Contains(Phase2AuthValues, site2cloud.Phase2Auth) {
 		return errors.New("invalid value for phase_2_authentication")
 	}
-	if !Contains(Phase2DhGroupsList, site2cloud.Phase2DhGroups) {
+	if !Contains(Phase2DhGroupValues, site2cloud.Phase2DhGroups) {
 		return errors.New("invalid value for phase_2_dh_groups")
 	}
-	if !Contains(Phase2EncryptionList, site2cloud.Phase2Encryption) {
+	if !Contains(Phase2EncryptionValues, site2cloud.Phase2Encryption) {
 		return errors.New("invalid value for phase_2_encryption")
 	}
 	return nil
 }
 
 func (c *Client) EnableDeadPeerDetection(site2cloud *Site2Cloud) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_dpd_config") + err.Error())
-	}
-	enableDPDConfig := url.Values{}
-	enableDPDConfig.Add("CID", c.CID)
-	enableDPDConfig.Add("action", "enable_dpd_config")
-	enableDPDConfig.Add("vpc_id", site2cloud.VpcID)
-	enableDPDConfig.Add("connection_name", site2cloud.TunnelName)
-
-	Url.RawQuery = enableDPDConfig.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableDeadPeerDetectionWithContext(context.Background(), site2cloud)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_dpd_config failed: " + err.Error())
+func (c *Client) EnableDeadPeerDetectionWithContext(ctx context.Context, site2cloud *Site2Cloud) error {
+	form := map[string]string{
+		"vpc_id":          site2cloud.VpcID,
+		"connection_name": site2cloud.TunnelName,
 	}
 	var data VGWConnEnableAdvertiseTransitCidrResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_dpd_config failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_dpd_config Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "enable_dpd_config", form, &data); err != nil {
+		return err
 	}
 	return nil
 }
 
 func (c *Client) DisableDeadPeerDetection(site2cloud *Site2Cloud) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_dpd_config") + err.Error())
-	}
-	disableDPDConfig := url.Values{}
-	disableDPDConfig.Add("CID", c.CID)
-	disableDPDConfig.Add("action", "disable_dpd_config")
-	disableDPDConfig.Add("vpc_id", site2cloud.VpcID)
-	disableDPDConfig.Add("connection_name", site2cloud.TunnelName)
-
-	Url.RawQuery = disableDPDConfig.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DisableDeadPeerDetectionWithContext(context.Background(), site2cloud)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_dpd_config failed: " + err.Error())
+func (c *Client) DisableDeadPeerDetectionWithContext(ctx context.Context, site2cloud *Site2Cloud) error {
+	form := map[string]string{
+		"vpc_id":          site2cloud.VpcID,
+		"connection_name": site2cloud.TunnelName,
 	}
 	var data VGWConnEnableAdvertiseTransitCidrResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_dpd_config failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_dpd_config Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "disable_dpd_config", form, &data); err != nil {
+		return err
 	}
 	return nil
 }
+
+// Site2cloud tunnel states reported in TunnelInfo.Status
+const (
+	Site2CloudTunnelUp   = "up"
+	Site2CloudTunnelDown = "down"
+)
+
+func (c *Client) WaitForSite2CloudTunnel(site2cloud *Site2Cloud, statuses []string, timeout time.Duration) error {
+	return c.WaitForSite2CloudTunnelWithContext(context.Background(), site2cloud, statuses, timeout)
+}
+
+// WaitForSite2CloudTunnelWithContext waits until the tunnel of the
+// connection on site2cloud.GwName reports one of statuses. The tunnel has
+// no status until the controller has set it up on the gateway.
+func (c *Client) WaitForSite2CloudTunnelWithContext(ctx context.Context, site2cloud *Site2Cloud, statuses []string, timeout time.Duration) error {
+	form := map[string]string{
+		"conn_name": site2cloud.TunnelName,
+		"vpc_id":    site2cloud.VpcID,
+	}
+	w := &Waiter{
+		Description: "site2cloud connection " + site2cloud.TunnelName,
+		Target:      statuses,
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			var data Site2CloudConnDetailResp
+			err := c.CallWithContext(ctx, "GET", "get_site2cloud_conn_detail", form, &data)
+			if reasonContains(err, "does not exist") {
+				return "", ErrNotFound
+			}
+			if err != nil {
+				return "", err
+			}
+			for _, tunnel := range data.Results.Connections.Tunnels {
+				if tunnel.GwName == site2cloud.GwName {
+					return tunnel.Status, nil
+				}
+			}
+			return "", nil
+		},
+	}
+	_, err := w.Wait(ctx)
+	return err
+}
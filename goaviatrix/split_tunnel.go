@@ -1,9 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"net/url"
+	"context"
 )
 
 type SplitTunnel struct {
@@ -33,59 +31,35 @@ type SplitTunnelUnit struct {
 }
 
 func (c *Client) GetSplitTunnel(splitTunnel *SplitTunnel) (*SplitTunnelUnit, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for modify_split_tunnel") + err.Error())
-	}
-	modifySplitTunnel := url.Values{}
-	modifySplitTunnel.Add("CID", c.CID)
-	modifySplitTunnel.Add("action", "modify_split_tunnel")
-	modifySplitTunnel.Add("command", "get")
-	modifySplitTunnel.Add("vpc_id", splitTunnel.VpcID)
-	modifySplitTunnel.Add("lb_name", splitTunnel.ElbName)
-	Url.RawQuery = modifySplitTunnel.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetSplitTunnelWithContext(context.Background(), splitTunnel)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get modify_split_tunnel(get) failed: " + err.Error())
+func (c *Client) GetSplitTunnelWithContext(ctx context.Context, splitTunnel *SplitTunnel) (*SplitTunnelUnit, error) {
+	form := map[string]string{
+		"command": "get",
+		"vpc_id":  splitTunnel.VpcID,
+		"lb_name": splitTunnel.ElbName,
 	}
 	var data SplitTunnelResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode modify_split_tunnel(get) failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API modify_split_tunnel(get) Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "modify_split_tunnel", form, &data); err != nil {
+		return nil, err
 	}
 	return &data.Results, nil
 }
 
 func (c *Client) ModifySplitTunnel(splitTunnel *SplitTunnel) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for modify_split_tunnel") + err.Error())
-	}
-	modifySplitTunnel := url.Values{}
-	modifySplitTunnel.Add("CID", c.CID)
-	modifySplitTunnel.Add("action", "modify_split_tunnel")
-	modifySplitTunnel.Add("command", "modify")
-	modifySplitTunnel.Add("vpc_id", splitTunnel.VpcID)
-	modifySplitTunnel.Add("lb_name", splitTunnel.ElbName)
-	modifySplitTunnel.Add("split_tunnel", splitTunnel.SplitTunnel)
-	modifySplitTunnel.Add("additional_cidrs", splitTunnel.AdditionalCidrs)
-	modifySplitTunnel.Add("nameservers", splitTunnel.NameServers)
-	modifySplitTunnel.Add("search_domains", splitTunnel.SearchDomains)
-	Url.RawQuery = modifySplitTunnel.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.ModifySplitTunnelWithContext(context.Background(), splitTunnel)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get modify_split_tunnel(modify) failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode modify_split_tunnel(modify) failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API modify_split_tunnel(modify) Get failed: " + data.Reason)
+func (c *Client) ModifySplitTunnelWithContext(ctx context.Context, splitTunnel *SplitTunnel) error {
+	form := map[string]string{
+		"command":          "modify",
+		"vpc_id":           splitTunnel.VpcID,
+		"lb_name":          splitTunnel.ElbName,
+		"split_tunnel":     splitTunnel.SplitTunnel,
+		"additional_cidrs": splitTunnel.AdditionalCidrs,
+		"nameservers":      splitTunnel.NameServers,
+		"search_domains":   splitTunnel.SearchDomains,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "modify_split_tunnel", form, nil)
 }
@@ -1,12 +1,10 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
-	"log"
-	"net/http"
+	"context"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -26,37 +24,28 @@ type TagAPIResp struct {
 	Reason  string                       `json:"reason"`
 }
 
+// GetDefaultTags returns the tags merged into those of every taggable
+// resource
+func (c *Client) GetDefaultTags() map[string]string {
+	return c.DefaultTags
+}
+
 func (c *Client) AddTags(tags *Tags) error {
-	tags.CID = c.CID
-	tags.Action = "add_resource_tags"
-	resp, err := c.Post(c.baseURL, tags)
-	if err != nil {
-		return errors.New("HTTP Post add_resource_tags failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_resource_tags failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_resource_tags Post failed: " + data.Reason)
-	}
-	return nil
+	return c.AddTagsWithContext(context.Background(), tags)
+}
+
+func (c *Client) AddTagsWithContext(ctx context.Context, tags *Tags) error {
+	return c.CallWithContext(ctx, "POST", "add_resource_tags", tags, nil)
 }
 
 func (c *Client) GetTags(tags *Tags) ([]string, error) {
-	tags.CID = c.CID
-	tags.Action = "list_resource_tags"
-	resp, err := c.Post(c.baseURL, tags)
-	if err != nil {
-		return nil, errors.New("HTTP Post list_resource_tags failed: " + err.Error())
-	}
+	return c.GetTagsWithContext(context.Background(), tags)
+}
 
+func (c *Client) GetTagsWithContext(ctx context.Context, tags *Tags) ([]string, error) {
 	var data TagAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_resource_tags failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_resource_tags Post failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "POST", "list_resource_tags", tags, &data); err != nil {
+		return nil, err
 	}
 
 	var tagList []string
@@ -84,28 +73,45 @@ func (c *Client) GetTags(tags *Tags) ([]string, error) {
 }
 
 func (c *Client) DeleteTags(tags *Tags) error {
-	tags.CID = c.CID
-	tags.Action = "delete_resource_tags"
-	verb := "POST"
-	body := fmt.Sprintf("CID=%s&action=%s&cloud_type=%d&resource_type=%s&resource_name=%s&del_tag_list=%s",
-		c.CID, tags.Action, tags.CloudType, tags.ResourceType, tags.ResourceName, tags.TagList)
-	log.Printf("[TRACE] %s %s Body: %s", verb, c.baseURL, body)
-	req, err := http.NewRequest(verb, c.baseURL, strings.NewReader(body))
-	if err == nil {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	} else {
-		return errors.New("HTTP New Request Post delete_resource_tags failed: " + err.Error())
+	return c.DeleteTagsWithContext(context.Background(), tags)
+}
+
+func (c *Client) DeleteTagsWithContext(ctx context.Context, tags *Tags) error {
+	form := map[string]string{
+		"cloud_type":    strconv.Itoa(tags.CloudType),
+		"resource_type": tags.ResourceType,
+		"resource_name": tags.ResourceName,
+		"del_tag_list":  tags.TagList,
 	}
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return errors.New("HTTP Post delete_resource_tags failed: " + err.Error())
+	return c.CallWithContext(ctx, "POST", "delete_resource_tags", form, nil)
+}
+
+// TagListToMap converts a list of "key:value" tags, as GetTags returns them,
+// to a map
+func TagListToMap(tagList []string) map[string]string {
+	tags := make(map[string]string, len(tagList))
+	for _, tag := range tagList {
+		kv := strings.SplitN(tag, ":", 2)
+		if len(kv) == 2 {
+			tags[kv[0]] = kv[1]
+		} else {
+			tags[kv[0]] = ""
+		}
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_resource_tags failed: " + err.Error())
+	return tags
+}
+
+// TagMapToList converts a map of tags to a list of "key:value" tags, sorted
+// by key
+func TagMapToList(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
 	}
-	if !data.Return {
-		return errors.New("Rest API delete_resource_tags Post failed: " + data.Reason)
+	sort.Strings(keys)
+	tagList := make([]string, 0, len(keys))
+	for _, k := range keys {
+		tagList = append(tagList, k+":"+tags[k])
 	}
-	return nil
+	return tagList
 }
@@ -0,0 +1,109 @@
+package goaviatrix
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+// TLSOptions controls how the client authenticates the controller's
+// certificate and, optionally, itself.
+type TLSOptions struct {
+	// VerifySSL verifies the controller certificate chain and host name.
+	VerifySSL bool
+	// CABundle is a PEM string or the path of a PEM file with the CAs
+	// trusted in addition to the system pool.
+	CABundle string
+	// Fingerprint pins the controller's leaf certificate to this hex
+	// SHA-256 digest. Colons are ignored. It is checked even when
+	// VerifySSL is false.
+	Fingerprint string
+	// ClientCert and ClientKey are PEM strings or file paths of a client
+	// certificate to present to the controller.
+	ClientCert string
+	ClientKey  string
+}
+
+// TLSConfig builds the tls.Config described by the options. With the zero
+// value it keeps the historic behaviour of skipping verification, and logs a
+// warning saying so.
+func (o *TLSOptions) TLSConfig() (*tls.Config, error) {
+	cfg := &tls.Config{}
+
+	if o.CABundle != "" {
+		pem, err := readPEM(o.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %s", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("failed to read CA bundle: no PEM certificates found")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if o.ClientCert != "" || o.ClientKey != "" {
+		certPEM, err := readPEM(o.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %s", err)
+		}
+		keyPEM, err := readPEM(o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %s", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %s", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if o.Fingerprint != "" {
+		want, err := hex.DecodeString(strings.ReplaceAll(o.Fingerprint, ":", ""))
+		if err != nil || len(want) != sha256.Size {
+			return nil, fmt.Errorf("invalid SHA-256 certificate fingerprint %q", o.Fingerprint)
+		}
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("controller presented no certificate")
+			}
+			got := sha256.Sum256(rawCerts[0])
+			if !bytes.Equal(got[:], want) {
+				return fmt.Errorf("controller certificate fingerprint %x does not match the pinned fingerprint", got)
+			}
+			return nil
+		}
+	}
+
+	if !o.VerifySSL {
+		// The pin, if any, is still enforced by VerifyPeerCertificate.
+		cfg.InsecureSkipVerify = true
+		if o.Fingerprint == "" {
+			log.Printf("[WARN] TLS certificate verification of the Aviatrix controller is disabled; " +
+				"set verify_ssl or a certificate fingerprint to authenticate the controller")
+		}
+	}
+	return cfg, nil
+}
+
+// readPEM returns s itself if it holds PEM data, otherwise the contents of
+// the file named by s.
+func readPEM(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("no PEM data or file path given")
+	}
+	if strings.Contains(s, "-----BEGIN") {
+		return []byte(s), nil
+	}
+	return ioutil.ReadFile(s)
+}
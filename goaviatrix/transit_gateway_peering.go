@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 )
 
 type TransitGatewayPeering struct {
@@ -19,51 +17,25 @@ type TransitGatewayPeeringAPIResp struct {
 }
 
 func (c *Client) CreateTransitGatewayPeering(transitGatewayPeering *TransitGatewayPeering) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for create_inter_transit_gateway_peering ") + err.Error())
-	}
-	createInterTransitGwPeering := url.Values{}
-	createInterTransitGwPeering.Add("CID", c.CID)
-	createInterTransitGwPeering.Add("action", "create_inter_transit_gateway_peering")
-	createInterTransitGwPeering.Add("gateway1", transitGatewayPeering.TransitGatewayName1)
-	createInterTransitGwPeering.Add("gateway2", transitGatewayPeering.TransitGatewayName2)
-	Url.RawQuery = createInterTransitGwPeering.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateTransitGatewayPeeringWithContext(context.Background(), transitGatewayPeering)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get create_inter_transit_gateway_peering failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_inter_transit_gateway_peering failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API create_inter_transit_gateway_peering Get failed: " + data.Reason)
+func (c *Client) CreateTransitGatewayPeeringWithContext(ctx context.Context, transitGatewayPeering *TransitGatewayPeering) error {
+	form := map[string]string{
+		"gateway1": transitGatewayPeering.TransitGatewayName1,
+		"gateway2": transitGatewayPeering.TransitGatewayName2,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "create_inter_transit_gateway_peering", form, nil)
 }
 
 func (c *Client) GetTransitGatewayPeering(transitGatewayPeering *TransitGatewayPeering) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for list_inter_transit_gateway_peering ") + err.Error())
-	}
-	listInterTransitGwPeering := url.Values{}
-	listInterTransitGwPeering.Add("CID", c.CID)
-	listInterTransitGwPeering.Add("action", "list_inter_transit_gateway_peering")
-	Url.RawQuery = listInterTransitGwPeering.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetTransitGatewayPeeringWithContext(context.Background(), transitGatewayPeering)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get list_inter_transit_gateway_peering failed: " + err.Error())
-	}
+func (c *Client) GetTransitGatewayPeeringWithContext(ctx context.Context, transitGatewayPeering *TransitGatewayPeering) error {
 	var data TransitGatewayPeeringAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode list_inter_transit_gateway_peering failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API list_inter_transit_gateway_peering Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_inter_transit_gateway_peering", nil, &data); err != nil {
+		return err
 	}
 	if len(data.Results) == 0 {
 		log.Printf("Transit gateway peering with gateways %s and %s not found",
@@ -92,27 +64,13 @@ func (c *Client) UpdateTransitGatewayPeering(transitGatewayPeering *TransitGatew
 }
 
 func (c *Client) DeleteTransitGatewayPeering(transitGatewayPeering *TransitGatewayPeering) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_inter_transit_gateway_peering ") + err.Error())
-	}
-	deleteInterTransitGwPeering := url.Values{}
-	deleteInterTransitGwPeering.Add("CID", c.CID)
-	deleteInterTransitGwPeering.Add("action", "delete_inter_transit_gateway_peering")
-	deleteInterTransitGwPeering.Add("gateway1", transitGatewayPeering.TransitGatewayName1)
-	deleteInterTransitGwPeering.Add("gateway2", transitGatewayPeering.TransitGatewayName2)
-	Url.RawQuery = deleteInterTransitGwPeering.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteTransitGatewayPeeringWithContext(context.Background(), transitGatewayPeering)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_inter_transit_gateway_peering failed: " + err.Error())
+func (c *Client) DeleteTransitGatewayPeeringWithContext(ctx context.Context, transitGatewayPeering *TransitGatewayPeering) error {
+	form := map[string]string{
+		"gateway1": transitGatewayPeering.TransitGatewayName1,
+		"gateway2": transitGatewayPeering.TransitGatewayName2,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_inter_transit_gateway_peering failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_inter_transit_gateway_peering Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "delete_inter_transit_gateway_peering", form, nil)
 }
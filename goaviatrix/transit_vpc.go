@@ -1,11 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
-	"strings"
 )
 
 // Gateway simple struct to hold gateway details
@@ -46,203 +43,99 @@ type TransitGwFireNetInterfacesResp struct {
 }
 
 func (c *Client) LaunchTransitVpc(gateway *TransitVpc) error {
-	gateway.CID = c.CID
-	gateway.Action = "create_transit_gw"
-	resp, err := c.Post(c.baseURL, gateway)
-	if err != nil {
-		return errors.New("HTTP Post create_transit_gw failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_transit_gw failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API create_transit_gw Post failed: " + data.Reason)
-	}
-	return nil
+	return c.LaunchTransitVpcWithContext(context.Background(), gateway)
+}
+
+func (c *Client) LaunchTransitVpcWithContext(ctx context.Context, gateway *TransitVpc) error {
+	return c.CallWithContext(ctx, "POST", "create_transit_gw", gateway, nil)
 }
 
 func (c *Client) EnableHaTransitVpc(gateway *TransitVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New("url Parsing failed for enable_transit_ha " + err.Error())
-	}
-	enableTransitHa := url.Values{}
-	enableTransitHa.Add("CID", c.CID)
-	enableTransitHa.Add("action", "enable_transit_ha")
-	enableTransitHa.Add("gw_name", gateway.GwName)
-	enableTransitHa.Add("public_subnet", gateway.HASubnet)
-	Url.RawQuery = enableTransitHa.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableHaTransitVpcWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_transit_ha failed: " + err.Error())
+func (c *Client) EnableHaTransitVpcWithContext(ctx context.Context, gateway *TransitVpc) error {
+	form := map[string]string{
+		"gw_name":       gateway.GwName,
+		"public_subnet": gateway.HASubnet,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_transit_ha failed: " + err.Error())
+	err := c.CallWithContext(ctx, "GET", "enable_transit_ha", form, nil)
+	if reasonContains(err, "HA GW already exists") {
+		log.Printf("[INFO] HA is already enabled %s", err)
+		return nil
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "HA GW already exists") {
-			log.Printf("[INFO] HA is already enabled %s", data.Reason)
-			return nil
-		}
-		log.Printf("[ERROR] Enabling HA failed with error %s", data.Reason)
-
-		return errors.New("Rest API enable_transit_ha Get failed: " + data.Reason)
+	if err != nil {
+		log.Printf("[ERROR] Enabling HA failed with error %s", err)
 	}
-	return nil
+	return err
 }
 
 func (c *Client) AttachTransitGWForHybrid(gateway *TransitVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_transit_gateway_interface_to_aws_tgw ") + err.Error())
-	}
-	enableTransitGatewayInterfaceToAwsTgw := url.Values{}
-	enableTransitGatewayInterfaceToAwsTgw.Add("CID", c.CID)
-	enableTransitGatewayInterfaceToAwsTgw.Add("action", "enable_transit_gateway_interface_to_aws_tgw")
-	enableTransitGatewayInterfaceToAwsTgw.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = enableTransitGatewayInterfaceToAwsTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.AttachTransitGWForHybridWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_transit_gateway_interface_to_aws_tgw failed: " + err.Error())
+func (c *Client) AttachTransitGWForHybridWithContext(ctx context.Context, gateway *TransitVpc) error {
+	form := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		if strings.Contains(err.Error(), "already enabled tgw interface") {
-			return nil
-		}
-		return errors.New("Json Decode enable_transit_gateway_interface_to_aws_tgw failed: " + err.Error())
+	err := c.CallWithContext(ctx, "GET", "enable_transit_gateway_interface_to_aws_tgw", form, nil)
+	if reasonContains(err, "already enabled tgw interface") {
+		return nil
 	}
-	if !data.Return {
-		return errors.New("Rest API enable_transit_gateway_interface_to_aws_tgw Get failed: " + data.Reason)
-	}
-	return nil
+	return err
 }
 
 func (c *Client) DetachTransitGWForHybrid(gateway *TransitVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_transit_gateway_interface_to_aws_tgw") + err.Error())
-	}
-	disableTransitGatewayInterfaceToAwsTgw := url.Values{}
-	disableTransitGatewayInterfaceToAwsTgw.Add("CID", c.CID)
-	disableTransitGatewayInterfaceToAwsTgw.Add("action", "disable_transit_gateway_interface_to_aws_tgw")
-	disableTransitGatewayInterfaceToAwsTgw.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = disableTransitGatewayInterfaceToAwsTgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DetachTransitGWForHybridWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_transit_gateway_interface_to_aws_tgw failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_transit_gateway_interface_to_aws_tgw failed: " + err.Error())
+func (c *Client) DetachTransitGWForHybridWithContext(ctx context.Context, gateway *TransitVpc) error {
+	form := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	if !data.Return {
-		return errors.New("Rest API disable_transit_gateway_interface_to_aws_tgw Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "disable_transit_gateway_interface_to_aws_tgw", form, nil)
 }
 
 func (c *Client) EnableConnectedTransit(gateway *TransitVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_connected_transit_on_gateway") + err.Error())
-	}
-	enableConnectedTransitOnGateway := url.Values{}
-	enableConnectedTransitOnGateway.Add("CID", c.CID)
-	enableConnectedTransitOnGateway.Add("action", "enable_connected_transit_on_gateway")
-	enableConnectedTransitOnGateway.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = enableConnectedTransitOnGateway.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableConnectedTransitWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_connected_transit_on_gateway failed: " + err.Error())
+func (c *Client) EnableConnectedTransitWithContext(ctx context.Context, gateway *TransitVpc) error {
+	form := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_connected_transit_on_gateway failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_connected_transit_on_gateway Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "enable_connected_transit_on_gateway", form, nil)
 }
 
 func (c *Client) DisableConnectedTransit(gateway *TransitVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_connected_transit_on_gateway") + err.Error())
-	}
-	disableConnectedTransitOnGateway := url.Values{}
-	disableConnectedTransitOnGateway.Add("CID", c.CID)
-	disableConnectedTransitOnGateway.Add("action", "disable_connected_transit_on_gateway")
-	disableConnectedTransitOnGateway.Add("gateway_name", gateway.GwName)
-	Url.RawQuery = disableConnectedTransitOnGateway.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DisableConnectedTransitWithContext(context.Background(), gateway)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_connected_transit_on_gateway failed: " + err.Error())
+func (c *Client) DisableConnectedTransitWithContext(ctx context.Context, gateway *TransitVpc) error {
+	form := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_connected_transit_on_gateway failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_connected_transit_on_gateway Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "disable_connected_transit_on_gateway", form, nil)
 }
 
 func (c *Client) EnableGatewayFireNetInterfaces(gateway *TransitVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_gateway_firenet_interfaces") + err.Error())
-	}
-	disableConnectedTransitOnGateway := url.Values{}
-	disableConnectedTransitOnGateway.Add("CID", c.CID)
-	disableConnectedTransitOnGateway.Add("action", "enable_gateway_firenet_interfaces")
-	disableConnectedTransitOnGateway.Add("gateway_name", gateway.GwName)
+	return c.EnableGatewayFireNetInterfacesWithContext(context.Background(), gateway)
+}
 
-	Url.RawQuery = disableConnectedTransitOnGateway.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Post enable_gateway_firenet_interfaces failed: " + err.Error())
+func (c *Client) EnableGatewayFireNetInterfacesWithContext(ctx context.Context, gateway *TransitVpc) error {
+	form := map[string]string{
+		"gateway_name": gateway.GwName,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_gateway_firenet_interfaces failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_gateway_firenet_interfaces Post failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "enable_gateway_firenet_interfaces", form, nil)
 }
 
 func (c *Client) DisableGatewayFireNetInterfaces(gateway *TransitVpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_gateway_firenet_interfaces") + err.Error())
-	}
-	disableConnectedTransitOnGateway := url.Values{}
-	disableConnectedTransitOnGateway.Add("CID", c.CID)
-	disableConnectedTransitOnGateway.Add("action", "disable_gateway_firenet_interfaces")
-	disableConnectedTransitOnGateway.Add("gateway", gateway.GwName)
+	return c.DisableGatewayFireNetInterfacesWithContext(context.Background(), gateway)
+}
 
-	Url.RawQuery = disableConnectedTransitOnGateway.Encode()
-	resp, err := c.Get(Url.String(), nil)
-	if err != nil {
-		return errors.New("HTTP Post disable_gateway_firenet_interfaces failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_gateway_firenet_interfaces failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_gateway_firenet_interfaces Post failed: " + data.Reason)
+func (c *Client) DisableGatewayFireNetInterfacesWithContext(ctx context.Context, gateway *TransitVpc) error {
+	form := map[string]string{
+		"gateway": gateway.GwName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "disable_gateway_firenet_interfaces", form, nil)
 }
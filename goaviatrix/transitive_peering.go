@@ -1,8 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
 	//"github.com/davecgh/go-spew/spew"
 )
@@ -24,35 +23,21 @@ type TransPeerListResp struct {
 }
 
 func (c *Client) CreateTransPeer(transPeer *TransPeer) error {
-	transPeer.CID = c.CID
-	transPeer.Action = "add_extended_vpc_peer"
-	resp, err := c.Post(c.baseURL, transPeer)
-	if err != nil {
-		return errors.New("HTTP Post add_extended_vpc_peer failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_extended_vpc_peer failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API add_extended_vpc_peer Post failed: " + data.Reason)
-	}
-	return nil
+	return c.CreateTransPeerWithContext(context.Background(), transPeer)
+}
+
+func (c *Client) CreateTransPeerWithContext(ctx context.Context, transPeer *TransPeer) error {
+	return c.CallWithContext(ctx, "POST", "add_extended_vpc_peer", transPeer, nil)
 }
 
 func (c *Client) GetTransPeer(transPeer *TransPeer) (*TransPeer, error) {
-	transPeer.CID = c.CID
-	transPeer.Action = "list_extended_vpc_peer"
-	resp, err := c.Post(c.baseURL, transPeer)
-	if err != nil {
-		return nil, errors.New("HTTP Post list_extended_vpc_peer failed: " + err.Error())
-	}
+	return c.GetTransPeerWithContext(context.Background(), transPeer)
+}
+
+func (c *Client) GetTransPeerWithContext(ctx context.Context, transPeer *TransPeer) (*TransPeer, error) {
 	var data TransPeerListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_extended_vpc_peer failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_extended_vpc_peer Post failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "POST", "list_extended_vpc_peer", transPeer, &data); err != nil {
+		return nil, err
 	}
 	transPeerList := data.Results
 	for i := range transPeerList {
@@ -70,18 +55,9 @@ func (c *Client) UpdateTransPeer(transPeer *TransPeer) error {
 }
 
 func (c *Client) DeleteTransPeer(transPeer *TransPeer) error {
-	transPeer.CID = c.CID
-	transPeer.Action = "delete_extended_vpc_peer"
-	resp, err := c.Post(c.baseURL, transPeer)
-	if err != nil {
-		return errors.New("HTTP Post delete_extended_vpc_peer failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_extended_vpc_peer failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_extended_vpc_peer Post failed: " + data.Reason)
-	}
-	return nil
+	return c.DeleteTransPeerWithContext(context.Background(), transPeer)
+}
+
+func (c *Client) DeleteTransPeerWithContext(ctx context.Context, transPeer *TransPeer) error {
+	return c.CallWithContext(ctx, "POST", "delete_extended_vpc_peer", transPeer, nil)
 }
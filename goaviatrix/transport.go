@@ -0,0 +1,49 @@
+package goaviatrix
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ProxyFunc returns the proxy selection for a transport: proxyURL for every
+// request if it is set, otherwise the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables.
+func ProxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %s", proxyURL, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", proxyURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: no host", proxyURL)
+	}
+	return http.ProxyURL(u), nil
+}
+
+// HeaderTransport adds fixed headers, such as those a reverse proxy in
+// front of the controller requires, to every request.
+type HeaderTransport struct {
+	Headers   map[string]string
+	Transport http.RoundTripper
+}
+
+func (t *HeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	for k, v := range t.Headers {
+		req.Header.Set(k, v)
+	}
+	next := t.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(req)
+}
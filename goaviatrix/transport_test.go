@@ -0,0 +1,50 @@
+package goaviatrix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProxyFunc(t *testing.T) {
+	req := httptest.NewRequest("POST", "https://10.0.0.1/v1/api", nil)
+	proxy, err := ProxyFunc("http://proxy.example.com:3128")
+	if err != nil {
+		t.Fatalf("ProxyFunc: %s", err)
+	}
+	if u, err := proxy(req); err != nil || u.String() != "http://proxy.example.com:3128" {
+		t.Errorf("proxy = %v, %v, want http://proxy.example.com:3128", u, err)
+	}
+
+	for _, in := range []string{"ftp://proxy.example.com", "http://", "://proxy"} {
+		if _, err := ProxyFunc(in); err == nil {
+			t.Errorf("ProxyFunc(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+func TestHeaderTransport(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+		writeJSON(w, APIResp{Return: true})
+	}))
+	defer server.Close()
+
+	transport := &HeaderTransport{
+		Headers:   map[string]string{"X-Api-Key": "k1", "X-Tenant": "t1"},
+		Transport: server.Client().Transport,
+	}
+	client, err := NewClientWithCID("", "", "test-cid", server.URL, &http.Client{Transport: transport})
+	if err != nil {
+		t.Fatalf("NewClientWithCID: %s", err)
+	}
+	if err := client.Call("GET", "list_accounts", nil, nil); err != nil {
+		t.Fatalf("Call: %s", err)
+	}
+	for k, v := range transport.Headers {
+		if got.Get(k) != v {
+			t.Errorf("header %s = %q, want %q", k, got.Get(k), v)
+		}
+	}
+}
@@ -3,10 +3,8 @@ package goaviatrix
 // Tunnel simple struct to hold tunnel details
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 )
 
 type Tunnel struct {
@@ -29,52 +27,26 @@ type TunnelListResp struct {
 }
 
 func (c *Client) CreateTunnel(tunnel *Tunnel) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for peer_vpc_pair ") + err.Error())
-	}
-	peerVpcPair := url.Values{}
-	peerVpcPair.Add("CID", c.CID)
-	peerVpcPair.Add("action", "peer_vpc_pair")
-	peerVpcPair.Add("vpc_name1", tunnel.VpcName1)
-	peerVpcPair.Add("vpc_name2", tunnel.VpcName2)
-	peerVpcPair.Add("ha_enabled", tunnel.EnableHA)
-	Url.RawQuery = peerVpcPair.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateTunnelWithContext(context.Background(), tunnel)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get peer_vpc_pair failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode peer_vpc_pair failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API peer_vpc_pair Get failed: " + data.Reason)
+func (c *Client) CreateTunnelWithContext(ctx context.Context, tunnel *Tunnel) error {
+	form := map[string]string{
+		"vpc_name1":  tunnel.VpcName1,
+		"vpc_name2":  tunnel.VpcName2,
+		"ha_enabled": tunnel.EnableHA,
 	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "peer_vpc_pair", form, nil)
 }
 
 func (c *Client) GetTunnel(tunnel *Tunnel) (*Tunnel, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_peer_vpc_pairs ") + err.Error())
-	}
-	listPeerVpcPairs := url.Values{}
-	listPeerVpcPairs.Add("CID", c.CID)
-	listPeerVpcPairs.Add("action", "list_peer_vpc_pairs")
-	Url.RawQuery = listPeerVpcPairs.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetTunnelWithContext(context.Background(), tunnel)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_peer_vpc_pairs failed: " + err.Error())
-	}
+func (c *Client) GetTunnelWithContext(ctx context.Context, tunnel *Tunnel) (*Tunnel, error) {
 	var data TunnelListResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_peer_vpc_pairs failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_peer_vpc_pairs Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_peer_vpc_pairs", nil, &data); err != nil {
+		return nil, err
 	}
 	tunList := data.Results.PairList
 	for i := range tunList {
@@ -92,27 +64,13 @@ func (c *Client) UpdateTunnel(tunnel *Tunnel) error {
 }
 
 func (c *Client) DeleteTunnel(tunnel *Tunnel) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for unpeer_vpc_pair ") + err.Error())
-	}
-	unPeerVpcPair := url.Values{}
-	unPeerVpcPair.Add("CID", c.CID)
-	unPeerVpcPair.Add("action", "unpeer_vpc_pair")
-	unPeerVpcPair.Add("vpc_name1", tunnel.VpcName1)
-	unPeerVpcPair.Add("vpc_name2", tunnel.VpcName2)
-	Url.RawQuery = unPeerVpcPair.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteTunnelWithContext(context.Background(), tunnel)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get unpeer_vpc_pair failed: " + err.Error())
+func (c *Client) DeleteTunnelWithContext(ctx context.Context, tunnel *Tunnel) error {
+	form := map[string]string{
+		"vpc_name1": tunnel.VpcName1,
+		"vpc_name2": tunnel.VpcName2,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode unpeer_vpc_pair failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API unpeer_vpc_pair Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "unpeer_vpc_pair", form, nil)
 }
@@ -1,13 +1,12 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -44,62 +43,27 @@ type AviatrixVersion struct {
 }
 
 func (c *Client) Upgrade(version *Version) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for upgrade") + err.Error())
-	}
-	attachSpokeToTransitGw := url.Values{}
-	attachSpokeToTransitGw.Add("CID", c.CID)
-	attachSpokeToTransitGw.Add("action", "upgrade")
+	return c.UpgradeWithContext(context.Background(), version)
+}
 
+func (c *Client) UpgradeWithContext(ctx context.Context, version *Version) error {
+	form := map[string]string{}
 	if version.Version == "" {
 		return errors.New("no target version is set")
 	} else if version.Version != "latest" {
-		attachSpokeToTransitGw.Add("version", version.Version)
+		form["version"] = version.Version
 	}
-	for i := 0; ; i++ {
-		Url.RawQuery = attachSpokeToTransitGw.Encode()
-		resp, err := c.Get(Url.String(), nil)
-		if err != nil {
-			return errors.New("HTTP Get upgrade failed: " + err.Error())
-		}
-		var data UpgradeResp
-		if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return errors.New("Json Decode upgrade failed: " + err.Error())
-		}
-		if !data.Return {
-			if strings.Contains(data.Reason, "Active upgrade in progress.") && i < 3 {
-				log.Printf("[INFO] Active upgrade is in progress. Retry after 60 secs...")
-				time.Sleep(60 * time.Second)
-				continue
-			}
-			return errors.New("Rest API upgrade Get failed: " + data.Reason)
-		}
-		break
-	}
-	return nil
+	return c.CallWithContext(withRetryPolicy(ctx, upgradeRetryPolicy()), "GET", "upgrade", form, nil)
 }
 
 func (c *Client) GetCurrentVersion() (string, *AviatrixVersion, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return "", nil, errors.New(("url Parsing failed for list_version_info") + err.Error())
-	}
-	listVersionInfo := url.Values{}
-	listVersionInfo.Add("CID", c.CID)
-	listVersionInfo.Add("action", "list_version_info")
-	Url.RawQuery = listVersionInfo.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetCurrentVersionWithContext(context.Background())
+}
 
-	if err != nil {
-		return "", nil, errors.New("HTTP Get list_version_info failed: " + err.Error())
-	}
+func (c *Client) GetCurrentVersionWithContext(ctx context.Context) (string, *AviatrixVersion, error) {
 	var data VersionInfoResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", nil, errors.New("Json Decode list_version_info failed: " + err.Error())
-	}
-	if !data.Return {
-		return "", nil, errors.New("Rest API list_version_info Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_version_info", nil, &data); err != nil {
+		return "", nil, err
 	}
 
 	curVersion, aVer, err := ParseVersion(data.Results.CurrentVersion)
@@ -111,54 +75,54 @@ func (c *Client) GetCurrentVersion() (string, *AviatrixVersion, error) {
 }
 
 func (c *Client) Pre32Upgrade() error {
-	privateBaseURL := strings.Replace(c.baseURL, "/v1/api", "/v1/backend1", 1)
+	return c.Pre32UpgradeWithContext(context.Background())
+}
+
+func (c *Client) Pre32UpgradeWithContext(ctx context.Context) error {
 	params := &Version{
 		Action: "userconnect_release",
-		CID:    c.CID,
+		CID:    c.GetCID(),
+	}
+	if err := c.checkMutation("POST", "userconnect_release", params); err != nil {
+		return err
 	}
-	path := privateBaseURL
-	for i := 0; ; i++ {
-		resp, err := c.Post(path, params)
+	path := c.backendURL
+	return c.retry(withRetryPolicy(ctx, upgradeRetryPolicy()), "userconnect_release", func() error {
+		req := c.beforeRequest("POST", "userconnect_release")
+		resp, err := c.RequestWithContext(ctx, "POST", path, params)
 		if err != nil {
-			return errors.New("HTTP Post userconnect_release failed: " + err.Error())
+			c.afterResponse(req, nil, nil, err)
+			return fmt.Errorf("HTTP Post userconnect_release failed: %w", err)
 		}
 		defer resp.Body.Close()
-		if resp.StatusCode == http.StatusOK {
-			body, _ := ioutil.ReadAll(resp.Body)
-			log.Printf("[TRACE] response %s", body)
-			if strings.Contains(string(body), "in progress") && i < 3 {
-				log.Printf("[INFO] Active upgrade is in progress. Retry after 60 secs...")
-				time.Sleep(60 * time.Second)
-			} else {
-				break
-			}
-		} else {
-			return fmt.Errorf("status code %d", resp.StatusCode)
+		if resp.StatusCode != http.StatusOK {
+			err = newAPIError("userconnect_release", resp.StatusCode, "")
+			c.afterResponse(req, resp, nil, err)
+			return err
 		}
-	}
-	return nil
+		body, _ := ioutil.ReadAll(resp.Body)
+		log.Printf("[TRACE] response %s", body)
+		if strings.Contains(string(body), "in progress") {
+			err = newAPIError("userconnect_release", resp.StatusCode, "Active upgrade in progress.")
+			c.afterResponse(req, resp, &APIResp{Reason: "Active upgrade in progress."}, err)
+			return err
+		}
+		c.afterResponse(req, resp, &APIResp{Return: true}, nil)
+		if c.Cache != nil {
+			c.Cache.Invalidate()
+		}
+		return nil
+	})
 }
 
 func (c *Client) GetLatestVersion() (string, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return "", errors.New(("url Parsing failed for list_version_info") + err.Error())
-	}
-	listVersionInfo := url.Values{}
-	listVersionInfo.Add("CID", c.CID)
-	listVersionInfo.Add("action", "list_version_info")
-	Url.RawQuery = listVersionInfo.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetLatestVersionWithContext(context.Background())
+}
 
-	if err != nil {
-		return "", errors.New("HTTP Get list_version_info failed: " + err.Error())
-	}
+func (c *Client) GetLatestVersionWithContext(ctx context.Context) (string, error) {
 	var data VersionInfoResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return "", errors.New("Json Decode list_version_info failed: " + err.Error())
-	}
-	if !data.Return {
-		return "", errors.New("Rest API list_version_info Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_version_info", nil, &data); err != nil {
+		return "", err
 	}
 
 	latestVersion, _, err := ParseVersion(data.Results.LatestVersion)
@@ -198,3 +162,48 @@ func ParseVersion(version string) (string, *AviatrixVersion, error) {
 	}
 	return strconv.FormatInt(aver.Major, 10) + "." + strconv.FormatInt(aver.Minor, 10), aver, nil
 }
+
+func (c *Client) WaitForVersion(version string, timeout time.Duration) error {
+	return c.WaitForVersionWithContext(context.Background(), version, timeout)
+}
+
+// WaitForVersionWithContext waits until an upgrade has brought the
+// controller to version, or to the latest release when version is
+// "latest". The controller may not answer while it upgrades, so failed
+// requests only end the wait when the controller rejected them.
+func (c *Client) WaitForVersionWithContext(ctx context.Context, version string, timeout time.Duration) error {
+	w := &Waiter{
+		Description: "controller upgrade to " + version,
+		Target:      []string{"upgraded"},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			var data VersionInfoResp
+			err := c.CallWithContext(ctx, "GET", "list_version_info", nil, &data)
+			if err != nil && !isRejection(err) && ctx.Err() == nil {
+				log.Printf("[DEBUG] Controller is not answering during upgrade: %s", err)
+				return "unavailable", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			_, current, err := ParseVersion(data.Results.CurrentVersion)
+			if err != nil {
+				return "", err
+			}
+			target := version
+			if target == "latest" {
+				target = data.Results.LatestVersion
+			}
+			_, want, err := ParseVersion(target)
+			if err != nil {
+				return "", err
+			}
+			if current.Compare(*want) >= 0 {
+				return "upgraded", nil
+			}
+			return "upgrading", nil
+		},
+	}
+	_, err := w.Wait(ctx)
+	return err
+}
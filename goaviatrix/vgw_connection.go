@@ -1,9 +1,7 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
-	"net/url"
+	"context"
 )
 
 // VGWConn simple struct to hold VGW Connection details
@@ -64,59 +62,32 @@ type VGWConnBgpManualSpokeAdvertisedNetworksResp struct {
 }
 
 func (c *Client) CreateVGWConn(vgwConn *VGWConn) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for connect_transit_gw_to_vgw") + err.Error())
-	}
-	connectTransitGwToVgw := url.Values{}
-	connectTransitGwToVgw.Add("CID", c.CID)
-	connectTransitGwToVgw.Add("action", "connect_transit_gw_to_vgw")
-	connectTransitGwToVgw.Add("vpc_id", vgwConn.VPCId)
-	connectTransitGwToVgw.Add("connection_name", vgwConn.ConnName)
-	connectTransitGwToVgw.Add("transit_gw", vgwConn.GwName)
-	connectTransitGwToVgw.Add("vgw_id", vgwConn.BgpVGWId)
-	connectTransitGwToVgw.Add("bgp_local_as_number", vgwConn.BgpLocalAsNum)
-	Url.RawQuery = connectTransitGwToVgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateVGWConnWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get connect_transit_gw_to_vgw failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode connect_transit_gw_to_vgw failed: " + err.Error())
+func (c *Client) CreateVGWConnWithContext(ctx context.Context, vgwConn *VGWConn) error {
+	form := map[string]string{
+		"vpc_id":              vgwConn.VPCId,
+		"connection_name":     vgwConn.ConnName,
+		"transit_gw":          vgwConn.GwName,
+		"vgw_id":              vgwConn.BgpVGWId,
+		"bgp_local_as_number": vgwConn.BgpLocalAsNum,
 	}
-	if !data.Return {
-		return errors.New("Rest API connect_transit_gw_to_vgw Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "connect_transit_gw_to_vgw", form, nil)
 }
 
 func (c *Client) GetVGWConn(vgwConn *VGWConn) (*VGWConn, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_vgw_connections") + err.Error())
-	}
-	listVgwConnections := url.Values{}
-	listVgwConnections.Add("CID", c.CID)
-	listVgwConnections.Add("action", "list_vgw_connections")
-	Url.RawQuery = listVgwConnections.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetVGWConnWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vgw_connections failed: " + err.Error())
-	}
+func (c *Client) GetVGWConnWithContext(ctx context.Context, vgwConn *VGWConn) (*VGWConn, error) {
 	data := VGWConnListResp{
 		Return:  false,
 		Results: make([]string, 0),
 		Reason:  "",
 	}
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vgw_connections failed: " + err.Error())
-	}
-
-	if !data.Return {
-		return nil, errors.New("Rest API list_vgw_connections Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_vgw_connections", nil, &data); err != nil {
+		return nil, err
 	}
 
 	vgwConnList := data.Results
@@ -133,53 +104,29 @@ func (c *Client) UpdateVGWConn(vgwConn *VGWConn) error {
 }
 
 func (c *Client) DeleteVGWConn(vgwConn *VGWConn) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disconnect_transit_gw_from_vgw") + err.Error())
-	}
-	disconnectTransitGwFromVgw := url.Values{}
-	disconnectTransitGwFromVgw.Add("CID", c.CID)
-	disconnectTransitGwFromVgw.Add("action", "disconnect_transit_gw_from_vgw")
-	disconnectTransitGwFromVgw.Add("vpc_id", vgwConn.VPCId)
-	disconnectTransitGwFromVgw.Add("connection_name", vgwConn.ConnName)
-	Url.RawQuery = disconnectTransitGwFromVgw.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteVGWConnWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disconnect_transit_gw_from_vgw failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disconnect_transit_gw_from_vgw failed: " + err.Error())
+func (c *Client) DeleteVGWConnWithContext(ctx context.Context, vgwConn *VGWConn) error {
+	form := map[string]string{
+		"vpc_id":          vgwConn.VPCId,
+		"connection_name": vgwConn.ConnName,
 	}
-	if !data.Return {
-		return errors.New("Rest API disconnect_transit_gw_from_vgw Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "disconnect_transit_gw_from_vgw", form, nil)
 }
 
 func (c *Client) GetVGWConnDetail(vgwConn *VGWConn) (*VGWConn, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for get_site2cloud_conn_detail") + err.Error())
-	}
-	listVgwConnections := url.Values{}
-	listVgwConnections.Add("CID", c.CID)
-	listVgwConnections.Add("action", "get_site2cloud_conn_detail")
-	listVgwConnections.Add("vpc_id", vgwConn.VPCId)
-	listVgwConnections.Add("conn_name", vgwConn.ConnName)
-	Url.RawQuery = listVgwConnections.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetVGWConnDetailWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get get_site2cloud_conn_detail failed: " + err.Error())
+func (c *Client) GetVGWConnDetailWithContext(ctx context.Context, vgwConn *VGWConn) (*VGWConn, error) {
+	form := map[string]string{
+		"vpc_id":    vgwConn.VPCId,
+		"conn_name": vgwConn.ConnName,
 	}
 	var data VGWConnDetailResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode get_site2cloud_conn_detail failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API get_site2cloud_conn_detail Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "get_site2cloud_conn_detail", form, &data); err != nil {
+		return nil, err
 	}
 
 	if data.Results.Connections.ConnName[0] != "" {
@@ -210,110 +157,66 @@ func (c *Client) GetVGWConnDetail(vgwConn *VGWConn) (*VGWConn, error) {
 }
 
 func (c *Client) EnableAdvertiseTransitCidr(vgwConn *VGWConn) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for enable_advertise_transit_cidr") + err.Error())
-	}
-	enableAdvertiseTransitCidr := url.Values{}
-	enableAdvertiseTransitCidr.Add("CID", c.CID)
-	enableAdvertiseTransitCidr.Add("action", "enable_advertise_transit_cidr")
-	enableAdvertiseTransitCidr.Add("vpc_id", vgwConn.VPCId)
-	enableAdvertiseTransitCidr.Add("connection_name", vgwConn.ConnName)
-
-	Url.RawQuery = enableAdvertiseTransitCidr.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.EnableAdvertiseTransitCidrWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get enable_advertise_transit_cidr failed: " + err.Error())
+func (c *Client) EnableAdvertiseTransitCidrWithContext(ctx context.Context, vgwConn *VGWConn) error {
+	form := map[string]string{
+		"vpc_id":          vgwConn.VPCId,
+		"connection_name": vgwConn.ConnName,
 	}
 	var data VGWConnEnableAdvertiseTransitCidrResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode enable_advertise_transit_cidr failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API enable_advertise_transit_cidr Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "enable_advertise_transit_cidr", form, &data); err != nil {
+		return err
 	}
 	return nil
 }
 
 func (c *Client) DisableAdvertiseTransitCidr(vgwConn *VGWConn) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_advertise_transit_cidr") + err.Error())
-	}
-	disableAdvertiseTransitCidr := url.Values{}
-	disableAdvertiseTransitCidr.Add("CID", c.CID)
-	disableAdvertiseTransitCidr.Add("action", "disable_advertise_transit_cidr")
-	disableAdvertiseTransitCidr.Add("vpc_id", vgwConn.VPCId)
-	disableAdvertiseTransitCidr.Add("connection_name", vgwConn.ConnName)
-
-	Url.RawQuery = disableAdvertiseTransitCidr.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DisableAdvertiseTransitCidrWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_advertise_transit_cidr failed: " + err.Error())
+func (c *Client) DisableAdvertiseTransitCidrWithContext(ctx context.Context, vgwConn *VGWConn) error {
+	form := map[string]string{
+		"vpc_id":          vgwConn.VPCId,
+		"connection_name": vgwConn.ConnName,
 	}
 	var data VGWConnEnableAdvertiseTransitCidrResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_advertise_transit_cidr failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_advertise_transit_cidr Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "disable_advertise_transit_cidr", form, &data); err != nil {
+		return err
 	}
 	return nil
 }
 
 func (c *Client) SetBgpManualSpokeAdvertisedNetworks(vgwConn *VGWConn) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for set_bgp_manual_spoke_advertised_networks") + err.Error())
-	}
-	setBgpManualSpokeAdvertisedNetworks := url.Values{}
-	setBgpManualSpokeAdvertisedNetworks.Add("CID", c.CID)
-	setBgpManualSpokeAdvertisedNetworks.Add("action", "set_bgp_manual_spoke_advertised_networks")
-	setBgpManualSpokeAdvertisedNetworks.Add("vpc_id", vgwConn.VPCId)
-	setBgpManualSpokeAdvertisedNetworks.Add("connection_name", vgwConn.ConnName)
-	setBgpManualSpokeAdvertisedNetworks.Add("cidr", vgwConn.BgpManualSpokeAdvertiseCidrs)
-
-	Url.RawQuery = setBgpManualSpokeAdvertisedNetworks.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.SetBgpManualSpokeAdvertisedNetworksWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get set_bgp_manual_spoke_advertised_networks failed: " + err.Error())
+func (c *Client) SetBgpManualSpokeAdvertisedNetworksWithContext(ctx context.Context, vgwConn *VGWConn) error {
+	form := map[string]string{
+		"vpc_id":          vgwConn.VPCId,
+		"connection_name": vgwConn.ConnName,
+		"cidr":            vgwConn.BgpManualSpokeAdvertiseCidrs,
 	}
 	var data VGWConnBgpManualSpokeAdvertisedNetworksResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode set_bgp_manual_spoke_advertised_networks failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API set_bgp_manual_spoke_advertised_networks Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "set_bgp_manual_spoke_advertised_networks", form, &data); err != nil {
+		return err
 	}
 	return nil
 }
 
 func (c *Client) DisableBgpManualSpokeAdvertisedNetworks(vgwConn *VGWConn) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for disable_bgp_manual_spoke_advertised_networks") + err.Error())
-	}
-	disableBgpManualSpokeAdvertisedNetworks := url.Values{}
-	disableBgpManualSpokeAdvertisedNetworks.Add("CID", c.CID)
-	disableBgpManualSpokeAdvertisedNetworks.Add("action", "disable_bgp_manual_spoke_advertised_networks")
-	disableBgpManualSpokeAdvertisedNetworks.Add("vpc_id", vgwConn.VPCId)
-	disableBgpManualSpokeAdvertisedNetworks.Add("connection_name", vgwConn.ConnName)
-
-	Url.RawQuery = disableBgpManualSpokeAdvertisedNetworks.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DisableBgpManualSpokeAdvertisedNetworksWithContext(context.Background(), vgwConn)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get disable_bgp_manual_spoke_advertised_networks failed: " + err.Error())
+func (c *Client) DisableBgpManualSpokeAdvertisedNetworksWithContext(ctx context.Context, vgwConn *VGWConn) error {
+	form := map[string]string{
+		"vpc_id":          vgwConn.VPCId,
+		"connection_name": vgwConn.ConnName,
 	}
 	var data VGWConnBgpManualSpokeAdvertisedNetworksResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode disable_bgp_manual_spoke_advertised_networks failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API disable_bgp_manual_spoke_advertised_networks Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "disable_bgp_manual_spoke_advertised_networks", form, &data); err != nil {
+		return err
 	}
 	return nil
 }
@@ -1,10 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"log"
-	"net/url"
 	"strconv"
 )
 
@@ -48,57 +46,30 @@ type SubnetInfo struct {
 }
 
 func (c *Client) CreateVpc(vpc *Vpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for create_custom_vpc ") + err.Error())
-	}
-	createCustomVpc := url.Values{}
-	createCustomVpc.Add("CID", c.CID)
-	createCustomVpc.Add("action", "create_custom_vpc")
-	createCustomVpc.Add("cloud_type", strconv.Itoa(vpc.CloudType))
-	createCustomVpc.Add("account_name", vpc.AccountName)
-	createCustomVpc.Add("region", vpc.Region)
-	createCustomVpc.Add("pool_name", vpc.Name)
-	createCustomVpc.Add("vpc_cidr", vpc.Cidr)
-	createCustomVpc.Add("aviatrix_transit_vpc", vpc.AviatrixTransitVpc)
-	createCustomVpc.Add("aviatrix_firenet_vpc", vpc.AviatrixFireNetVpc)
-
-	Url.RawQuery = createCustomVpc.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateVpcWithContext(context.Background(), vpc)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get create_custom_vpc failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode create_custom_vpc failed: " + err.Error())
+func (c *Client) CreateVpcWithContext(ctx context.Context, vpc *Vpc) error {
+	form := map[string]string{
+		"cloud_type":           strconv.Itoa(vpc.CloudType),
+		"account_name":         vpc.AccountName,
+		"region":               vpc.Region,
+		"pool_name":            vpc.Name,
+		"vpc_cidr":             vpc.Cidr,
+		"aviatrix_transit_vpc": vpc.AviatrixTransitVpc,
+		"aviatrix_firenet_vpc": vpc.AviatrixFireNetVpc,
 	}
-	if !data.Return {
-		return errors.New("Rest API create_custom_vpc Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "create_custom_vpc", form, nil)
 }
 
 func (c *Client) GetVpc(vpc *Vpc) (*Vpc, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for list_custom_vpcs ") + err.Error())
-	}
-	listPeerVpcPairs := url.Values{}
-	listPeerVpcPairs.Add("CID", c.CID)
-	listPeerVpcPairs.Add("action", "list_custom_vpcs")
-	Url.RawQuery = listPeerVpcPairs.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetVpcWithContext(context.Background(), vpc)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_custom_vpcs failed: " + err.Error())
-	}
+func (c *Client) GetVpcWithContext(ctx context.Context, vpc *Vpc) (*Vpc, error) {
 	var data VpcResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_custom_vpcs failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_custom_vpcs Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "GET", "list_custom_vpcs", nil, &data); err != nil {
+		return nil, err
 	}
 	allVpcPoolVpcListResp := data.Results.AllVpcPoolVpcList
 	for i := range allVpcPoolVpcListResp {
@@ -134,27 +105,13 @@ func (c *Client) UpdateVpc(vpc *Vpc) error {
 }
 
 func (c *Client) DeleteVpc(vpc *Vpc) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for delete_custom_vpc ") + err.Error())
-	}
-	createCustomVpc := url.Values{}
-	createCustomVpc.Add("CID", c.CID)
-	createCustomVpc.Add("action", "delete_custom_vpc")
-	createCustomVpc.Add("account_name", vpc.AccountName)
-	createCustomVpc.Add("pool_name", vpc.Name)
-	Url.RawQuery = createCustomVpc.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.DeleteVpcWithContext(context.Background(), vpc)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get delete_custom_vpc failed: " + err.Error())
+func (c *Client) DeleteVpcWithContext(ctx context.Context, vpc *Vpc) error {
+	form := map[string]string{
+		"account_name": vpc.AccountName,
+		"pool_name":    vpc.Name,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_custom_vpc failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_custom_vpc Get failed: " + data.Reason)
-	}
-	return nil
+	return c.CallWithContext(ctx, "GET", "delete_custom_vpc", form, nil)
 }
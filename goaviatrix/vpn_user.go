@@ -1,11 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
-	"net/url"
-	"strings"
 )
 
 // VPNUser simple struct to hold vpn_user details
@@ -36,62 +33,39 @@ type VPNUserInfo struct {
 }
 
 func (c *Client) CreateVPNUser(vpnUser *VPNUser) error {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return errors.New(("url Parsing failed for add_vpn_user") + err.Error())
-	}
-	addVpnUser := url.Values{}
-	addVpnUser.Add("CID", c.CID)
-	addVpnUser.Add("action", "add_vpn_user")
-	addVpnUser.Add("vpc_id", vpnUser.VpcID)
-	addVpnUser.Add("username", vpnUser.UserName)
-	addVpnUser.Add("user_email", vpnUser.UserEmail)
-	addVpnUser.Add("lb_name", vpnUser.GwName)
-	addVpnUser.Add("saml_endpoint", vpnUser.SamlEndpoint)
-	Url.RawQuery = addVpnUser.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.CreateVPNUserWithContext(context.Background(), vpnUser)
+}
 
-	if err != nil {
-		return errors.New("HTTP Get add_vpn_user failed: " + err.Error())
+func (c *Client) CreateVPNUserWithContext(ctx context.Context, vpnUser *VPNUser) error {
+	form := map[string]string{
+		"vpc_id":        vpnUser.VpcID,
+		"username":      vpnUser.UserName,
+		"user_email":    vpnUser.UserEmail,
+		"lb_name":       vpnUser.GwName,
+		"saml_endpoint": vpnUser.SamlEndpoint,
 	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode add_vpn_user failed: " + err.Error())
+	err := c.CallWithContext(ctx, "GET", "add_vpn_user", form, nil)
+	if reasonContains(err, "Sending VPN certificates to email") {
+		return nil
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "Sending VPN certificates to email") {
-			return nil
-		}
-		return errors.New("Rest API add_vpn_user Get failed: " + data.Reason)
-	}
-	return nil
+	return err
 }
 
 func (c *Client) GetVPNUser(vpnUser *VPNUser) (*VPNUser, error) {
-	Url, err := url.Parse(c.baseURL)
-	if err != nil {
-		return nil, errors.New(("url Parsing failed for get_vpn_user_by_name") + err.Error())
-	}
-	getVpnUserByName := url.Values{}
-	getVpnUserByName.Add("CID", c.CID)
-	getVpnUserByName.Add("action", "get_vpn_user_by_name")
-	getVpnUserByName.Add("username", vpnUser.UserName)
-
-	Url.RawQuery = getVpnUserByName.Encode()
-	resp, err := c.Get(Url.String(), nil)
+	return c.GetVPNUserWithContext(context.Background(), vpnUser)
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get get_vpn_user_by_name failed: " + err.Error())
+func (c *Client) GetVPNUserWithContext(ctx context.Context, vpnUser *VPNUser) (*VPNUser, error) {
+	form := map[string]string{
+		"username": vpnUser.UserName,
 	}
 	var data VPNUserResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode get_vpn_user_by_name failed: " + err.Error())
+	err := c.CallWithContext(ctx, "GET", "get_vpn_user_by_name", form, &data)
+	if reasonContains(err, "Invalid VPN username") {
+		return nil, ErrNotFound
 	}
-	if !data.Return {
-		if strings.Contains(data.Reason, "Invalid VPN username") {
-			return nil, ErrNotFound
-		}
-		return nil, errors.New("Rest API get_vpn_user_by_name Get failed: " + data.Reason)
+	if err != nil {
+		return nil, err
 	}
 
 	if data.Results.VpnUser.UserName != "" {
@@ -106,19 +80,13 @@ func (c *Client) GetVPNUser(vpnUser *VPNUser) (*VPNUser, error) {
 }
 
 func (c *Client) DeleteVPNUser(vpnUser *VPNUser) error {
-	vpnUser.Action = "delete_vpn_user"
-	path := c.baseURL + fmt.Sprintf("?CID=%s&action=%s&vpc_id=%s&username=%s", c.CID, vpnUser.Action,
-		vpnUser.VpcID, vpnUser.UserName)
-	resp, err := c.Delete(path, nil)
-	if err != nil {
-		return errors.New("HTTP Get delete_vpn_user failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode delete_vpn_user failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API delete_vpn_user Get failed: " + data.Reason)
+	return c.DeleteVPNUserWithContext(context.Background(), vpnUser)
+}
+
+func (c *Client) DeleteVPNUserWithContext(ctx context.Context, vpnUser *VPNUser) error {
+	form := map[string]string{
+		"vpc_id":   vpnUser.VpcID,
+		"username": vpnUser.UserName,
 	}
-	return nil
+	return c.CallWithContext(ctx, "DELETE", "delete_vpn_user", form, nil)
 }
@@ -1,8 +1,8 @@
 package goaviatrix
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
+	"time"
 )
 
 type VpnUserXlr struct {
@@ -21,20 +21,14 @@ type VpnUserXlrAPIResp struct {
 }
 
 func (c *Client) GetVpnUserAccelerator() ([]string, error) {
-	xlr := VpnUserXlr{}
-	xlr.CID = c.CID
-	xlr.Action = "list_vpn_user_xlr"
-	resp, err := c.Post(c.baseURL, xlr)
+	return c.GetVpnUserAcceleratorWithContext(context.Background())
+}
 
-	if err != nil {
-		return nil, errors.New("HTTP Get list_vpn_user_xlr failed: " + err.Error())
-	}
+func (c *Client) GetVpnUserAcceleratorWithContext(ctx context.Context) ([]string, error) {
+	xlr := VpnUserXlr{}
 	var data VpnUserXlrAPIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, errors.New("Json Decode list_vpn_user_xlr failed: " + err.Error())
-	}
-	if !data.Return {
-		return nil, errors.New("Rest API list_vpn_user_xlr Get failed: " + data.Reason)
+	if err := c.CallWithContext(ctx, "POST", "list_vpn_user_xlr", xlr, &data); err != nil {
+		return nil, err
 	}
 
 	elbList := make([]string, 0)
@@ -43,19 +37,36 @@ func (c *Client) GetVpnUserAccelerator() ([]string, error) {
 }
 
 func (c *Client) UpdateVpnUserAccelerator(xlr *VpnUserXlr) error {
-	xlr.CID = c.CID
-	xlr.Action = "update_vpn_user_xlr"
-	resp, err := c.Post(c.baseURL, xlr)
+	return c.UpdateVpnUserAcceleratorWithContext(context.Background(), xlr)
+}
 
-	if err != nil {
-		return errors.New("HTTP Post update_vpn_user_xlr failed: " + err.Error())
-	}
-	var data APIResp
-	if err = json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return errors.New("Json Decode update_vpn_user_xlr failed: " + err.Error())
-	}
-	if !data.Return {
-		return errors.New("Rest API update_vpn_user_xlr Get failed: " + data.Reason)
+func (c *Client) UpdateVpnUserAcceleratorWithContext(ctx context.Context, xlr *VpnUserXlr) error {
+	return c.CallWithContext(ctx, "POST", "update_vpn_user_xlr", xlr, nil)
+}
+
+func (c *Client) WaitForVpnUserAcceleratorElb(elb string, timeout time.Duration) error {
+	return c.WaitForVpnUserAcceleratorElbWithContext(context.Background(), elb, timeout)
+}
+
+// WaitForVpnUserAcceleratorElbWithContext waits until elb is one of the load
+// balancers the VPN user accelerator can use. A newly created ELB takes a
+// while to be listed.
+func (c *Client) WaitForVpnUserAcceleratorElbWithContext(ctx context.Context, elb string, timeout time.Duration) error {
+	w := &Waiter{
+		Description: "ELB " + elb,
+		Target:      []string{"available"},
+		Timeout:     timeout,
+		Refresh: func(ctx context.Context) (string, error) {
+			var data VpnUserXlrAPIResp
+			if err := c.CallWithContext(ctx, "POST", "list_vpn_user_xlr", VpnUserXlr{}, &data); err != nil {
+				return "", err
+			}
+			if Contains(data.Results["all"], elb) {
+				return "available", nil
+			}
+			return "pending", nil
+		},
 	}
-	return nil
+	_, err := w.Wait(ctx)
+	return err
 }
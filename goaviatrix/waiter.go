@@ -0,0 +1,83 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// DefaultPollInterval is how often a Waiter refreshes when PollInterval is
+// not set.
+const DefaultPollInterval = 10 * time.Second
+
+// ErrWaitTimeout is returned by Waiter.Wait when the target state is not
+// reached within the timeout.
+var ErrWaitTimeout = errors.New("timeout while waiting for state")
+
+// StateRefreshFunc returns the current state of the object being waited on
+type StateRefreshFunc func(ctx context.Context) (string, error)
+
+// Waiter polls Refresh until it returns one of the Target states. If
+// Pending is set, any state that is neither pending nor a target is an
+// error. Transient controller errors are retried on the next poll; any
+// other error ends the wait.
+type Waiter struct {
+	// Description names what is being waited for in logs and errors
+	Description  string
+	Refresh      StateRefreshFunc
+	Pending      []string
+	Target       []string
+	PollInterval time.Duration
+	// Timeout bounds the whole wait. Zero means only ctx bounds it.
+	Timeout time.Duration
+}
+
+// Wait polls until a target state is reached and returns it. The first
+// refresh happens immediately.
+func (w *Waiter) Wait(ctx context.Context) (string, error) {
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	state := ""
+	for {
+		current, err := w.Refresh(ctx)
+		switch {
+		case err == nil:
+			state = current
+			if Contains(w.Target, state) {
+				return state, nil
+			}
+			if len(w.Pending) > 0 && !Contains(w.Pending, state) {
+				return state, fmt.Errorf("unexpected state %q while waiting for %s, wanted %v", state, w.Description, w.Target)
+			}
+			log.Printf("[DEBUG] Waiting for %s: state is %q, wanted %v", w.Description, state, w.Target)
+		case IsTransient(err) && ctx.Err() == nil:
+			log.Printf("[DEBUG] Waiting for %s: %s", w.Description, err)
+		default:
+			if ctx.Err() == context.DeadlineExceeded {
+				return state, w.timeoutError(state)
+			}
+			return state, err
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			if err == context.DeadlineExceeded {
+				return state, w.timeoutError(state)
+			}
+			return state, err
+		}
+	}
+}
+
+func (w *Waiter) timeoutError(state string) error {
+	return fmt.Errorf("%w %v of %s after %s (last state %q)", ErrWaitTimeout, w.Target, w.Description, w.Timeout, state)
+}
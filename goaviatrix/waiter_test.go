@@ -0,0 +1,153 @@
+package goaviatrix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stateSequence returns a StateRefreshFunc answering with states, then
+// errs, in turn, repeating the last answer, and a pointer to the number of
+// refreshes.
+func stateSequence(states []string, errs []error) (StateRefreshFunc, *int) {
+	calls := 0
+	return func(ctx context.Context) (string, error) {
+		i := calls
+		calls++
+		if i >= len(states) {
+			i = len(states) - 1
+		}
+		var err error
+		if i < len(errs) {
+			err = errs[i]
+		}
+		return states[i], err
+	}, &calls
+}
+
+func TestWaiterReachesTarget(t *testing.T) {
+	busy := newAPIError("get_gateway_info", http.StatusOK, "Controller is busy")
+	refresh, calls := stateSequence(
+		[]string{"", "waiting", "waiting", "up"},
+		[]error{busy, nil, nil, nil},
+	)
+	w := &Waiter{
+		Description:  "gateway gw1",
+		Refresh:      refresh,
+		Pending:      []string{"waiting"},
+		Target:       []string{"up", "running"},
+		PollInterval: time.Millisecond,
+	}
+	state, err := w.Wait(context.Background())
+	if err != nil || state != "up" {
+		t.Errorf("Wait = %q, %v, want up", state, err)
+	}
+	if *calls != 4 {
+		t.Errorf("refreshed %d times, want 4", *calls)
+	}
+}
+
+func TestWaiterUnexpectedState(t *testing.T) {
+	refresh, _ := stateSequence([]string{"waiting", "error"}, nil)
+	w := &Waiter{
+		Description:  "gateway gw1",
+		Refresh:      refresh,
+		Pending:      []string{"waiting"},
+		Target:       []string{"up"},
+		PollInterval: time.Millisecond,
+	}
+	state, err := w.Wait(context.Background())
+	if state != "error" || err == nil || !strings.Contains(err.Error(), `unexpected state "error"`) {
+		t.Errorf("Wait = %q, %v, want an unexpected state error", state, err)
+	}
+
+	// Without Pending any state keeps the waiter polling.
+	refresh, calls := stateSequence([]string{"waiting", "error", "up"}, nil)
+	w = &Waiter{Refresh: refresh, Target: []string{"up"}, PollInterval: time.Millisecond}
+	if state, err := w.Wait(context.Background()); err != nil || state != "up" || *calls != 3 {
+		t.Errorf("Wait = %q, %v after %d refreshes, want up after 3", state, err, *calls)
+	}
+}
+
+func TestWaiterStopsOnError(t *testing.T) {
+	notFound := newAPIError("get_gateway_info", http.StatusOK, "Gateway gw1 does not exist")
+	refresh, calls := stateSequence([]string{""}, []error{notFound})
+	w := &Waiter{Refresh: refresh, Target: []string{"up"}, PollInterval: time.Millisecond}
+	if _, err := w.Wait(context.Background()); !IsNotFound(err) {
+		t.Errorf("Wait err = %v, want the refresh error", err)
+	}
+	if *calls != 1 {
+		t.Errorf("refreshed %d times, want 1", *calls)
+	}
+}
+
+func TestWaiterTimeout(t *testing.T) {
+	refresh, _ := stateSequence([]string{"waiting"}, nil)
+	w := &Waiter{
+		Description:  "gateway gw1",
+		Refresh:      refresh,
+		Target:       []string{"up"},
+		PollInterval: 5 * time.Millisecond,
+		Timeout:      30 * time.Millisecond,
+	}
+	state, err := w.Wait(context.Background())
+	if !errors.Is(err, ErrWaitTimeout) || state != "waiting" {
+		t.Errorf("Wait = %q, %v, want %v in state waiting", state, err, ErrWaitTimeout)
+	}
+
+	// A deadline on ctx is reported the same way.
+	w.Timeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if _, err := w.Wait(ctx); !errors.Is(err, ErrWaitTimeout) {
+		t.Errorf("Wait err = %v, want %v", err, ErrWaitTimeout)
+	}
+}
+
+func TestWaiterCanceled(t *testing.T) {
+	refresh, _ := stateSequence([]string{"waiting"}, nil)
+	w := &Waiter{Refresh: refresh, Target: []string{"up"}, PollInterval: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if _, err := w.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWaitForGatewayReady(t *testing.T) {
+	cases := []struct {
+		instState, vpcState string
+		wantErr             error
+		wantState           string
+	}{
+		{"running", "up", nil, ""},
+		{"terminated", "down", nil, `unexpected state "terminated"`},
+		{"stopped", "down", nil, `unexpected state "stopped"`},
+		{"pending", "down", ErrWaitTimeout, ""},
+		{"running", "waiting", ErrWaitTimeout, ""},
+	}
+	for _, tc := range cases {
+		client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, GatewayListResp{Return: true, Results: []Gateway{
+				{GwName: "gw1", InstState: tc.instState, VpcState: tc.vpcState},
+			}})
+		})
+		err := client.WaitForGatewayReady("gw1", 30*time.Millisecond)
+		server.Close()
+		switch {
+		case tc.wantErr != nil:
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("%s/%s: err = %v, want %v", tc.instState, tc.vpcState, err, tc.wantErr)
+			}
+		case tc.wantState != "":
+			if err == nil || !strings.Contains(err.Error(), tc.wantState) {
+				t.Errorf("%s/%s: err = %v, want %s", tc.instState, tc.vpcState, err, tc.wantState)
+			}
+		case err != nil:
+			t.Errorf("%s/%s: err = %v, want none", tc.instState, tc.vpcState, err)
+		}
+	}
+}
@@ -9,4 +9,6 @@ func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: aviatrix.Provider,
 	})
+	// Serve returns once Terraform has shut the provider down.
+	aviatrix.LogCallSummary()
 }